@@ -4,21 +4,73 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 
 	"hash_interface/internal/cluster"
 	"hash_interface/internal/models"
 	"hash_interface/internal/models/response"
+	"hash_interface/tools"
 )
 
 // Naver LABS internal Server "http://10.113.93.194:8001"
 
+// namespace : 설정된 경우 모든 데이터 요청에 X-Namespace 헤더로 전달
+var namespace = os.Getenv("CLUSTER_NAMESPACE")
+
+// apiToken : 서버에 토큰 인증이 설정된 경우 Authorization 헤더로 전달
+var apiToken = os.Getenv("CLUSTER_API_TOKEN")
+
+var httpClient = &http.Client{}
+
+// setUpHTTPClient : env CLUSTER_CA_FILE 설정 시 서버의 HTTPS 인증서를 해당 CA 로 검증
+func setUpHTTPClient() error {
+
+	caFile := os.Getenv("CLUSTER_CA_FILE")
+	if caFile == "" {
+		return nil
+	}
+
+	tlsConfig, err := tools.TLSFiles{CAFile: caFile}.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	httpClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	return nil
+}
+
+// doRequest : 공통 헤더를 설정하여 서버에 요청
+func doRequest(method string, requestURI string, body io.Reader) (*http.Response, error) {
+
+	request, err := http.NewRequest(method, requestURI, body)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if namespace != "" {
+		request.Header.Set("X-Namespace", namespace)
+	}
+
+	if apiToken != "" {
+		request.Header.Set("Authorization", "Bearer "+apiToken)
+	}
+
+	return httpClient.Do(request)
+}
+
 func requestAddClientToServer(dataFlags clientFlag) error {
 
 	requestURI := fmt.Sprintf("%s/clients", baseUrl)
 
-	client := &http.Client{}
-
 	requestData := models.NewClientRequestContainer{}
 
 	if dataFlags.SlaveAddress != "" {
@@ -39,16 +91,15 @@ func requestAddClientToServer(dataFlags clientFlag) error {
 
 	requestBody := bytes.NewBuffer(encodedData)
 
-	setRequest, err := http.NewRequest("POST", requestURI, requestBody)
+	res, err := doRequest(http.MethodPost, requestURI, requestBody)
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
-	res, err := client.Do(setRequest)
-	if err != nil {
-		return err
+	if res.StatusCode >= http.StatusBadRequest {
+		return decodeErrorResponse(res)
 	}
-	defer res.Body.Close()
 
 	var hashServerResponse response.RedisListTemplate
 	decoder := json.NewDecoder(res.Body)
@@ -74,7 +125,7 @@ func requestClientListToServer() error {
 
 	requestURI := fmt.Sprintf("%s/clients", baseUrl)
 
-	res, err := http.Get(requestURI)
+	res, err := doRequest(http.MethodGet, requestURI, nil)
 	if err != nil {
 		return err
 	}
@@ -103,12 +154,16 @@ func requestClientListToServer() error {
 func requestGetToServer(key string) error {
 	requestURI := fmt.Sprintf("%s/hash/data/%s", baseUrl, key)
 
-	res, err := http.Get(requestURI)
+	res, err := doRequest(http.MethodGet, requestURI, nil)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= http.StatusBadRequest {
+		return decodeErrorResponse(res)
+	}
+
 	var hashServerResponse response.GetResultTemplate
 	decoder := json.NewDecoder(res.Body)
 
@@ -127,8 +182,6 @@ func requestSetToServer(dataFlags dataFlag) error {
 
 	requestURI := fmt.Sprintf("%s/hash/data", baseUrl)
 
-	client := &http.Client{}
-
 	KeyValue := cluster.KeyValuePair{
 		Key:   dataFlags.Key,
 		Value: dataFlags.Value,
@@ -144,16 +197,15 @@ func requestSetToServer(dataFlags dataFlag) error {
 
 	requestBody := bytes.NewBuffer(encodedData)
 
-	setRequest, err := http.NewRequest("POST", requestURI, requestBody)
+	res, err := doRequest(http.MethodPost, requestURI, requestBody)
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
-	res, err := client.Do(setRequest)
-	if err != nil {
-		return err
+	if res.StatusCode >= http.StatusBadRequest {
+		return decodeErrorResponse(res)
 	}
-	defer res.Body.Close()
 
 	var hashServerResponse response.SetResultTemplate
 	decoder := json.NewDecoder(res.Body)
@@ -168,3 +220,21 @@ func requestSetToServer(dataFlags dataFlag) error {
 
 	return nil
 }
+
+// decodeErrorResponse : 서버의 에러 응답 Body(ErrorTemplate)를 에러로 변환
+func decodeErrorResponse(res *http.Response) error {
+
+	var errorResponse response.ErrorTemplate
+	decoder := json.NewDecoder(res.Body)
+
+	if err := decoder.Decode(&errorResponse); err != nil {
+		return fmt.Errorf("서버 에러 응답 (HTTP %d)", res.StatusCode)
+	}
+
+	return fmt.Errorf(
+		"서버 에러 응답 (HTTP %d) [%s] %s",
+		res.StatusCode,
+		errorResponse.Error.Code,
+		errorResponse.Error.Message,
+	)
+}
@@ -47,6 +47,10 @@ func main() {
 		baseUrl = "http://localhost:8001"
 	}
 
+	if err := setUpHTTPClient(); err != nil {
+		log.Fatal(err)
+	}
+
 	for {
 
 		fmt.Print("hash-interface > ")
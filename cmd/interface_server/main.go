@@ -1,12 +1,20 @@
 package main
 
 import (
+	"crypto/tls"
 	"net/http"
+	"os"
 	"strconv"
 
 	"hash_interface/configs"
+	"hash_interface/internal/auth"
 	"hash_interface/internal/cluster"
+	"hash_interface/internal/grpcapi"
 	"hash_interface/internal/handlers"
+	"hash_interface/internal/hash"
+	"hash_interface/internal/memcache"
+	"hash_interface/internal/ratelimit"
+	"hash_interface/internal/resp"
 	"hash_interface/internal/routers"
 	"hash_interface/tools"
 
@@ -26,6 +34,10 @@ import (
 
 // @host localhost:8888
 // @BasePath /api/v1
+
+// @securityDefinitions.apikey ApiToken
+// @in header
+// @name Authorization
 func main() {
 
 	var err error
@@ -40,6 +52,115 @@ func main() {
 		)
 	}
 
+	// API 토큰 인증 설정, 토큰 파일 없이는 명시적으로 비활성화한 경우에만 시작
+	tokenFilePath := os.Getenv(configs.TokenFileEnv)
+
+	if err := auth.SetUp(tokenFilePath, os.Getenv(configs.AuthDisabledEnv) == "true"); err == auth.ErrNoTokenFile {
+		tools.ErrorLogger.Fatalf(
+			"Error - %s 미설정 (인증 없이 실행하려면 %s=true)",
+			configs.TokenFileEnv,
+			configs.AuthDisabledEnv,
+		)
+
+	} else if err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - API token file load failure : ",
+			err.Error(),
+		)
+
+	} else if auth.IsEnabled() {
+		tools.InfoLogger.Printf("API 토큰 인증 활성화 (%s)", tokenFilePath)
+
+	} else {
+		tools.ErrorLogger.Printf(
+			"Warning - %s=true, API 토큰 인증 비활성화",
+			configs.AuthDisabledEnv,
+		)
+	}
+
+	// 요청 제한 (HTTP / gRPC 공통) & 클라이언트 IP 헤더를 믿을 프록시 설정
+	limitSettings, err := ratelimit.ParseSettings(
+		os.Getenv(configs.RateLimitPerSecondEnv),
+		os.Getenv(configs.RateLimitBurstEnv),
+		os.Getenv(configs.MaxConcurrentRequestsEnv),
+	)
+	if err != nil {
+		tools.ErrorLogger.Fatalln("Error - Request limit setup error : ", err.Error())
+	}
+	trustedProxies, err := ratelimit.ParseTrustedProxies(os.Getenv(configs.TrustedProxiesEnv))
+	if err != nil {
+		tools.ErrorLogger.Fatalln("Error - Trusted proxy setup error : ", err.Error())
+	}
+	handlers.SetUpRequestLimits(limitSettings, trustedProxies)
+	grpcapi.SetUpRequestLimits(limitSettings)
+
+	// 해쉬 슬롯 계산 방식 (기본 legacy : 이전 버전과 같은 슬롯)
+	if err := hash.SetUpAlgorithm(os.Getenv(configs.HashSlotAlgorithmEnv)); err != nil {
+		tools.ErrorLogger.Fatalln("Error - Hash slot algorithm setup error : ", err.Error())
+	}
+
+	// TLS 설정 시 HTTPS 리스너 & 모니터 서버와 mutual TLS
+	tlsFiles := tools.GetTLSFilesFromEnv()
+	if tlsFiles.IsEnabled() {
+		if err := cluster.SetUpMonitorClientTLS(tlsFiles); err != nil {
+			tools.ErrorLogger.Fatalln(
+				"Error - Monitor client TLS setup error : ",
+				err.Error(),
+			)
+		}
+	}
+
+	// 레디스 노드 AUTH / TLS 설정
+	if nodeConfigFilePath := os.Getenv(configs.RedisNodeConfigFileEnv); nodeConfigFilePath != "" {
+		if err := cluster.LoadNodeConfigFile(nodeConfigFilePath); err != nil {
+			tools.ErrorLogger.Fatalln(
+				"Error - Redis node config file load failure : ",
+				err.Error(),
+			)
+		}
+	}
+
+	// 슬레이브 복제 모드 (stream / native) 설정
+	if err := cluster.SetUpReplicationMode(os.Getenv(configs.ReplicationModeEnv)); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Replication mode setup error : ",
+			err.Error(),
+		)
+	}
+	tools.InfoLogger.Printf("슬레이브 복제 모드 : %s", cluster.GetReplicationMode())
+
+	// 노드 별 Connection Pool 크기 설정
+	if err := cluster.SetUpConnectionPool(
+		os.Getenv(configs.RedisPoolMaxIdleEnv),
+		os.Getenv(configs.RedisPoolMaxActiveEnv),
+	); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Connection pool setup error : ",
+			err.Error(),
+		)
+	}
+
+	// 마스터 별 최소 슬레이브 수 (슬레이브 이동) 설정
+	if err := cluster.SetUpReplicaMigration(os.Getenv(configs.MinReplicasPerMasterEnv)); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Replica migration setup error : ",
+			err.Error(),
+		)
+	}
+
+	// 장애 감지 (phi 기준, Failover 최소 down 시간 / 재승격 대기 시간) 설정
+	if err := cluster.SetUpFailureDetector(
+		os.Getenv(configs.PhiSuspectThresholdEnv),
+		os.Getenv(configs.PhiDownThresholdEnv),
+		os.Getenv(configs.FailoverMinDownMsEnv),
+		os.Getenv(configs.FailoverCooldownMsEnv),
+	); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Failure detector setup error : ",
+			err.Error(),
+		)
+	}
+
 	// Redis Master Containers들과 Connection설정
 	err = cluster.NodeConnectionSetup(
 		configs.GetInitialMasterAddressList(),
@@ -79,8 +200,22 @@ func main() {
 		configs.GetInitialTotalAddressList(),
 	)
 
-	// 타이머로 Redis Node들 모니터링 시작
-	// cluster.StartMonitorNodes()
+	// 요청과 별개로 Redis Node 들 상태 확인 / Failover 시작
+	cluster.StartHealthDetector()
+
+	// 모니터 서버들의 노드 상태 변경 알림 구독
+	monitorEventCallbackURL := os.Getenv(configs.MonitorEventCallbackURLEnv)
+	if monitorEventCallbackURL == "" {
+		scheme := configs.HTTP
+		if tlsFiles.IsEnabled() {
+			scheme = configs.HTTPS
+		}
+		monitorEventCallbackURL = scheme + configs.CurrentIP + ":" + strconv.Itoa(configs.Port) + configs.MonitorEventPath
+	}
+	cluster.StartMonitorSubscription(monitorEventCallbackURL, os.Getenv(configs.MonitorSubscribeTokenEnv))
+
+	// 슬레이브가 부족한 마스터에 대기 노드 / 여유 슬레이브 이동 시작
+	cluster.StartReplicaMigration()
 
 	router := mux.NewRouter()
 
@@ -94,9 +229,79 @@ func main() {
 	router.PathPrefix("/").HandlerFunc(handlers.ExceptionHandle)
 	http.Handle("/", router)
 
-	tools.InfoLogger.Println("Server start listening on port ", configs.Port)
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(configs.Port),
+		Handler: router,
+	}
 
-	tools.ErrorLogger.Fatal(
-		http.ListenAndServe(":"+strconv.Itoa(configs.Port), router),
-	)
+	// RESP 클러스터 프로토콜 (CLUSTER SLOTS, MOVED / ASK) 설정, redirect 모드는 HASH_SLOT_ALGORITHM=redis 필요
+	respAnnounceAddress := os.Getenv(configs.RESPAnnounceAddressEnv)
+	if respAnnounceAddress == "" {
+		respAnnounceAddress = configs.CurrentIP + ":" + strconv.Itoa(configs.RESPPort)
+	}
+	if err := resp.SetUpClusterMode(os.Getenv(configs.RESPClusterModeEnv), respAnnounceAddress); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - RESP cluster mode setup error : ",
+			err.Error(),
+		)
+	}
+
+	// RESP 리스너 (HTTP 와 같은 인증서 사용)
+	go func() {
+		var respTLSConfig *tls.Config
+		if tlsFiles.IsEnabled() {
+			var tlsErr error
+			if respTLSConfig, tlsErr = tlsFiles.ServerConfig(false); tlsErr != nil {
+				tools.ErrorLogger.Fatalln("Error - RESP TLS config error : ", tlsErr.Error())
+			}
+		}
+
+		tools.InfoLogger.Println("RESP listener start on port ", configs.RESPPort)
+		tools.ErrorLogger.Fatal(resp.ListenAndServe(":"+strconv.Itoa(configs.RESPPort), respTLSConfig))
+	}()
+
+	// memcached 리스너 (HTTP 와 같은 인증서 사용)
+	go func() {
+		var memcachedTLSConfig *tls.Config
+		if tlsFiles.IsEnabled() {
+			var tlsErr error
+			if memcachedTLSConfig, tlsErr = tlsFiles.ServerConfig(false); tlsErr != nil {
+				tools.ErrorLogger.Fatalln("Error - memcached TLS config error : ", tlsErr.Error())
+			}
+		}
+
+		tools.InfoLogger.Println("memcached listener start on port ", configs.MemcachedPort)
+		tools.ErrorLogger.Fatal(memcache.ListenAndServe(":"+strconv.Itoa(configs.MemcachedPort), memcachedTLSConfig))
+	}()
+
+	// gRPC 서버 (HTTP 와 같은 인증서 사용)
+	go func() {
+		var grpcTLSConfig *tls.Config
+		if tlsFiles.IsEnabled() {
+			var tlsErr error
+			if grpcTLSConfig, tlsErr = tlsFiles.ServerConfig(false); tlsErr != nil {
+				tools.ErrorLogger.Fatalln("Error - gRPC TLS config error : ", tlsErr.Error())
+			}
+		}
+
+		tools.InfoLogger.Println("gRPC server start on port ", configs.GRPCPort)
+		tools.ErrorLogger.Fatal(grpcapi.ListenAndServe(":"+strconv.Itoa(configs.GRPCPort), grpcTLSConfig))
+	}()
+
+	if !tlsFiles.IsEnabled() {
+		tools.InfoLogger.Println("Server start listening on port ", configs.Port)
+		tools.ErrorLogger.Fatal(server.ListenAndServe())
+	}
+
+	// 사용자 요청은 API 토큰으로 인증하므로 클라이언트 인증서는 요구하지 않는다
+	if server.TLSConfig, err = tlsFiles.ServerConfig(false); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - TLS config error : ",
+			err.Error(),
+		)
+	}
+
+	tools.InfoLogger.Println("Server start listening (HTTPS) on port ", configs.Port)
+
+	tools.ErrorLogger.Fatal(server.ListenAndServeTLS("", ""))
 }
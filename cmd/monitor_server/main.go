@@ -2,6 +2,7 @@ package main
 
 import (
 	"net/http"
+	"os"
 	"strconv"
 
 	"hash_interface/configs"
@@ -35,6 +36,42 @@ func main() {
 			"Error - Get Go-App IP error : ", err.Error())
 	}
 
+	// 레디스 노드 AUTH / TLS 설정 (인터페이스 서버와 같은 파일 사용)
+	if nodeConfigFilePath := os.Getenv(configs.RedisNodeConfigFileEnv); nodeConfigFilePath != "" {
+		if err := cluster.LoadNodeConfigFile(nodeConfigFilePath); err != nil {
+			tools.ErrorLogger.Fatalln("Error - Redis node config file load failure : ", err.Error())
+		}
+	}
+
+	// 노드 별 Connection Pool 크기 설정 (인터페이스 서버와 같은 환경변수)
+	if err := cluster.SetUpConnectionPool(
+		os.Getenv(configs.RedisPoolMaxIdleEnv),
+		os.Getenv(configs.RedisPoolMaxActiveEnv),
+	); err != nil {
+		tools.ErrorLogger.Fatalln("Error - Connection pool setup error : ", err.Error())
+	}
+
+	// 장애 감지 (phi 기준) 설정 (인터페이스 서버와 같은 환경변수)
+	if err := cluster.SetUpFailureDetector(
+		os.Getenv(configs.PhiSuspectThresholdEnv),
+		os.Getenv(configs.PhiDownThresholdEnv),
+		os.Getenv(configs.FailoverMinDownMsEnv),
+		os.Getenv(configs.FailoverCooldownMsEnv),
+	); err != nil {
+		tools.ErrorLogger.Fatalln("Error - Failure detector setup error : ", err.Error())
+	}
+
+	// 상태 변경 알림 요청의 API 토큰, 구독할 수 있는 인터페이스 서버 Callback URL, 구독 요청 토큰 / TLS 설정
+	tlsFiles := tools.GetTLSFilesFromEnv()
+	if err := cluster.SetUpMonitorProbing(
+		os.Getenv(configs.MonitorEventTokenEnv),
+		os.Getenv(configs.MonitorEventCallbackURLsEnv),
+		os.Getenv(configs.MonitorSubscribeTokenEnv),
+		tlsFiles,
+	); err != nil {
+		tools.ErrorLogger.Fatalln("Error - Monitor probing setup error : ", err.Error())
+	}
+
 	// Redis Master Containers들과 Connection설정
 	err = cluster.NodeConnectionSetup(
 		configs.GetInitialMasterAddressList(),
@@ -52,6 +89,9 @@ func main() {
 		tools.ErrorLogger.Fatalln("Error - Node connection error : ", err.Error())
 	}
 
+	// 등록된 노드 확인 & 구독한 인터페이스 서버에 상태 변경 알림 시작
+	cluster.StartMonitorProbing()
+
 	router := mux.NewRouter()
 
 	moniterRouter := router.PathPrefix("/monitor").Subrouter()
@@ -63,9 +103,22 @@ func main() {
 	router.PathPrefix("/").HandlerFunc(handlers.ExceptionHandle)
 	http.Handle("/", router)
 
-	tools.InfoLogger.Println("Server start listening on port ", configs.Port)
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(configs.Port),
+		Handler: router,
+	}
 
-	tools.ErrorLogger.Fatal(
-		http.ListenAndServe(":"+strconv.Itoa(configs.Port), router),
-	)
+	if !tlsFiles.IsEnabled() {
+		tools.InfoLogger.Println("Server start listening on port ", configs.Port)
+		tools.ErrorLogger.Fatal(server.ListenAndServe())
+	}
+
+	// 모니터 서버는 CA 로 서명된 인증서를 가진 인터페이스 서버의 요청만 받는다 (mutual TLS)
+	if server.TLSConfig, err = tlsFiles.ServerConfig(true); err != nil {
+		tools.ErrorLogger.Fatalln("Error - TLS config error : ", err.Error())
+	}
+
+	tools.InfoLogger.Println("Server start listening (HTTPS) on port ", configs.Port)
+
+	tools.ErrorLogger.Fatal(server.ListenAndServeTLS("", ""))
 }
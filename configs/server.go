@@ -9,6 +9,12 @@ const (
 	BaseURL = "localhost/interface"
 	// Port is 8080
 	Port = 8888
+	// RESPPort : redis-cli, 레디스 클라이언트 라이브러리용 RESP 리스너
+	RESPPort = 6379
+	// GRPCPort : gRPC 데이터 / 관리 API
+	GRPCPort = 9090
+	// MemcachedPort : memcached ASCII 프로토콜 리스너
+	MemcachedPort = 11211
 	// JSONContent is for response header
 	JsonContent = "application/json"
 	// CORSheader is a header field for Cross Origin Resource Sharing Problem Solve
@@ -18,6 +24,118 @@ const (
 
 	ApiDocumentPath = HTTP + BaseURL + "/docs/index.html"
 
+	// TokenFileEnv : API 토큰 파일 경로 환경변수, 미설정 시 AuthDisabledEnv 가 "true" 가 아니면 서버 시작 실패
+	TokenFileEnv = "API_TOKEN_FILE"
+	// AuthDisabledEnv : "true" 이면 토큰 파일 없이 인증 비활성화 (로컬 개발용)
+	AuthDisabledEnv = "AUTH_DISABLED"
+
+	// HashSlotAlgorithmEnv : 해쉬 슬롯 계산 방식 (legacy / redis), 미설정 시 legacy (이전 버전과 같은 슬롯)
+	HashSlotAlgorithmEnv = "HASH_SLOT_ALGORITHM"
+
+	// RESPClusterModeEnv : RESP 클러스터 모드 (proxy / redirect), 미설정 시 proxy
+	RESPClusterModeEnv = "RESP_CLUSTER_MODE"
+	// RESPAnnounceAddressEnv : CLUSTER SLOTS 등으로 알리는 RESP 주소 (host:port), 미설정 시 CurrentIP:RESPPort
+	RESPAnnounceAddressEnv = "RESP_ANNOUNCE_ADDRESS"
+
+	// ReplicationModeEnv : 슬레이브 복제 모드 (stream / native), 미설정 시 stream (인터페이스 서버가 쓰기 전송)
+	ReplicationModeEnv = "REPLICATION_MODE"
+	// MinReplicasPerMasterEnv : 마스터 별 최소 슬레이브 수, 미만인 마스터에 대기 노드 / 여유 슬레이브를 옮긴다 (0 = 비활성화)
+	MinReplicasPerMasterEnv = "MIN_REPLICAS_PER_MASTER"
+
+	// RedisNodeConfigFileEnv : 레디스 노드 접속 설정 (AUTH / ACL / TLS) 파일 경로 환경변수
+	RedisNodeConfigFileEnv = "REDIS_NODE_CONFIG_FILE"
+	// RedisPoolMaxIdleEnv, RedisPoolMaxActiveEnv : 노드 별 Connection Pool 의 최대 유휴 / 사용 연결 수, 미설정 시 기본값
+	RedisPoolMaxIdleEnv   = "REDIS_POOL_MAX_IDLE"
+	RedisPoolMaxActiveEnv = "REDIS_POOL_MAX_ACTIVE"
+	// PhiSuspectThresholdEnv, PhiDownThresholdEnv : 노드를 suspect / down 으로 판단하는 phi 값
+	PhiSuspectThresholdEnv = "PHI_SUSPECT_THRESHOLD"
+	PhiDownThresholdEnv    = "PHI_DOWN_THRESHOLD"
+	// FailoverMinDownMsEnv : down 이 이 시간 (ms) 이상 계속되어야 Failover 시작
+	FailoverMinDownMsEnv = "FAILOVER_MIN_DOWN_MS"
+	// FailoverCooldownMsEnv : Failover 로 승격된 마스터를 이 시간 (ms) 동안 다시 Failover 하지 않는다
+	FailoverCooldownMsEnv = "FAILOVER_COOLDOWN_MS"
+	// MonitorEventCallbackURLEnv : 모니터 서버가 상태 변경 알림을 보낼 인터페이스 서버 URL, 미설정 시 <현재 IP>:<Port>/monitor/events
+	MonitorEventCallbackURLEnv = "MONITOR_EVENT_CALLBACK_URL"
+	// MonitorEventTokenEnv : 모니터 서버가 알림 요청에 사용하는 API 토큰 (인터페이스 서버의 admin 토큰)
+	MonitorEventTokenEnv = "MONITOR_EVENT_TOKEN"
+	// MonitorEventCallbackURLsEnv : 모니터 서버가 상태 변경 알림을 보낼 인터페이스 서버 Callback URL 목록 (쉼표 구분), 목록에 없는 URL 은 구독할 수 없다
+	MonitorEventCallbackURLsEnv = "MONITOR_EVENT_CALLBACK_URLS"
+	// MonitorSubscribeTokenEnv : 모니터 서버 구독 요청 토큰 (모니터 / 인터페이스 서버에 같은 값), 미설정 시 mutual TLS 인증서로만 구독
+	MonitorSubscribeTokenEnv = "MONITOR_SUBSCRIBE_TOKEN"
+	// RateLimitPerSecondEnv, RateLimitBurstEnv, MaxConcurrentRequestsEnv : 요청 제한 설정, 미설정 시 기본값
+	RateLimitPerSecondEnv    = "RATE_LIMIT_PER_SECOND"
+	RateLimitBurstEnv        = "RATE_LIMIT_BURST"
+	MaxConcurrentRequestsEnv = "MAX_CONCURRENT_REQUESTS"
+	// TrustedProxiesEnv : X-Real-IP / X-Forwarded-For 를 믿을 프록시 IP / CIDR (쉼표 구분), 미설정 시 헤더 무시
+	TrustedProxiesEnv = "TRUSTED_PROXIES"
+
+	// DefaultRateLimitPerSecond, DefaultRateLimitBurst : API 토큰 (또는 클라이언트 IP) 별 Token bucket
+	DefaultRateLimitPerSecond = 100.0
+	DefaultRateLimitBurst     = 200
+	// DefaultMaxConcurrentRequests : 인터페이스 서버가 동시에 처리하는 최대 요청 수, 초과 시 503
+	DefaultMaxConcurrentRequests = 256
+
+	// DefaultMaxStalenessMs : 슬레이브 조회 시 X-Max-Staleness 헤더가 없을 때 허용하는 복제 지연
+	DefaultMaxStalenessMs = 10000
+	// SessionWaitMs : 세션 토큰의 쓰기를 슬레이브가 반영할 때까지 기다리는 시간, 초과 시 마스터에서 조회
+	SessionWaitMs = 100
+	// ReplicationAckTimeoutMs : 슬레이브 응답 / ack=replica, all 요청의 슬레이브 반영 대기 타임아웃
+	ReplicationAckTimeoutMs = 1000
+	// ReplicationBacklogEntries, ReplicationBacklogBytes : 마스터 별 복제 Backlog 제한, 초과 시 오래된 쓰기부터 버린다
+	ReplicationBacklogEntries = 100000
+	ReplicationBacklogBytes   = 64 << 20
+	// ReplicationBatchSize : 슬레이브에 한 번에 (Pipeline) 전송하는 최대 쓰기 수
+	ReplicationBatchSize = 256
+	// ReplicationInfoPollMs : native 복제 모드에서 INFO replication 으로 링크 상태 / Offset 을 확인하는 주기
+	ReplicationInfoPollMs = 50
+	// DefaultMinReplicasPerMaster : MIN_REPLICAS_PER_MASTER 미설정 시 마스터 별 최소 슬레이브 수
+	DefaultMinReplicasPerMaster = 1
+	// ReplicaMigrationIntervalMs : 슬레이브가 부족한 마스터를 확인하는 주기 (승격 / 노드 추가 시에는 바로 확인)
+	ReplicaMigrationIntervalMs = 5000
+	// DefaultPoolMaxIdle, DefaultPoolMaxActive : 노드 별 Connection Pool 기본값 (MaxActive 0 = 제한 없음)
+	DefaultPoolMaxIdle   = 16
+	DefaultPoolMaxActive = 64
+	// PoolIdleTimeoutSec : 이보다 오래 쉰 유휴 연결은 닫는다
+	PoolIdleTimeoutSec = 240
+	// PoolTestOnBorrowMs : 이보다 오래 쉰 연결은 빌려줄 때 PING 으로 확인
+	PoolTestOnBorrowMs = 1000
+	// RedisReadTimeoutMs, RedisWriteTimeoutMs : 레디스 연결 읽기 / 쓰기 타임아웃 (노드 설정 파일의 timeouts 로 노드 별 변경)
+	RedisReadTimeoutMs  = 3000
+	RedisWriteTimeoutMs = 3000
+	// RedisCommandTimeoutMs : 요청에 Deadline 이 없을 때 레디스 명령 하나에 허용하는 시간 (연결 대기 포함)
+	RedisCommandTimeoutMs = 2000
+	// CircuitBreakerFailureThreshold : 노드 연결 / 타임아웃 에러가 연속으로 이만큼 나면 Circuit Breaker 열림
+	CircuitBreakerFailureThreshold = 5
+	// CircuitBreakerOpenMs : 열린 Circuit Breaker 가 확인 요청 (half-open) 하나를 허용하기까지 기다리는 시간
+	CircuitBreakerOpenMs = 5000
+	// HealthCheckIntervalMs : Health Detector 가 모든 노드에 PING 을 보내는 주기
+	HealthCheckIntervalMs = 1000
+	// HealthRecoveryHeartbeats : down 인 노드가 up 으로 돌아오기 위해 연속으로 성공해야 하는 PING 수
+	HealthRecoveryHeartbeats = 3
+	// DefaultPhiSuspectThreshold, DefaultPhiDownThreshold : PHI_SUSPECT_THRESHOLD / PHI_DOWN_THRESHOLD 미설정 시 기본값
+	DefaultPhiSuspectThreshold = 3.0
+	DefaultPhiDownThreshold    = 8.0
+	// DefaultFailoverMinDownMs : FAILOVER_MIN_DOWN_MS 미설정 시 Failover 전 down 이 계속되어야 하는 시간
+	DefaultFailoverMinDownMs = 2000
+	// DefaultFailoverCooldownMs : FAILOVER_COOLDOWN_MS 미설정 시 승격된 마스터를 다시 Failover 하지 않는 시간
+	DefaultFailoverCooldownMs = 30000
+	// PhiWindowSize : phi 계산에 사용하는 최근 heartbeat 간격 수
+	PhiWindowSize = 100
+	// PhiAcceptablePauseMs : 평균 heartbeat 간격에 더해 허용하는 멈춤 (GC, 순간 지연)
+	PhiAcceptablePauseMs = 1000
+	// PhiMinStdDevMs : heartbeat 간격 표준편차의 최소값
+	PhiMinStdDevMs = 500
+	// HealthHistorySize : 노드 별로 기록하는 최근 확인 결과 수
+	HealthHistorySize = 60
+	// MonitorSubscribeIntervalMs : 인터페이스 서버가 모니터 서버의 상태 변경 알림 구독을 갱신하는 주기
+	MonitorSubscribeIntervalMs = 30000
+	// MonitorSubscriptionTTLMs : 모니터 서버가 갱신되지 않은 구독을 유지하는 시간
+	MonitorSubscriptionTTLMs = 90000
+	// MonitorEventTimeoutMs : 상태 변경 알림 / 구독 요청 타임아웃
+	MonitorEventTimeoutMs = 3000
+	// MonitorEventPath : 인터페이스 서버가 모니터 서버의 상태 변경 알림을 받는 경로
+	MonitorEventPath = "/monitor/events"
+
 	// Redis Master Node #1 (Container name : redis_one)
 	RedisMasterOneAddress = "172.29.0.4:8000"
 	// Redis Master Node #2 (Container name : redis_two)
@@ -1,6 +1,6 @@
 // GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
 // This file was generated by swaggo/swag at
-// 2020-04-06 09:30:35.111942 +0900 KST m=+0.042841127
+// 2026-10-19 11:45:46.768620593 +0000 UTC m=+0.056488307
 
 package docs
 
@@ -31,6 +31,11 @@ var doc = `{
     "paths": {
         "/clients": {
             "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -48,13 +53,18 @@ var doc = `{
                     "500": {
                         "description": "서버 오류",
                         "schema": {
-                            "$ref": "#/definitions/response.BasicTemplate"
+                            "$ref": "#/definitions/response.ErrorTemplate"
                         }
                     }
                 }
             },
             "post": {
-                "description": "**Slave 추가 시,** 반드시 요청 바디에 **\"master_address\" 필드에 타겟 노드 주소 설정**\nMaster, Slave 운용하고 싶지 않은 경우, 모두 Master로 등록",
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "**Slave 추가 시,** 반드시 요청 바디에 **\"master_address\" 필드에 타겟 노드 주소 설정**\nMaster, Slave 운용하고 싶지 않은 경우, 모두 Master로 등록\n**role 이 \"standby\" 인 경우,** 슬레이브가 부족한 마스터 (MIN_REPLICAS_PER_MASTER 미만) 에 자동으로 슬레이브로 연결",
                 "consumes": [
                     "application/json"
                 ],
@@ -69,6 +79,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
+                            "type": "object",
                             "$ref": "#/definitions/models.NewClientRequestContainer"
                         }
                     }
@@ -80,10 +91,60 @@ var doc = `{
                             "$ref": "#/definitions/response.RedisListTemplate"
                         }
                     },
+                    "400": {
+                        "description": "INVALID_BODY / INVALID_REQUEST",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
                     "500": {
-                        "description": "서버 오류",
+                        "description": "CLIENT_SETUP_FAILED",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/cluster/replication": {
+            "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 슬레이브 별 복제 현황\nmaster_offset 은 마스터의 마지막 쓰기 순번, acked_offset 은 슬레이브가 반영을 확인한 마지막 순번 (클러스터 전체 쓰기 순번)\npending_entries 는 복제 Backlog 에서 아직 전송하지 못한 쓰기 수\nnative 복제 모드는 레디스 INFO replication 의 link_status, redis_master_offset, redis_replica_offset 을 함께 응답",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Replication Offsets of Each Slave",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.BasicTemplate"
+                            "$ref": "#/definitions/response.ReplicationTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/cluster/slots": {
+            "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 해쉬 슬롯 구간별 마스터 / 슬레이브 주소\nepoch 는 슬롯 할당 (마스터 추가, 장애 복구 등) 이나 마스터 슬레이브 구성이 바뀔 때마다 증가한다.\n데이터 이동 중인 슬롯은 이동이 끝날 때까지 기존 마스터 소유로 보고한다.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Hash Slot Map with Topology Epoch",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SlotMapTemplate"
                         }
                     }
                 }
@@ -91,6 +152,11 @@ var doc = `{
         },
         "/hash/data": {
             "post": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
                 "description": "## Key, Value 쌍 저장\n**기존 값이 존재할 경우 덮어씌워진다**",
                 "consumes": [
                     "application/json"
@@ -100,12 +166,31 @@ var doc = `{
                 ],
                 "summary": "Set new Key, Value Pair",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "이전 쓰기 응답의 세션 토큰 (응답 토큰에 합쳐진다)",
+                        "name": "X-Session-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "master / replica / all (미지정 시 네임스페이스 설정)",
+                        "name": "X-Write-Ack",
+                        "in": "header"
+                    },
                     {
                         "description": "Multiple Pairs can be set",
                         "name": "newSetData",
                         "in": "body",
                         "required": true,
                         "schema": {
+                            "type": "object",
                             "$ref": "#/definitions/models.DataRequestContainer"
                         }
                     }
@@ -117,10 +202,28 @@ var doc = `{
                             "$ref": "#/definitions/response.SetResultTemplate"
                         }
                     },
+                    "400": {
+                        "description": "INVALID_BODY",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
                     "500": {
                         "description": "서버 오류",
                         "schema": {
-                            "$ref": "#/definitions/response.BasicTemplate"
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "503": {
+                        "description": "REPLICATION_ACK_FAILED (마스터에는 저장됨) / CIRCUIT_OPEN / NODE_DOWN",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "504": {
+                        "description": "REDIS_TIMEOUT",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
                         }
                     }
                 }
@@ -128,7 +231,12 @@ var doc = `{
         },
         "/hash/data/{key}": {
             "get": {
-                "description": "## 요청한 Key 값에 저장된 Value 값 가져오기",
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 요청한 Key 값에 저장된 Value 값 가져오기\nX-Read-Preference 로 조회할 노드 선택 (master / prefer-replica / replica-only, 기본값 master)\n슬레이브 조회는 복제 지연이 X-Max-Staleness (ex. 500ms, 5s, 0 = 제한 없음, 기본값 10s) 이내인 경우만 허용한다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -137,6 +245,30 @@ var doc = `{
                 ],
                 "summary": "Get stored Value with passed Key",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "master / prefer-replica / replica-only",
+                        "name": "X-Read-Preference",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "허용하는 최대 복제 지연 (Go duration)",
+                        "name": "X-Max-Staleness",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "쓰기 응답의 세션 토큰 (Read-your-writes)",
+                        "name": "X-Session-Token",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "description": "Target Key",
@@ -152,10 +284,355 @@ var doc = `{
                             "$ref": "#/definitions/response.GetResultTemplate"
                         }
                     },
+                    "400": {
+                        "description": "INVALID_REQUEST",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "404": {
+                        "description": "KEY_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
                     "500": {
                         "description": "서버 오류",
                         "schema": {
-                            "$ref": "#/definitions/response.BasicTemplate"
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "503": {
+                        "description": "REPLICA_UNAVAILABLE / CIRCUIT_OPEN / NODE_DOWN",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "504": {
+                        "description": "REDIS_TIMEOUT",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 요청한 Key 에 Value 저장\n새로 생성된 경우 **201**, 기존 값을 덮어쓴 경우 **204**",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create or Replace Value of passed Key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target Key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "이전 쓰기 응답의 세션 토큰 (응답 토큰에 합쳐진다)",
+                        "name": "X-Session-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "master / replica / all (미지정 시 네임스페이스 설정)",
+                        "name": "X-Write-Ack",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Value to store",
+                        "name": "newValue",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.ValueRequestContainer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.SetResultTemplate"
+                        }
+                    },
+                    "204": {
+                        "description": "기존 값 덮어씀"
+                    },
+                    "400": {
+                        "description": "INVALID_BODY",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "503": {
+                        "description": "REPLICATION_ACK_FAILED (마스터에는 저장됨) / CIRCUIT_OPEN / NODE_DOWN",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "504": {
+                        "description": "REDIS_TIMEOUT",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "summary": "Check whether passed Key exists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target Key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Key 존재"
+                    },
+                    "404": {
+                        "description": "Key 없음"
+                    },
+                    "500": {
+                        "description": "서버 오류"
+                    }
+                }
+            }
+        },
+        "/hash/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Export Key, Value Pairs in Namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ExportTemplate"
+                        }
+                    },
+                    "404": {
+                        "description": "NAMESPACE_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/hash/keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Scan Keys in Namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace (미지정 시 기본 네임스페이스)",
+                        "name": "X-Namespace",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.KeyListTemplate"
+                        }
+                    },
+                    "404": {
+                        "description": "NAMESPACE_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "500": {
+                        "description": "서버 오류",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/monitor/events": {
+            "post": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 모니터 서버의 노드 상태 변경 알림 (모니터 서버가 호출)\n인터페이스 서버는 시작 시 모니터 서버들에 이 경로를 구독한다 (MONITOR_EVENT_CALLBACK_URL).\n마스터가 down 이면 바로 PING 으로 확인하고, 이 서버도 응답을 받지 못하면 Failover 를 시작한다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "summary": "Receive Node State Change from Monitor Server",
+                "parameters": [
+                    {
+                        "description": "상태 변경",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/cluster.NodeStateEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "INVALID_BODY",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/namespaces": {
+            "get": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Registered Namespaces with Quotas and Usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NamespaceListTemplate"
+                        }
+                    }
+                }
+            }
+        },
+        "/namespaces/{namespace}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiToken": []
+                    }
+                ],
+                "description": "## 네임스페이스 등록 / 할당량 변경\n할당량 0 은 제한 없음. 이미 등록된 경우 할당량 / 기본 쓰기 복제 수준만 변경된다.\n사용량은 등록 / 변경 시 레디스를 SCAN 하여 다시 계산한다 (할당량은 인터페이스 서버 별로 적용).\nwrite_ack : 요청에 X-Write-Ack 가 없을 때의 쓰기 복제 수준 (master / replica / all)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Register Namespace or Update its Quotas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name ([A-Za-z0-9_-], max 64)",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key count / bytes quota",
+                        "name": "quota",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/models.NamespaceRequestContainer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NamespaceListTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_NAMESPACE",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "503": {
+                        "description": "NODE_DOWN, CIRCUIT_OPEN",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
+                        }
+                    },
+                    "504": {
+                        "description": "REDIS_TIMEOUT",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorTemplate"
                         }
                     }
                 }
@@ -174,12 +651,44 @@ var doc = `{
                 }
             }
         },
+        "cluster.NodeStateEvent": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "string"
+                },
+                "monitor": {
+                    "description": "Monitor : 알린 모니터 서버 주소 (모니터 서버가 채운다)",
+                    "type": "string"
+                },
+                "phi": {
+                    "type": "number"
+                },
+                "previous": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
         "cluster.RedisClient": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
+                "circuit_breaker": {
+                    "description": "CircuitBreaker : 조회 시점의 Circuit Breaker 상태 (GetMasterClients / GetSlaveClients 결과에만 채운다)",
+                    "type": "string"
+                },
+                "health": {
+                    "description": "Health : 조회 시점의 Health Detector 상태 (GetMasterClients / GetSlaveClients 결과에만 채운다)",
+                    "type": "string"
+                },
                 "role": {
                     "type": "string"
                 }
@@ -196,6 +705,21 @@ var doc = `{
                 }
             }
         },
+        "models.NamespaceRequestContainer": {
+            "type": "object",
+            "properties": {
+                "max_bytes": {
+                    "type": "integer"
+                },
+                "max_keys": {
+                    "type": "integer"
+                },
+                "write_ack": {
+                    "description": "WriteAck : 기본 쓰기 복제 수준 (master / replica / all), 비어있으면 master",
+                    "type": "string"
+                }
+            }
+        },
         "models.NewClientRequestContainer": {
             "type": "object",
             "properties": {
@@ -208,17 +732,95 @@ var doc = `{
                     "type": "string"
                 },
                 "role": {
-                    "description": "Role : \"Master\" / \"Slave\"",
+                    "description": "Role : \"Master\" / \"Slave\" / \"Standby\" (슬레이브가 부족한 마스터에 자동 연결)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ValueRequestContainer": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "namespace.Namespace": {
+            "type": "object",
+            "properties": {
+                "keySizes": {
+                    "description": "keySizes : Key -\u003e 저장된 (Key + Value) 바이트 크기",
+                    "type": "object"
+                },
+                "key_count": {
+                    "type": "integer"
+                },
+                "max_bytes": {
+                    "type": "integer"
+                },
+                "max_keys": {
+                    "description": "MaxKeys, MaxBytes : 0 (Unlimited) 이면 제한 없음",
+                    "type": "integer"
+                },
+                "mutex": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "used_bytes": {
+                    "type": "integer"
+                },
+                "write_ack": {
+                    "description": "WriteAck : 요청에 지정하지 않은 경우의 쓰기 복제 수준 (master / replica / all), 빈 문자열이면 master",
                     "type": "string"
                 }
             }
         },
-        "response.BasicTemplate": {
+        "response.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code : 고정된 에러 코드 (ex. KEY_NOT_FOUND)",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message : 사람이 읽기 위한 에러 설명, 내용은 바뀔 수 있다",
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErrorTemplate": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.ErrorDetail"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_link": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.NextLink"
+                }
+            }
+        },
+        "response.ExportTemplate": {
             "type": "object",
             "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cluster.KeyValuePair"
+                    }
+                },
                 "message": {
                     "type": "string"
                 },
+                "namespace": {
+                    "type": "string"
+                },
                 "next_link": {
                     "type": "object",
                     "$ref": "#/definitions/response.NextLink"
@@ -231,6 +833,10 @@ var doc = `{
                 "handled_node": {
                     "type": "string"
                 },
+                "handled_role": {
+                    "description": "HandledRole : 값을 읽은 노드의 역할 (master / replica)",
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -238,11 +844,54 @@ var doc = `{
                     "type": "object",
                     "$ref": "#/definitions/response.NextLink"
                 },
+                "replication_lag_ms": {
+                    "description": "ReplicationLagMs : 슬레이브에서 읽은 경우 조회 시점의 복제 지연 (ms), 마스터는 0",
+                    "type": "integer"
+                },
                 "result": {
                     "type": "string"
                 }
             }
         },
+        "response.KeyListTemplate": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "next_link": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.NextLink"
+                }
+            }
+        },
+        "response.NamespaceListTemplate": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "namespaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/namespace.Namespace"
+                    }
+                },
+                "next_link": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.NextLink"
+                }
+            }
+        },
         "response.NextLink": {
             "type": "object",
             "properties": {
@@ -289,6 +938,67 @@ var doc = `{
                 }
             }
         },
+        "response.ReplicaStatusResult": {
+            "type": "object",
+            "properties": {
+                "acked_offset": {
+                    "type": "integer"
+                },
+                "is_streaming": {
+                    "type": "boolean"
+                },
+                "lag_ms": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "link_status": {
+                    "type": "string"
+                },
+                "master": {
+                    "type": "string"
+                },
+                "master_offset": {
+                    "description": "MasterOffset : 마스터의 마지막 쓰기 순번, AckedOffset : 슬레이브가 반영을 확인한 마지막 순번",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Mode : 복제 모드 (stream / native), native 모드는 레디스 INFO replication 의 링크 상태와 Offset 을 함께 응답",
+                    "type": "string"
+                },
+                "pending_entries": {
+                    "type": "integer"
+                },
+                "redis_master_offset": {
+                    "type": "integer"
+                },
+                "redis_replica_offset": {
+                    "type": "integer"
+                },
+                "slave": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ReplicationTemplate": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "next_link": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.NextLink"
+                },
+                "replicas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ReplicaStatusResult"
+                    }
+                }
+            }
+        },
         "response.SetResultTemplate": {
             "type": "object",
             "properties": {
@@ -306,6 +1016,70 @@ var doc = `{
                     }
                 }
             }
+        },
+        "response.SlotMapTemplate": {
+            "type": "object",
+            "properties": {
+                "epoch": {
+                    "type": "integer"
+                },
+                "hash_algorithm": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "next_link": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.NextLink"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SlotRangeResult"
+                    }
+                }
+            }
+        },
+        "response.SlotNode": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID : 레디스 클러스터 노드 ID 형식 (CLUSTER NODES 와 동일)",
+                    "type": "string"
+                }
+            }
+        },
+        "response.SlotRangeResult": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer"
+                },
+                "master": {
+                    "type": "object",
+                    "$ref": "#/definitions/response.SlotNode"
+                },
+                "slaves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SlotNode"
+                    }
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiToken": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+/* API 토큰 인증
+ * 토큰 파일의 각 줄 : "<토큰의 SHA-256 hex> <role> [이름]"
+ * '#' 으로 시작하는 줄과 빈 줄은 무시
+ * 평문 토큰은 저장하지 않는다. (해쉬 생성 : echo -n <토큰> | sha256sum)
+ */
+
+type Role uint8
+
+const (
+	RoleNone Role = iota
+	// RoleRead : 데이터 조회
+	RoleRead
+	// RoleWrite : 데이터 조회 / 저장
+	RoleWrite
+	// RoleAdmin : 데이터 + 레디스 클라이언트, 토폴로지, 네임스페이스 관리
+	RoleAdmin
+)
+
+const (
+	// HeaderName : "Authorization: Bearer <토큰>"
+	HeaderName   = "Authorization"
+	BearerPrefix = "Bearer "
+)
+
+var (
+	ErrMissingToken = errors.New("인증 토큰이 없습니다 (Authorization: Bearer <token>)")
+	ErrInvalidToken = errors.New("유효하지 않은 인증 토큰")
+	ErrForbidden    = errors.New("요청 권한이 없습니다")
+	// ErrNoTokenFile : 토큰 파일 없이 인증도 비활성화하지 않은 경우 (서버 시작 실패)
+	ErrNoTokenFile = errors.New("API 토큰 파일이 설정되지 않았습니다")
+)
+
+// Identity : 인증된 토큰의 정보
+type Identity struct {
+	Name string
+	Role Role
+
+	// TokenHash : 토큰 식별자 (로그 등에 평문 대신 사용)
+	TokenHash string
+}
+
+// tokenStore : 토큰 해쉬 -> Identity
+var tokenStore map[string]Identity
+var tokenStoreMutex *sync.RWMutex
+
+// isDisabled : Disable() 을 호출한 경우에만 인증 생략
+// 토큰 파일을 로드하지 않았으면 모든 토큰이 유효하지 않다 (fail closed)
+var isDisabled bool
+
+func init() {
+	if tokenStore == nil {
+		tokenStore = make(map[string]Identity)
+	}
+	if tokenStoreMutex == nil {
+		tokenStoreMutex = &sync.RWMutex{}
+	}
+}
+
+func (role Role) String() string {
+	switch role {
+	case RoleRead:
+		return "read"
+	case RoleWrite:
+		return "write"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// ParseRole : 토큰 파일의 role 문자열 파싱
+func ParseRole(role string) (Role, error) {
+	switch strings.ToLower(role) {
+	case "read", "read-only":
+		return RoleRead, nil
+	case "write", "read-write":
+		return RoleWrite, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("지원하지 않는 role : %s", role)
+	}
+}
+
+// HashToken : 평문 토큰 => 토큰 파일에 저장되는 SHA-256 hex
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadTokenFile : @filePath 의 토큰 파일을 읽어 인증 활성화
+// 기존에 로드된 토큰은 모두 교체된다.
+//
+func LoadTokenFile(filePath string) error {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	newTokenStore := make(map[string]Identity)
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		words := strings.Fields(line)
+		if len(words) < 2 {
+			return fmt.Errorf("토큰 파일 %d 번째 줄 형식 오류", lineNumber)
+		}
+
+		tokenHash := strings.ToLower(words[0])
+		if decoded, err := hex.DecodeString(tokenHash); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("토큰 파일 %d 번째 줄 : SHA-256 hex 가 아닙니다", lineNumber)
+		}
+
+		role, err := ParseRole(words[1])
+		if err != nil {
+			return fmt.Errorf("토큰 파일 %d 번째 줄 : %s", lineNumber, err.Error())
+		}
+
+		name := tokenHash[:8]
+		if len(words) > 2 {
+			name = words[2]
+		}
+
+		newTokenStore[tokenHash] = Identity{
+			Name:      name,
+			Role:      role,
+			TokenHash: tokenHash,
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	tokenStoreMutex.Lock()
+	defer tokenStoreMutex.Unlock()
+
+	tokenStore = newTokenStore
+	isDisabled = false
+
+	return nil
+}
+
+// SetUp : 서버 시작 시 인증 설정
+// @tokenFilePath 가 있으면 로드하고, 없으면 @isAuthDisabled 인 경우에만 인증 비활성화
+// 둘 다 아니면 ErrNoTokenFile (fail closed, 서버를 시작하지 않는다)
+//
+func SetUp(tokenFilePath string, isAuthDisabled bool) error {
+
+	if tokenFilePath != "" {
+		return LoadTokenFile(tokenFilePath)
+	}
+
+	if !isAuthDisabled {
+		return ErrNoTokenFile
+	}
+
+	Disable()
+
+	return nil
+}
+
+func IsEnabled() bool {
+	tokenStoreMutex.RLock()
+	defer tokenStoreMutex.RUnlock()
+
+	return !isDisabled
+}
+
+// Disable : 인증 비활성화, 모든 요청을 허용한다 (AUTH_DISABLED=true 인 경우에만 사용)
+//
+func Disable() {
+	tokenStoreMutex.Lock()
+	defer tokenStoreMutex.Unlock()
+
+	isDisabled = true
+}
+
+// Authenticate : "Authorization" 헤더 값으로 Identity 확인
+//
+func Authenticate(authorizationHeader string) (Identity, error) {
+
+	if !strings.HasPrefix(authorizationHeader, BearerPrefix) {
+		return Identity{}, ErrMissingToken
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, BearerPrefix))
+	if token == "" {
+		return Identity{}, ErrMissingToken
+	}
+
+	tokenStoreMutex.RLock()
+	defer tokenStoreMutex.RUnlock()
+
+	identity, isExist := tokenStore[HashToken(token)]
+	if !isExist {
+		return Identity{}, ErrInvalidToken
+	}
+
+	return identity, nil
+}
+
+// Authorize : @identity 가 @requiredRole 이상의 권한을 가졌는지 확인
+// admin ⊃ write ⊃ read
+//
+func (identity Identity) Authorize(requiredRole Role) error {
+
+	if identity.Role < requiredRole {
+		return ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setUpTestTokenFile : @lines 로 임시 토큰 파일 생성, 반환된 함수로 삭제와 인증 상태 초기화
+//
+func setUpTestTokenFile(t *testing.T, lines ...string) (string, func()) {
+
+	directory, err := ioutil.TempDir("", "auth_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := filepath.Join(directory, "tokens")
+	if err := ioutil.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return filePath, func() {
+		os.RemoveAll(directory)
+		resetTokenStore()
+	}
+}
+
+// resetTokenStore : 토큰 파일을 로드하지 않은 시작 상태
+func resetTokenStore() {
+	tokenStoreMutex.Lock()
+	defer tokenStoreMutex.Unlock()
+
+	tokenStore = make(map[string]Identity)
+	isDisabled = false
+}
+
+func TestLoadTokenFile(t *testing.T) {
+
+	filePath, cleanUp := setUpTestTokenFile(
+		t,
+		"# <sha256 hex>  <role>  [name]",
+		"",
+		HashToken("dashboard-token")+"  read   dashboard",
+		strings.ToUpper(HashToken("batch-token"))+"\tread-write",
+		"  "+HashToken("operator-token")+" ADMIN operator  ",
+	)
+	defer cleanUp()
+
+	if err := LoadTokenFile(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		token string
+		name  string
+		role  Role
+	}{
+		{"dashboard-token", "dashboard", RoleRead},
+		// 이름이 없으면 해쉬 앞 8 자리, 대문자 hex 도 허용
+		{"batch-token", HashToken("batch-token")[:8], RoleWrite},
+		{"operator-token", "operator", RoleAdmin},
+	}
+
+	for _, eachCase := range testCases {
+		identity, err := Authenticate(BearerPrefix + eachCase.token)
+		if err != nil {
+			t.Errorf("%s : %v", eachCase.token, err)
+			continue
+		}
+		if identity.Name != eachCase.name || identity.Role != eachCase.role || identity.TokenHash != HashToken(eachCase.token) {
+			t.Errorf("%s : unexpected identity %+v", eachCase.token, identity)
+		}
+	}
+}
+
+func TestLoadInvalidTokenFile(t *testing.T) {
+
+	validLine := HashToken("valid-token") + " read"
+
+	testCases := []struct {
+		name string
+		line string
+	}{
+		{"missing role", HashToken("token")},
+		{"not hex", strings.Repeat("z", 64) + " read"},
+		{"short hash", HashToken("token")[:32] + " read"},
+		{"plain token", "my-secret-token read"},
+		{"unknown role", HashToken("token") + " root"},
+	}
+
+	for _, eachCase := range testCases {
+
+		filePath, cleanUp := setUpTestTokenFile(t, validLine, eachCase.line)
+
+		err := LoadTokenFile(filePath)
+		if err == nil || !strings.Contains(err.Error(), "2 번째 줄") {
+			t.Errorf("%s : expected line 2 error, got %v", eachCase.name, err)
+		}
+
+		// 형식 오류가 있는 파일은 일부만 적용하지 않는다
+		if _, err := Authenticate(BearerPrefix + "valid-token"); err != ErrInvalidToken {
+			t.Errorf("%s : token store must not be replaced, got %v", eachCase.name, err)
+		}
+
+		cleanUp()
+	}
+}
+
+func TestParseRole(t *testing.T) {
+
+	testCases := []struct {
+		role     string
+		expected Role
+	}{
+		{"read", RoleRead},
+		{"read-only", RoleRead},
+		{"write", RoleWrite},
+		{"Read-Write", RoleWrite},
+		{"admin", RoleAdmin},
+	}
+
+	for _, eachCase := range testCases {
+		if role, err := ParseRole(eachCase.role); err != nil || role != eachCase.expected {
+			t.Errorf("%s : expected %s, got %s (%v)", eachCase.role, eachCase.expected, role, err)
+		}
+	}
+
+	for _, invalidRole := range []string{"", "none", "root", "readwrite"} {
+		if role, err := ParseRole(invalidRole); err == nil || role != RoleNone {
+			t.Errorf("%q must be rejected, got %s", invalidRole, role)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+
+	// admin ⊃ write ⊃ read
+	testCases := []struct {
+		role         Role
+		requiredRole Role
+		isAllowed    bool
+	}{
+		{RoleRead, RoleRead, true},
+		{RoleRead, RoleWrite, false},
+		{RoleRead, RoleAdmin, false},
+		{RoleWrite, RoleRead, true},
+		{RoleWrite, RoleWrite, true},
+		{RoleWrite, RoleAdmin, false},
+		{RoleAdmin, RoleRead, true},
+		{RoleAdmin, RoleWrite, true},
+		{RoleAdmin, RoleAdmin, true},
+		{RoleNone, RoleRead, false},
+	}
+
+	for _, eachCase := range testCases {
+		err := Identity{Role: eachCase.role}.Authorize(eachCase.requiredRole)
+		if isAllowed := err == nil; isAllowed != eachCase.isAllowed {
+			t.Errorf("%s => %s : expected allowed %v, got %v", eachCase.role, eachCase.requiredRole, eachCase.isAllowed, err)
+		}
+		if err != nil && err != ErrForbidden {
+			t.Errorf("%s => %s : expected ErrForbidden, got %v", eachCase.role, eachCase.requiredRole, err)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+
+	filePath, cleanUp := setUpTestTokenFile(t, HashToken("valid-token")+" write")
+	defer cleanUp()
+
+	if err := LoadTokenFile(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		header   string
+		expected error
+	}{
+		{"Bearer valid-token", nil},
+		{"Bearer  valid-token ", nil},
+		{"", ErrMissingToken},
+		{"valid-token", ErrMissingToken},
+		{"Basic valid-token", ErrMissingToken},
+		{"Bearer ", ErrMissingToken},
+		{"Bearer wrong-token", ErrInvalidToken},
+		// 토큰 파일의 해쉬를 토큰으로 보내도 인증되지 않는다
+		{"Bearer " + HashToken("valid-token"), ErrInvalidToken},
+	}
+
+	for _, eachCase := range testCases {
+		if _, err := Authenticate(eachCase.header); err != eachCase.expected {
+			t.Errorf("%q : expected %v, got %v", eachCase.header, eachCase.expected, err)
+		}
+	}
+}
+
+func TestSetUpFailsClosed(t *testing.T) {
+
+	filePath, cleanUp := setUpTestTokenFile(t, HashToken("valid-token")+" read")
+	defer cleanUp()
+
+	testCases := []struct {
+		name           string
+		tokenFilePath  string
+		isAuthDisabled bool
+		expected       error
+		isEnabled      bool
+	}{
+		{"no token file", "", false, ErrNoTokenFile, true},
+		{"explicitly disabled", "", true, nil, false},
+		// 토큰 파일이 있으면 비활성화 설정보다 우선
+		{"token file", filePath, true, nil, true},
+	}
+
+	for _, eachCase := range testCases {
+
+		resetTokenStore()
+
+		if err := SetUp(eachCase.tokenFilePath, eachCase.isAuthDisabled); err != eachCase.expected {
+			t.Errorf("%s : expected %v, got %v", eachCase.name, eachCase.expected, err)
+		}
+		if IsEnabled() != eachCase.isEnabled {
+			t.Errorf("%s : expected enabled %v", eachCase.name, eachCase.isEnabled)
+		}
+	}
+
+	// 토큰 파일을 로드하지 않고 비활성화하지도 않았으면 어떤 토큰도 인증되지 않는다
+	resetTokenStore()
+
+	if _, err := Authenticate(BearerPrefix + "valid-token"); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken before loading a token file, got %v", err)
+	}
+
+	if err := SetUp(filepath.Join(filepath.Dir(filePath), "missing"), false); err == nil {
+		t.Fatal("missing token file must fail")
+	}
+}
@@ -0,0 +1,233 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 노드 별 Circuit Breaker
+ * Connection Pool 로 실행하는 모든 명령의 결과를 노드 별로 기록한다.
+ *   - closed    : 정상, 연결 / 타임아웃 에러가 CircuitBreakerFailureThreshold 번 연속이면 open
+ *   - open      : 명령을 보내지 않고 바로 ErrCircuitOpen, CircuitBreakerOpenMs 후 half-open
+ *   - half-open : 확인 요청 하나만 보낸다, 성공하면 closed / 실패하면 다시 open
+ * 레디스가 응답한 에러 (redis.Error, ex. WRONGTYPE) 와 요청 취소는 노드 장애로 보지 않는다.
+ * 생존 확인 (Ping) 은 Circuit Breaker 를 거치지 않는다.
+ */
+
+// CircuitState : Circuit Breaker 상태
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// ErrCircuitOpen : 노드의 Circuit Breaker 가 열려 명령을 보내지 않음
+var ErrCircuitOpen = errors.New(msg.CircuitOpen)
+
+type circuitBreaker struct {
+	mutex               sync.Mutex
+	state               CircuitState
+	consecutiveFailures int
+	openedAt            time.Time
+	// isProbing : half-open 상태에서 확인 요청이 진행 중
+	isProbing bool
+}
+
+// circuitBreakers : 노드 주소 -> Circuit Breaker
+var circuitBreakers map[string]*circuitBreaker
+var circuitBreakerMutex *sync.Mutex
+
+func init() {
+	if circuitBreakers == nil {
+		circuitBreakers = make(map[string]*circuitBreaker)
+	}
+	if circuitBreakerMutex == nil {
+		circuitBreakerMutex = &sync.Mutex{}
+	}
+}
+
+// breakerOf : @address 노드의 Circuit Breaker (없으면 closed 상태로 생성)
+//
+func breakerOf(address string) *circuitBreaker {
+
+	circuitBreakerMutex.Lock()
+	defer circuitBreakerMutex.Unlock()
+
+	breaker, isSet := circuitBreakers[address]
+	if !isSet {
+		breaker = &circuitBreaker{state: CircuitClosed}
+		circuitBreakers[address] = breaker
+	}
+
+	return breaker
+}
+
+// resetCircuitBreaker : 새로 연결한 노드는 이전 기록을 지운다
+//
+func resetCircuitBreaker(address string) {
+
+	circuitBreakerMutex.Lock()
+	defer circuitBreakerMutex.Unlock()
+
+	delete(circuitBreakers, address)
+}
+
+// allow : 명령을 보내도 되는지, open 이면 ErrCircuitOpen
+//
+func (breaker *circuitBreaker) allow() error {
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case CircuitOpen:
+		if time.Since(breaker.openedAt) < configs.CircuitBreakerOpenMs*time.Millisecond {
+			return ErrCircuitOpen
+		}
+		breaker.state = CircuitHalfOpen
+		breaker.isProbing = true
+		return nil
+
+	case CircuitHalfOpen:
+		if breaker.isProbing {
+			return ErrCircuitOpen
+		}
+		breaker.isProbing = true
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// record : 명령 결과 기록, @address 는 로그용
+//
+func (breaker *circuitBreaker) record(address string, err error) {
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if !isNodeFailure(err) {
+		if breaker.state != CircuitClosed {
+			tools.InfoLogger.Printf(msg.CircuitClosed, address)
+		}
+		breaker.state = CircuitClosed
+		breaker.consecutiveFailures = 0
+		breaker.isProbing = false
+		return
+	}
+
+	breaker.consecutiveFailures++
+
+	if breaker.state == CircuitHalfOpen || breaker.consecutiveFailures >= configs.CircuitBreakerFailureThreshold {
+		if breaker.state != CircuitOpen {
+			tools.ErrorLogger.Printf(msg.CircuitOpened, address, breaker.consecutiveFailures, err.Error())
+		}
+		breaker.state = CircuitOpen
+		breaker.openedAt = time.Now()
+		breaker.isProbing = false
+	}
+}
+
+// status : 현재 상태 (open 이 CircuitBreakerOpenMs 지났으면 다음 요청이 확인 요청이 되므로 half-open)
+//
+func (breaker *circuitBreaker) status() CircuitState {
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.state == CircuitOpen && time.Since(breaker.openedAt) >= configs.CircuitBreakerOpenMs*time.Millisecond {
+		return CircuitHalfOpen
+	}
+
+	return breaker.state
+}
+
+// isNodeFailure : 노드 장애로 볼 에러인지 (연결 / 타임아웃 에러)
+//
+func isNodeFailure(err error) bool {
+
+	if err == nil || errors.Is(err, context.Canceled) || err == redis.ErrNil {
+		return false
+	}
+
+	if _, isRedisError := err.(redis.Error); isRedisError {
+		return false
+	}
+
+	return true
+}
+
+// GetCircuitState : @address 노드의 Circuit Breaker 상태
+//
+func GetCircuitState(address string) CircuitState {
+	return breakerOf(address).status()
+}
+
+// contextConn : 명령마다 ctx 의 남은 시간으로 타임아웃을 걸고 결과를 Circuit Breaker 에 기록하는 연결
+// Close 는 Pool 에 반납하고 getConnection 이 만든 Deadline 을 해제한다
+type contextConn struct {
+	redis.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	address string
+	breaker *circuitBreaker
+}
+
+func (connection contextConn) Do(command string, args ...interface{}) (interface{}, error) {
+
+	reply, err := doWithContext(connection.ctx, connection.Conn, command, args...)
+	connection.breaker.record(connection.address, err)
+
+	return reply, err
+}
+
+func (connection contextConn) Close() error {
+	connection.cancel()
+	return connection.Conn.Close()
+}
+
+// withCommandDeadline : @ctx 의 Deadline 과 @address 노드의 명령 타임아웃 중 먼저 끝나는 쪽 적용
+//
+func withCommandDeadline(ctx context.Context, address string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(getNodeTimeouts(address).CommandMs)*time.Millisecond)
+}
+
+// doWithContext : @ctx 의 남은 시간을 읽기 타임아웃으로 명령 실행, 시간이 지났거나 취소되면 ctx 에러
+//
+func doWithContext(ctx context.Context, connection redis.Conn, command string, args ...interface{}) (interface{}, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	timeout := time.Until(deadlineOf(ctx))
+	if timeout <= 0 {
+		return nil, context.DeadlineExceeded
+	}
+
+	reply, err := redis.DoWithTimeout(connection, timeout, command, args...)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("%w (%s)", ctxErr, err.Error())
+		}
+	}
+
+	return reply, err
+}
+
+func deadlineOf(ctx context.Context) time.Time {
+	deadline, _ := ctx.Deadline()
+	return deadline
+}
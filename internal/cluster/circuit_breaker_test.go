@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+
+	// 상태 변경 로그
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	address := "10.0.3.1:8000"
+	defer resetCircuitBreaker(address)
+
+	breaker := breakerOf(address)
+	nodeError := errors.New("i/o timeout")
+
+	// 레디스가 응답한 에러와 요청 취소는 장애로 세지 않는다
+	for i := 0; i < configs.CircuitBreakerFailureThreshold; i++ {
+		breaker.record(address, redis.Error("WRONGTYPE"))
+		breaker.record(address, fmt.Errorf("%w (closed)", context.Canceled))
+	}
+	if state := GetCircuitState(address); state != CircuitClosed {
+		t.Fatalf("expected closed, got %s", state)
+	}
+
+	for i := 0; i < configs.CircuitBreakerFailureThreshold; i++ {
+		if err := breaker.allow(); err != nil {
+			t.Fatalf("unexpected rejection after %d failures", i)
+		}
+		breaker.record(address, nodeError)
+	}
+	if err := breaker.allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	// CircuitBreakerOpenMs 가 지나면 확인 요청 하나만 허용
+	breaker.openedAt = time.Now().Add(-configs.CircuitBreakerOpenMs * time.Millisecond)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if err := breaker.allow(); err != ErrCircuitOpen {
+		t.Fatalf("expected second probe to be rejected, got %v", err)
+	}
+
+	// 확인 요청이 실패하면 다시 open, 성공하면 closed
+	breaker.record(address, nodeError)
+	if state := GetCircuitState(address); state != CircuitOpen {
+		t.Fatalf("expected open after failed probe, got %s", state)
+	}
+
+	breaker.openedAt = time.Now().Add(-configs.CircuitBreakerOpenMs * time.Millisecond)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	breaker.record(address, nil)
+	if state := GetCircuitState(address); state != CircuitClosed {
+		t.Fatalf("expected closed after successful probe, got %s", state)
+	}
+}
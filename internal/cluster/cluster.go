@@ -4,46 +4,32 @@ import (
 	"fmt"
 	msg "hash_interface/internal/cluster/message"
 	"hash_interface/tools"
-	"time"
 )
 
 // GetRedisClient : 해쉬 슬롯의 @hashSlotIndex 번째 인덱스를 담당하는 Redis Client 반환
-/* Check Process :
- * 1) Check Hash Mapped Redis Client First (Master Node)
- * 2) Get Votes From Monitor Servers
- * 3-1) If Votes are bigger than half, Re-setup connection and return
- * 3-2) If Votes are smaller than half, Promote It's Slave Client to New Master
+/* 생존 확인 / Failover 는 Health Detector (health.go) 가 요청과 별개로 처리한다.
+ * 1) 해쉬 슬롯을 담당하는 마스터 조회
+ * 2) 캐시된 노드 상태가 down 이면 (Failover 진행 중) ErrNodeDown
  */
 func GetRedisClient(hashSlotIndex uint16) (*RedisClient, error) {
 
-	tempClient := hashSlot.get(hashSlotIndex)
-
-	redisMutexMap[tempClient.Address].Lock()
-
-	// HashSlot이 handleIfDead() 재분배되어도, 이전 값 유지
-	defer redisMutexMap[tempClient.Address].Unlock()
-
-	start := time.Now()
-	defer tools.InfoLogger.Printf(msg.FunctionExecutionTime, time.Since(start))
-
-	tools.InfoLogger.Printf("요청한 키값의 해쉬값 : %d", hashSlotIndex)
+	redisClient := hashSlot.get(hashSlotIndex)
+	if redisClient == nil {
+		return nil, fmt.Errorf(msg.NotAnyRedisSetUpYet)
+	}
 
-	// 반환 전, redisClient의 생존여부 확인/처리
-	if err := tempClient.handleIfDead(); err != nil {
-		tools.ErrorLogger.Println("GetRedisClient : ", err.Error())
+	if err := getNodeHealthError(redisClient.Address); err != nil {
 		return nil, err
 	}
 
-	targetClient := hashSlot.get(hashSlotIndex)
-
-	tools.InfoLogger.Printf(msg.RedisNodeSelected, targetClient.Address)
-
-	// handleIfDead에 의해 해쉬 슬롯이 바뀔 수도 있다.
-	return targetClient, nil
+	return redisClient, nil
 }
 
 func GetMasterWithAddress(address string) (*RedisClient, error) {
 
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
 	if len(redisMasterClients) == 0 {
 		return &RedisClient{}, fmt.Errorf(msg.NotAnyRedisSetUpYet)
 	}
@@ -61,6 +47,9 @@ func GetMasterWithAddress(address string) (*RedisClient, error) {
 
 func GetSlaveClientWithAddress(address string) (*RedisClient, error) {
 
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
 	if len(redisSlaveClients) == 0 {
 		return &RedisClient{}, fmt.Errorf(msg.NotAnyRedisSetUpYet)
 	}
@@ -78,9 +67,12 @@ func GetSlaveClientWithAddress(address string) (*RedisClient, error) {
 
 func swapMasterSlaveConfigs(masterNode *RedisClient, slaveNode *RedisClient) error {
 
-	slaveNode.RemoveFromList()
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
 
-	masterNode.RemoveFromList()
+	slaveNode.removeFromListLocked()
+
+	masterNode.removeFromListLocked()
 
 	slaveNode.Role = MasterRole
 	masterNode.Role = SlaveRole
@@ -88,17 +80,31 @@ func swapMasterSlaveConfigs(masterNode *RedisClient, slaveNode *RedisClient) err
 	redisSlaveClients = append(redisSlaveClients, masterNode)
 	redisMasterClients = append(redisMasterClients, slaveNode)
 
+	// 기존 마스터와 나머지 슬레이브는 새로운 마스터의 슬레이브가 된다
+	slaveNodes := []*RedisClient{masterNode}
+	for _, eachSlave := range masterSlaveMap[masterNode.Address] {
+		if eachSlave.Address != slaveNode.Address {
+			slaveNodes = append(slaveNodes, eachSlave)
+			slaveMasterMap[eachSlave.Address] = slaveNode
+		}
+	}
+
 	delete(masterSlaveMap, masterNode.Address)
-	masterSlaveMap[slaveNode.Address] = masterNode
+	masterSlaveMap[slaveNode.Address] = slaveNodes
 
 	delete(slaveMasterMap, slaveNode.Address)
 	slaveMasterMap[masterNode.Address] = slaveNode
 
+	bumpTopologyEpoch()
+
 	return nil
 }
 
 func checkRedisClientSetup() error {
 
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
 	if len(redisMasterClients) == 0 {
 		return fmt.Errorf(msg.RedisMasterNotSetUpYet)
 	}
@@ -131,11 +137,14 @@ func AddNewMaster(newMasterAddress string) error {
 	}
 
 	// 모니터 서버에도 등록 요청
-	if _, err := monitorClient.ask(*newMaster, NewConnect); err != nil {
+	if _, err := monitorClient.ask(newMaster, NewConnect); err != nil {
 		tools.ErrorLogger.Printf(msg.MonitorRegisterFail)
 		return err
 	}
 
+	// 데이터 이동 (reshardData) 이 끝나면 슬롯 이동 상태 해제
+	defer finishSlotMigrations()
+
 	if ok := hashSlot.distributeTo(newMaster); ok != true {
 		return fmt.Errorf(msg.DistributeToFail, newMaster.Address)
 	}
@@ -149,6 +158,9 @@ func AddNewMaster(newMasterAddress string) error {
 		return err
 	}
 
+	// 새로운 마스터에 옮길 슬레이브 확인
+	requestReplicaMigration()
+
 	return nil
 }
 
@@ -168,7 +180,7 @@ func AddNewSlave(newSlaveAddress string, targetMaster *RedisClient) error {
 	}
 
 	// 모니터 서버에도 등록 요청
-	if _, err := monitorClient.ask(*newSlave, NewConnect); err != nil {
+	if _, err := monitorClient.ask(newSlave, NewConnect); err != nil {
 		tools.ErrorLogger.Printf(msg.MonitorRegisterFail)
 		return err
 	}
@@ -179,18 +191,32 @@ func AddNewSlave(newSlaveAddress string, targetMaster *RedisClient) error {
 
 	initMasterSlaveMaps(targetMaster, newSlave)
 
-	// 기존 마스터의 데이터 복사
-	if err := targetMaster.copyDataTo(*newSlave); err != nil {
+	// 기존 마스터의 데이터 복사 (native 모드는 REPLICAOF)
+	if err := targetMaster.syncReplica(newSlave); err != nil {
 		return err
 	}
 
+	// 슬레이브가 부족한 다른 마스터로 옮길 수 있다
+	requestReplicaMigration()
+
 	return nil
 }
 
 func AppendMaster(masterClient *RedisClient) {
+	resetNodeHealth(masterClient.Address)
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
 	redisMasterClients = append(redisMasterClients, masterClient)
+	mutexOfLocked(masterClient.Address)
 }
 
 func AppendSlave(slaveClient *RedisClient) {
+	resetNodeHealth(slaveClient.Address)
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
 	redisSlaveClients = append(redisSlaveClients, slaveClient)
 }
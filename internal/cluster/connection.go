@@ -6,8 +6,6 @@ import (
 	"hash_interface/internal/hash"
 	"hash_interface/tools"
 	"sync"
-
-	"github.com/gomodule/redigo/redis"
 )
 
 const (
@@ -21,11 +19,13 @@ const (
 	Default ConnectOption = iota
 	InitSlaveSetup
 	AddSlave
+	// StandbySetup : 마스터 없이 대기하는 노드 (replica_migration.go)
+	StandbySetup
 )
 
 func init() {
 	if masterSlaveMap == nil {
-		masterSlaveMap = make(map[string]*RedisClient)
+		masterSlaveMap = make(map[string][]*RedisClient)
 	}
 	if slaveMasterMap == nil {
 		slaveMasterMap = make(map[string]*RedisClient)
@@ -42,6 +42,9 @@ func init() {
 	if addClientMutex == nil {
 		addClientMutex = &sync.Mutex{}
 	}
+	if topologyMutex == nil {
+		topologyMutex = &sync.RWMutex{}
+	}
 }
 
 func NodeConnectionSetup(addressList []string, connectOption ConnectOption) error {
@@ -54,14 +57,10 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 		}
 
 		if newRedisClient.isAlreadyExist() {
-			return fmt.Errorf(msg.ClientAlreadyExist)
+			return fmt.Errorf(msg.ClientAlreadyExist, eachNodeAddress)
 		}
 
-		newRedisClient.Connection, err = redis.Dial(
-			"tcp",
-			eachNodeAddress,
-			redis.DialConnectTimeout(ConnTimeoutDuration),
-		)
+		newRedisClient.Pool, err = NewNodePool(eachNodeAddress)
 		if err != nil {
 			tools.ErrorLogger.Printf(
 				msg.ConnectionFailure,
@@ -70,33 +69,50 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 			)
 			return err
 		}
+		resetCircuitBreaker(eachNodeAddress)
+		resetNodeHealth(eachNodeAddress)
 
 		switch connectOption {
 		case Default:
 
 			newRedisClient.Role = MasterRole
+
+			// Mutex for each Master-Slave set (슬레이브는 연결될 때 마스터의 뮤텍스를 공유)
+			topologyMutex.Lock()
 			redisMasterClients = append(redisMasterClients, &newRedisClient)
+			mutexOfLocked(newRedisClient.Address)
+			topologyMutex.Unlock()
 
 		case InitSlaveSetup:
 
-			if len(redisMasterClients) == 0 {
+			masterClients := getMasterClientList()
+			if len(masterClients) == 0 {
 				return fmt.Errorf(msg.RedisMasterNotSetUpYet)
 			}
-			if len(redisMasterClients) > len(addressList) {
+			if len(masterClients) > len(addressList) {
 				return fmt.Errorf(msg.SlaveNumberMustBeLarger)
 			}
 
 			// Modula index for circular assignment
-			index := i % len(redisMasterClients)
-			targetMasterClient := redisMasterClients[index]
+			index := i % len(masterClients)
+			targetMasterClient := masterClients[index]
 
 			newRedisClient.Role = SlaveRole
+
+			topologyMutex.Lock()
 			redisSlaveClients = append(redisSlaveClients, &newRedisClient)
+			topologyMutex.Unlock()
 
-			// Mutex for each Master-Slave set
-			redisMutexMap[targetMasterClient.Address] = &sync.Mutex{}
 			initMasterSlaveMaps(targetMasterClient, &newRedisClient)
 
+			// native 복제 모드는 레디스 복제 설정 (초기 데이터는 없으므로 데이터 로그 복사 생략)
+			if replicationMode == ReplicationModeNative {
+				if err := newRedisClient.replicaOf(targetMasterClient); err != nil {
+					return err
+				}
+				ensureReplicaMonitor(targetMasterClient.Address)
+			}
+
 			tools.InfoLogger.Printf(
 				msg.SlaveMappedToMaster,
 				newRedisClient.Address,
@@ -110,7 +126,22 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 			}
 
 			newRedisClient.Role = SlaveRole
+
+			topologyMutex.Lock()
 			redisSlaveClients = append(redisSlaveClients, &newRedisClient)
+			topologyMutex.Unlock()
+
+		case StandbySetup:
+
+			if len(addressList) != 1 {
+				return fmt.Errorf(msg.AddStandbyParameterError)
+			}
+
+			newRedisClient.Role = StandbyRole
+
+			topologyMutex.Lock()
+			redisSlaveClients = append(redisSlaveClients, &newRedisClient)
+			topologyMutex.Unlock()
 
 		}
 
@@ -122,12 +153,14 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 
 func MakeHashMapToRedis() error {
 
-	connectionCount := len(redisMasterClients)
+	masterClients := getMasterClientList()
+
+	connectionCount := len(masterClients)
 	if connectionCount == 0 {
 		return fmt.Errorf(msg.RedisMasterNotSetUpYet)
 	}
 
-	for i, eachRedisNode := range redisMasterClients {
+	for i, eachRedisNode := range masterClients {
 		// arithmatic order fixed to prevent Mantissa Loss
 		hashSlotStart := uint16(
 			float64(i) / float64(connectionCount) * float64(hash.HashSlotsNumber),
@@ -136,16 +169,13 @@ func MakeHashMapToRedis() error {
 			float64(i+1) / float64(connectionCount) * float64(hash.HashSlotsNumber),
 		)
 
-		hashSlot.assign(redisMasterClients[i], hashSlotStart, hashSlotEnd)
+		hashSlot.assign(masterClients[i], hashSlotStart, hashSlotEnd)
 
 		newHashRange := HashRange{
 			startIndex: hashSlotStart,
 			endIndex:   hashSlotEnd,
 		}
-		clientHashRangeMap[eachRedisNode.Address] = append(
-			clientHashRangeMap[eachRedisNode.Address],
-			newHashRange,
-		)
+		appendHashRange(eachRedisNode.Address, newHashRange)
 
 		tools.InfoLogger.Printf(
 			msg.HashSlotAssignResult,
@@ -158,12 +188,59 @@ func MakeHashMapToRedis() error {
 	return nil
 }
 
+// initMasterSlaveMaps : @masterNode 의 슬레이브 목록에 @slaveNode 추가 (같은 주소의 슬레이브는 교체)
+// 슬레이브 목록은 새 슬라이스로 만들어 getSlavesOf 로 받은 목록을 사용하는 조회 / 복제 고루틴이 이전 목록을 안전하게 사용하도록 한다
+//
 func initMasterSlaveMaps(masterNode *RedisClient, slaveNode *RedisClient) {
 
-	masterSlaveMap[masterNode.Address] = slaveNode
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	slaveNodes := []*RedisClient{}
+	for _, eachSlave := range masterSlaveMap[masterNode.Address] {
+		if eachSlave.Address != slaveNode.Address {
+			slaveNodes = append(slaveNodes, eachSlave)
+		}
+	}
+
+	masterSlaveMap[masterNode.Address] = append(slaveNodes, slaveNode)
 	slaveMasterMap[slaveNode.Address] = masterNode
 
-	redisMutexMap[slaveNode.Address] = redisMutexMap[masterNode.Address]
+	redisMutexMap[slaveNode.Address] = mutexOfLocked(masterNode.Address)
+
+	bumpTopologyEpoch()
+}
+
+// detachSlave : @masterNode 의 슬레이브 목록에서 @slaveNode 제거 (initMasterSlaveMaps 와 같이 새 슬라이스로 만든다)
+// 복제 고루틴은 isSlaveOf 로 확인하고 종료된다
+//
+func detachSlave(masterNode *RedisClient, slaveNode *RedisClient) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	slaveNodes := []*RedisClient{}
+	for _, eachSlave := range masterSlaveMap[masterNode.Address] {
+		if eachSlave.Address != slaveNode.Address {
+			slaveNodes = append(slaveNodes, eachSlave)
+		}
+	}
+
+	masterSlaveMap[masterNode.Address] = slaveNodes
+	if currentMaster, isSet := slaveMasterMap[slaveNode.Address]; isSet && currentMaster.Address == masterNode.Address {
+		delete(slaveMasterMap, slaveNode.Address)
+	}
+
+	bumpTopologyEpoch()
+}
+
+// isSlaveOf : @slaveAddress 가 아직 @masterAddress 의 슬레이브인지 (복제 고루틴 종료 확인용)
+//
+func isSlaveOf(slaveAddress string, masterAddress string) bool {
+
+	masterNode, isSet := getMasterOf(slaveAddress)
+
+	return isSet && masterNode.Address == masterAddress
 }
 
 func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error {
@@ -189,12 +266,15 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	}
 
 	// 슬레이브 환경 설정
+	topologyMutex.Lock()
 	slaveClient.Role = SlaveRole
 	redisSlaveClients = append(redisSlaveClients, slaveClient)
+	topologyMutex.Unlock()
+
 	initMasterSlaveMaps(masterClient, slaveClient)
 
-	// 기존 마스터의 데이터 복사
-	if err := masterClient.copyDataTo(*slaveClient); err != nil {
+	// 기존 마스터의 데이터 복사 (native 모드는 REPLICAOF)
+	if err := masterClient.syncReplica(slaveClient); err != nil {
 		tools.ErrorLogger.Printf(
 			"슬레이브(%s)에 데이터 복사 에러 : %s",
 			slaveClient.Address,
@@ -204,7 +284,7 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	}
 
 	// 모니터링 하는 레디스에서 제거
-	if _, err := monitorClient.ask(*slaveClient, EndConnect); err != nil {
+	if _, err := monitorClient.ask(slaveClient, EndConnect); err != nil {
 		tools.ErrorLogger.Printf("모니터 서버에 %s 제거 요청 실패!", slaveClient.Address)
 		return err
 	}
@@ -212,7 +292,7 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	tools.ErrorLogger.Printf("모니터 서버에 %s 제거 요청 성공!", slaveClient.Address)
 
 	// 모니터 서버에게 연결 확인 요청
-	if _, err := monitorClient.ask(*slaveClient, NewConnect); err != nil {
+	if _, err := monitorClient.ask(slaveClient, NewConnect); err != nil {
 		tools.ErrorLogger.Printf("모니터 서버에 %s 등록 요청 실패!", slaveClient.Address)
 		return err
 	}
@@ -232,14 +312,17 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	return nil
 }
 
+// TryReconnect : Connection Pool 이 없으면 새로 만들고, 있으면 PING 으로 확인
+// Pool 은 끊어진 연결을 버리고 다시 연결하므로 Pool 을 새로 만들 필요는 없다
+//
 func (redisClient *RedisClient) TryReconnect() error {
 	var err error
 
-	redisClient.Connection, err = redis.Dial(
-		"tcp",
-		redisClient.Address,
-		redis.DialConnectTimeout(ConnTimeoutDuration),
-	)
+	if redisClient.Pool == nil {
+		redisClient.Pool, err = NewNodePool(redisClient.Address)
+	} else {
+		_, err = redisClient.Ping()
+	}
 	if err != nil {
 		tools.ErrorLogger.Printf(msg.ReconnectFail, redisClient.Address)
 		return err
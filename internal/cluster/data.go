@@ -0,0 +1,547 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/internal/hash"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// scanBatchSize : SCAN 명령 1회당 COUNT 힌트
+	scanBatchSize = 100
+
+	// deletedValue : DEL 명령의 데이터 로그 Value 자리 (로그 한 줄은 항상 4 단어)
+	deletedValue = "-"
+
+	// NoExpiry : 만료 시각 없음 (SET 과 같이 기존 만료 시각도 지운다)
+	NoExpiry = 0
+
+	// KeepExpiry : 기존 만료 시각 유지 (SET KEEPTTL)
+	KeepExpiry = -1
+
+	// notStored : setWithExpiryScript 가 조건 (NX / XX), compareAndSwapScript 가 현재 값이 달라 저장하지 않음
+	notStored = -3
+)
+
+// SetCondition : SET 의 저장 조건
+type SetCondition string
+
+const (
+	// SetAlways : 항상 저장
+	SetAlways SetCondition = ""
+	// SetIfAbsent : 저장되어 있지 않을 때만 저장 (SET NX)
+	SetIfAbsent SetCondition = "NX"
+	// SetIfExist : 저장되어 있을 때만 저장 (SET XX)
+	SetIfExist SetCondition = "XX"
+)
+
+// ErrKeyNotFound : 요청한 Key 값이 저장되어 있지 않음
+var ErrKeyNotFound = errors.New(msg.KeyNotFound)
+
+// compareAndSwapScript, compareAndDeleteScript : 현재 값이 ARGV[1] 일 때만 변경 (마스터에서 원자적으로 실행)
+// compareAndSwapScript 의 ARGV[3] 은 만료 unix 시각 (초), 0 이면 만료 없음, -1 (KeepExpiry) 이면 기존 만료 시각 유지
+// compareAndSwapScript 반환 : setWithExpiryScript 와 동일 (notStored, 남은 만료 시간 또는 -1)
+var compareAndSwapScript *redis.Script
+var compareAndDeleteScript *redis.Script
+
+// setWithExpiryScript : ARGV[1] 저장 후 ARGV[2] (만료 unix 시각, 초) 에 만료, ARGV[3] 이 "NX" 이면 없을 때만, "XX" 이면 있을 때만 저장
+// ARGV[2] 가 0 이면 만료 없음, -1 (KeepExpiry) 이면 기존 만료 시각 유지
+// 반환 : notStored (저장 안 함), KeepExpiry 일 때 기존 Key 의 남은 만료 시간 (ms, PTTL 과 동일), 그 외 -1
+var setWithExpiryScript *redis.Script
+
+func init() {
+	if compareAndSwapScript == nil {
+		compareAndSwapScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return -3
+end
+if ARGV[3] == "-1" then
+	local remainingMs = redis.call("PTTL", KEYS[1])
+	redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+	return remainingMs
+end
+redis.call("SET", KEYS[1], ARGV[2])
+if ARGV[3] ~= "0" then
+	redis.call("EXPIREAT", KEYS[1], ARGV[3])
+end
+return -1`)
+	}
+	if setWithExpiryScript == nil {
+		setWithExpiryScript = redis.NewScript(1, `
+local isExist = redis.call("EXISTS", KEYS[1]) == 1
+if (ARGV[3] == "NX" and isExist) or (ARGV[3] == "XX" and not isExist) then
+	return -3
+end
+if ARGV[2] == "-1" then
+	local remainingMs = redis.call("PTTL", KEYS[1])
+	redis.call("SET", KEYS[1], ARGV[1], "KEEPTTL")
+	return remainingMs
+end
+redis.call("SET", KEYS[1], ARGV[1])
+if ARGV[2] ~= "0" then
+	redis.call("EXPIREAT", KEYS[1], ARGV[2])
+end
+return -1`)
+	}
+	if compareAndDeleteScript == nil {
+		compareAndDeleteScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+	}
+}
+
+// SetData : @key 의 해쉬 슬롯을 담당하는 마스터에 (key, value) 저장
+//  1. 레디스에 SET 명령 실행
+//  2. 변경사항 데이터 로그 기록
+//  3. 슬레이브에게 전파 (@ack 수준을 만족하지 못하면 ErrReplicationAck)
+//
+// 레디스 명령은 @ctx 의 Deadline 과 노드의 명령 타임아웃 중 먼저 끝나는 쪽까지 기다린다 (아래 함수 모두 동일)
+// 1, 2 는 Key 의 쓰기 Lock (lockKeyWrite) 안에서 처리해 같은 Key 의 쓰기가 반영된 순서대로 기록된다 (아래 쓰기 함수 모두 동일)
+//
+func SetData(ctx context.Context, key string, value string, ack WriteAck) (*RedisClient, error) {
+
+	redisClient, err := GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	if _, err := redis.String(redisClient.DoContext(ctx, "SET", key, value)); err != nil {
+		return redisClient, err
+	}
+
+	if err := redisClient.recordAndReplicate(unlockKey, "SET", key, value, ack); err != nil {
+		return redisClient, err
+	}
+
+	return redisClient, nil
+}
+
+// SetDataWithExpiry : SetData 와 동일하나 @expiresAt (unix 초) 에 레디스가 Key 를 만료시킨다
+// @expiresAt 이 NoExpiry 이면 만료 없음, KeepExpiry 이면 기존 만료 시각 유지 (SET KEEPTTL)
+// @condition 을 만족할 때만 저장 (SET NX / XX)
+// 만료 시각도 데이터 로그에 EXPIREAT 로 기록되어 슬레이브에 전파된다 (KeepExpiry 는 남은 만료 시간을 초 단위로 올림)
+//
+func SetDataWithExpiry(ctx context.Context, key string, value string, expiresAt int64, condition SetCondition, ack WriteAck) (isStored bool, redisClient *RedisClient, err error) {
+
+	if expiresAt == NoExpiry {
+		switch condition {
+		case SetAlways:
+			redisClient, err = SetData(ctx, key, value, ack)
+			return err == nil, redisClient, err
+		case SetIfAbsent:
+			return SetDataIfAbsent(ctx, key, value, ack)
+		}
+	}
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	connection, err := redisClient.getConnection(ctx)
+	if err != nil {
+		return false, redisClient, err
+	}
+	defer connection.Close()
+
+	remainingMs, err := redis.Int64(setWithExpiryScript.Do(connection, key, value, expiresAt, string(condition)))
+	if err != nil || remainingMs == notStored {
+		return false, redisClient, err
+	}
+
+	if expiresAt == KeepExpiry {
+		expiresAt = keptExpiresAt(remainingMs)
+	}
+
+	if err := redisClient.recordAndReplicateWithExpiry(unlockKey, key, value, expiresAt, ack); err != nil {
+		return true, redisClient, err
+	}
+
+	return true, redisClient, nil
+}
+
+// keptExpiresAt : KeepExpiry 로 저장한 Key 의 남은 만료 시간 (@remainingMs, PTTL) 을 슬레이브에 기록할 만료 시각으로 변환
+// 초 단위로 올림, 만료 시각이 없으면 (음수) NoExpiry
+//
+func keptExpiresAt(remainingMs int64) int64 {
+
+	if remainingMs < 0 {
+		return NoExpiry
+	}
+
+	nowMs := time.Now().UnixNano() / int64(time.Millisecond)
+
+	return (nowMs + remainingMs + 999) / 1000
+}
+
+// ReplaceData : SetData 와 동일하나, 기존 값의 존재 여부를 함께 반환
+// GETSET 을 이용하므로 존재 확인과 저장이 원자적으로 처리된다.
+//
+func ReplaceData(ctx context.Context, key string, value string, ack WriteAck) (isCreated bool, redisClient *RedisClient, err error) {
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	_, err = redis.String(redisClient.DoContext(ctx, "GETSET", key, value))
+	if err == redis.ErrNil {
+		isCreated = true
+
+	} else if err != nil {
+		return false, redisClient, err
+	}
+
+	if err := redisClient.recordAndReplicate(unlockKey, "SET", key, value, ack); err != nil {
+		return isCreated, redisClient, err
+	}
+
+	return isCreated, redisClient, nil
+}
+
+// SetDataIfAbsent : @key 가 저장되어 있지 않을 때만 저장 (SET NX)
+// 저장된 경우에만 데이터 로그 기록 & 슬레이브 전파
+//
+func SetDataIfAbsent(ctx context.Context, key string, value string, ack WriteAck) (isStored bool, redisClient *RedisClient, err error) {
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	_, err = redis.String(redisClient.DoContext(ctx, "SET", key, value, "NX"))
+	if err == redis.ErrNil {
+		return false, redisClient, nil
+
+	} else if err != nil {
+		return false, redisClient, err
+	}
+
+	if err := redisClient.recordAndReplicate(unlockKey, "SET", key, value, ack); err != nil {
+		return true, redisClient, err
+	}
+
+	return true, redisClient, nil
+}
+
+// CompareAndSwapData : @key 의 현재 값이 @expectedValue 일 때만 @value 저장
+// 저장된 경우에만 데이터 로그 기록 & 슬레이브 전파
+//
+func CompareAndSwapData(ctx context.Context, key string, expectedValue string, value string, ack WriteAck) (isSwapped bool, redisClient *RedisClient, err error) {
+	return CompareAndSwapDataWithExpiry(ctx, key, expectedValue, value, NoExpiry, ack)
+}
+
+// CompareAndSwapDataWithExpiry : CompareAndSwapData 와 동일하나 @expiresAt (unix 초) 에 만료
+// NoExpiry 이면 만료 없음, KeepExpiry 이면 기존 만료 시각 유지
+//
+func CompareAndSwapDataWithExpiry(ctx context.Context, key string, expectedValue string, value string, expiresAt int64, ack WriteAck) (isSwapped bool, redisClient *RedisClient, err error) {
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	connection, err := redisClient.getConnection(ctx)
+	if err != nil {
+		return false, redisClient, err
+	}
+	defer connection.Close()
+
+	remainingMs, err := redis.Int64(compareAndSwapScript.Do(connection, key, expectedValue, value, expiresAt))
+	if err != nil || remainingMs == notStored {
+		return false, redisClient, err
+	}
+
+	if expiresAt == KeepExpiry {
+		expiresAt = keptExpiresAt(remainingMs)
+	}
+
+	if err := redisClient.recordAndReplicateWithExpiry(unlockKey, key, value, expiresAt, ack); err != nil {
+		return true, redisClient, err
+	}
+
+	return true, redisClient, nil
+}
+
+// GetData : @key 에 저장된 값 반환, 저장되어 있지 않으면 ErrKeyNotFound
+//
+func GetData(ctx context.Context, key string) (string, *RedisClient, error) {
+
+	redisClient, err := GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return "", nil, err
+	}
+
+	value, err := redis.String(redisClient.DoContext(ctx, "GET", key))
+	if err == redis.ErrNil {
+		return "", redisClient, ErrKeyNotFound
+
+	} else if err != nil {
+		return "", redisClient, err
+	}
+
+	return value, redisClient, nil
+}
+
+// IsDataExist : @key 가 저장되어 있는지 확인
+//
+func IsDataExist(ctx context.Context, key string) (bool, *RedisClient, error) {
+
+	redisClient, err := GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	isExist, err := redis.Bool(redisClient.DoContext(ctx, "EXISTS", key))
+	if err != nil {
+		return false, redisClient, err
+	}
+
+	return isExist, redisClient, nil
+}
+
+// DeleteData : @key 삭제, 저장되어 있지 않았으면 isDeleted = false
+// 삭제된 경우에만 데이터 로그 기록 & 슬레이브 전파
+//
+func DeleteData(ctx context.Context, key string, ack WriteAck) (isDeleted bool, redisClient *RedisClient, err error) {
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	deletedCount, err := redis.Int(redisClient.DoContext(ctx, "DEL", key))
+	if err != nil {
+		return false, redisClient, err
+	}
+
+	if deletedCount == 0 {
+		return false, redisClient, nil
+	}
+
+	if err := redisClient.recordAndReplicate(unlockKey, "DEL", key, deletedValue, ack); err != nil {
+		return true, redisClient, err
+	}
+
+	return true, redisClient, nil
+}
+
+// CompareAndDeleteData : @key 의 현재 값이 @expectedValue 일 때만 삭제
+// 삭제된 경우에만 데이터 로그 기록 & 슬레이브 전파
+//
+func CompareAndDeleteData(ctx context.Context, key string, expectedValue string, ack WriteAck) (isDeleted bool, redisClient *RedisClient, err error) {
+
+	redisClient, err = GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		return false, nil, err
+	}
+
+	unlockKey := lockKeyWrite(key)
+	defer unlockKey()
+
+	connection, err := redisClient.getConnection(ctx)
+	if err != nil {
+		return false, redisClient, err
+	}
+	defer connection.Close()
+
+	isDeleted, err = redis.Bool(compareAndDeleteScript.Do(connection, key, expectedValue))
+	if err != nil || !isDeleted {
+		return false, redisClient, err
+	}
+
+	if err := redisClient.recordAndReplicate(unlockKey, "DEL", key, deletedValue, ack); err != nil {
+		return true, redisClient, err
+	}
+
+	return true, redisClient, nil
+}
+
+// recordAndReplicate : 마스터에 반영된 명령을 데이터 로그에 기록 후 슬레이브에 전파
+// 기록 후 @unlockKey 로 Key 의 쓰기 Lock 을 해제하고 슬레이브 반영을 기다린다
+// 데이터 로그 기록 실패 또는 @ack 수준을 만족하지 못하면 에러 (변경 이벤트는 마스터 반영 기준으로 발행)
+//
+func (redisClient *RedisClient) recordAndReplicate(unlockKey func(), command string, key string, value string, ack WriteAck) error {
+
+	sequence, err := redisClient.recordWrite(command, key, value)
+	unlockKey()
+
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.RecordDataLogFail, redisClient.Address, err.Error())
+		return err
+	}
+
+	replicationErr := redisClient.waitForAck(sequence, ack)
+
+	redisClient.publishWrite(command, key, value)
+
+	return replicationErr
+}
+
+// recordAndReplicateWithExpiry : SET 과 만료 시각 (EXPIREAT) 을 차례로 데이터 로그에 기록 후 슬레이브에 전파
+// @expiresAt 이 NoExpiry 이면 recordAndReplicate 와 동일
+// 두 기록 모두 Key 의 쓰기 Lock 안에서 처리하고, 슬레이브에는 기록한 순서대로 전송되므로 EXPIREAT 의 @ack 를 기다리면 SET 도 반영된 것이다
+//
+func (redisClient *RedisClient) recordAndReplicateWithExpiry(unlockKey func(), key string, value string, expiresAt int64, ack WriteAck) error {
+
+	if expiresAt == NoExpiry {
+		return redisClient.recordAndReplicate(unlockKey, "SET", key, value, ack)
+	}
+
+	sequence, err := redisClient.recordWrite("SET", key, value)
+	if err == nil {
+		sequence, err = redisClient.recordWrite("EXPIREAT", key, strconv.FormatInt(expiresAt, 10))
+	}
+	unlockKey()
+
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.RecordDataLogFail, redisClient.Address, err.Error())
+		return err
+	}
+
+	replicationErr := redisClient.waitForAck(sequence, ack)
+
+	redisClient.publishWrite("SET", key, value)
+
+	return replicationErr
+}
+
+// publishWrite : 마스터에 반영된 쓰기의 변경 이벤트 발행 (DEL 은 Value 없이)
+//
+func (redisClient *RedisClient) publishWrite(command string, key string, value string) {
+
+	event := DataEvent{
+		Command:     command,
+		Key:         key,
+		Value:       value,
+		NodeAddress: redisClient.Address,
+	}
+	if command == "DEL" {
+		event.Value = ""
+	}
+	publishDataEvent(event)
+}
+
+// ScanKeys : 모든 마스터 노드에서 @pattern (glob) 에 맞는 Key 목록 반환
+//
+func ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+
+	keys := []string{}
+
+	for _, eachMaster := range getMasterClientList() {
+
+		masterKeys, err := eachMaster.scanKeys(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, masterKeys...)
+	}
+
+	return keys, nil
+}
+
+// ScanKeySizes : 모든 마스터 노드에서 @pattern 에 맞는 Key -> 저장된 (Key + Value) 바이트 크기
+// 네임스페이스 사용량을 레디스 기준으로 다시 계산할 때 사용, 문자열이 아닌 Key (WRONGTYPE) 는 제외
+//
+func ScanKeySizes(ctx context.Context, pattern string) (map[string]int64, error) {
+
+	keySizes := make(map[string]int64)
+
+	for _, eachMaster := range getMasterClientList() {
+
+		masterKeys, err := eachMaster.scanKeys(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, eachKey := range masterKeys {
+
+			valueSize, err := redis.Int64(eachMaster.DoContext(ctx, "STRLEN", eachKey))
+			if isWrongTypeError(err) {
+				continue
+
+			} else if err != nil {
+				return nil, err
+			}
+
+			// 스캔 이후 삭제된 Key
+			if valueSize == 0 {
+				continue
+			}
+
+			keySizes[eachKey] = int64(len(eachKey)) + valueSize
+		}
+	}
+
+	return keySizes, nil
+}
+
+// isWrongTypeError : 다른 자료형의 Key 에 명령을 실행했을 때의 레디스 에러인지 확인
+//
+func isWrongTypeError(err error) bool {
+
+	redisErr, isRedisError := err.(redis.Error)
+
+	return isRedisError && strings.HasPrefix(string(redisErr), "WRONGTYPE")
+}
+
+// scanKeys : 인스턴스에서 SCAN 커서가 끝날 때까지 @pattern 에 맞는 Key 수집
+// 노드의 명령 타임아웃은 SCAN 한 번마다 적용 (전체 스캔은 @ctx 의 Deadline 까지)
+//
+func (redisClient *RedisClient) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+
+	keys := []string{}
+	cursor := 0
+
+	for {
+		reply, err := redis.Values(
+			redisClient.DoContext(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", scanBatchSize),
+		)
+		if err != nil {
+			tools.ErrorLogger.Printf(msg.ScanKeysFail, redisClient.Address, err.Error())
+			return nil, err
+		}
+
+		var batch []string
+		if _, err := redis.Scan(reply, &cursor, &batch); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, batch...)
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,194 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"hash_interface/internal/hash"
+	"hash_interface/internal/redistest"
+)
+
+// setUpTestReplicaSet : 테스트 레디스 서버 두 개로 (마스터, 슬레이브) 구성, @keys 의 해쉬 슬롯을 마스터에 할당
+// 반환된 함수로 토폴로지 / 데이터 로그 / 서버 정리
+//
+func setUpTestReplicaSet(t *testing.T, keys ...string) (*redistest.Server, *redistest.Server, func()) {
+
+	cleanUpDataLogDirectory := setUpTestDataLogDirectory(t)
+
+	masterServer := redistest.NewServer()
+	slaveServer := redistest.NewServer()
+
+	masterPool, err := NewNodePool(masterServer.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slavePool, err := NewNodePool(slaveServer.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	masterClient := &RedisClient{Address: masterServer.Address, Role: MasterRole, Pool: masterPool}
+	slaveClient := &RedisClient{Address: slaveServer.Address, Role: SlaveRole, Pool: slavePool}
+
+	for _, eachClient := range []*RedisClient{masterClient, slaveClient} {
+		if err := createDataLogFile(eachClient.Address); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	AppendMaster(masterClient)
+	AppendSlave(slaveClient)
+	initMasterSlaveMaps(masterClient, slaveClient)
+
+	slots := []uint16{}
+	for _, eachKey := range keys {
+		slot := hash.GetHashSlotIndex(eachKey)
+		hashSlot.assign(masterClient, slot, slot+1)
+		slots = append(slots, slot)
+	}
+
+	return masterServer, slaveServer, func() {
+		hashSlot.slotsMutex.Lock()
+		for _, eachSlot := range slots {
+			delete(hashSlot.slots, eachSlot)
+		}
+		hashSlot.slotsMutex.Unlock()
+
+		for _, eachClient := range []*RedisClient{masterClient, slaveClient} {
+			eachClient.RemoveFromList()
+			eachClient.removeDataLogFile()
+			removeReplicationBacklog(eachClient.Address)
+			resetReplicationState(eachClient.Address)
+
+			topologyMutex.Lock()
+			delete(masterSlaveMap, eachClient.Address)
+			delete(slaveMasterMap, eachClient.Address)
+			delete(redisMutexMap, eachClient.Address)
+			topologyMutex.Unlock()
+
+			eachClient.Pool.Close()
+		}
+
+		// 토폴로지에서 빠진 슬레이브의 전송 고루틴 종료 대기
+		for deadline := time.Now().Add(3 * replicationIdleCheck); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			replicaSenderMutex.Lock()
+			_, isRunning := replicaSenders[slaveClient.Address]
+			replicaSenderMutex.Unlock()
+
+			if !isRunning {
+				break
+			}
+		}
+
+		masterServer.Close()
+		slaveServer.Close()
+		cleanUpDataLogDirectory()
+	}
+}
+
+func TestConcurrentWritesSameKey(t *testing.T) {
+
+	key := "concurrent-key"
+
+	masterServer, slaveServer, cleanUp := setUpTestReplicaSet(t, key)
+	defer cleanUp()
+
+	// 반영 후 응답이 늦어지면, 나중에 반영된 쓰기가 먼저 기록될 수 있다
+	masterServer.SetMaxReplyDelay(2 * time.Millisecond)
+
+	masterClient, err := GetRedisClient(hash.GetHashSlotIndex(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for round := 0; round < 20; round++ {
+
+		waitGroup := sync.WaitGroup{}
+
+		for writer := 0; writer < 8; writer++ {
+
+			waitGroup.Add(1)
+
+			go func(writer int) {
+				defer waitGroup.Done()
+
+				value := strconv.Itoa(round) + "-" + strconv.Itoa(writer)
+
+				var err error
+				if writer%4 == 3 {
+					_, _, err = DeleteData(context.Background(), key, AckMaster)
+				} else {
+					_, err = SetData(context.Background(), key, value, AckMaster)
+				}
+				if err != nil {
+					t.Error(err)
+				}
+			}(writer)
+		}
+
+		waitGroup.Wait()
+
+		// 마스터의 마지막 쓰기까지 슬레이브가 반영할 때까지 대기
+		if err := masterClient.waitForAck(getReplicationState(masterClient.Address).sequence, AckAll); err != nil {
+			t.Fatal(err)
+		}
+
+		masterValue, isMasterSet := masterServer.Get(key)
+		slaveValue, isSlaveSet := slaveServer.Get(key)
+		if masterValue != slaveValue || isMasterSet != isSlaveSet {
+			t.Fatalf("round %d : master (%q, %v) != slave (%q, %v)", round, masterValue, isMasterSet, slaveValue, isSlaveSet)
+		}
+	}
+}
+
+func TestFullSyncFromMasterRedis(t *testing.T) {
+
+	masterServer, slaveServer, cleanUp := setUpTestReplicaSet(t)
+	defer cleanUp()
+
+	// 공백 / 줄바꿈 / 빈 값, 만료 시각이 있는 값
+	storedValues := map[string]string{
+		"spaces":  "a   b  ",
+		"newline": "line1\nline2",
+		"empty":   "",
+	}
+	for eachKey, eachValue := range storedValues {
+		masterServer.Execute("SET", eachKey, eachValue)
+	}
+	masterServer.Execute("SET", "expiring", "v", "EX", "100")
+	storedValues["expiring"] = "v"
+
+	masterClient, err := GetMasterWithAddress(masterServer.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slaveClient := getSlavesOf(masterClient.Address)[0]
+
+	if err := masterClient.copyDataTo(slaveClient); err != nil {
+		t.Fatal(err)
+	}
+
+	for eachKey, eachValue := range storedValues {
+		if slaveValue, isSet := slaveServer.Get(eachKey); !isSet || slaveValue != eachValue {
+			t.Errorf("%s : expected %q, got (%q, %v)", eachKey, eachValue, slaveValue, isSet)
+		}
+	}
+
+	if reply := slaveServer.Execute("PTTL", "expiring"); reply == ":-1\r\n" || reply == ":-2\r\n" {
+		t.Fatalf("expected remaining TTL to be copied, got %q", reply)
+	}
+
+	// 슬레이브 데이터 로그에도 같은 값이 남는다 (승격 후 재분배용)
+	slaveDataContainer := make(HashToDataMap)
+	if err := slaveClient.getLatestDataFromLog(slaveDataContainer); err != nil {
+		t.Fatal(err)
+	}
+	for eachKey, eachValue := range storedValues {
+		if loggedValue := slaveDataContainer[hash.GetHashSlotIndex(eachKey)][eachKey]; loggedValue != eachValue {
+			t.Errorf("%s : expected logged %q, got %q", eachKey, eachValue, loggedValue)
+		}
+	}
+}
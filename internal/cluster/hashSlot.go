@@ -10,6 +10,8 @@ import (
 type HashSlot struct {
 	slots             map[uint16]*RedisClient
 	redistributeMutex *sync.Mutex
+	// slotsMutex : 요청의 조회 (get) 와 Failover / 재분배의 할당 (assign) 동기화
+	slotsMutex *sync.RWMutex
 }
 
 var hashSlot HashSlot
@@ -21,7 +23,7 @@ var hashSlot HashSlot
 // used for sync in accessing Hash Maps After Redistribution
 // var redistributeSlotMutex = &sync.Mutex{}
 
-// clientHashRangeMap : Redis Client 주소 -> 담당하는 해쉬 슬롯 구간들
+// clientHashRangeMap : Redis Client 주소 -> 담당하는 해쉬 슬롯 구간들 (topologyMutex 로 보호)
 var clientHashRangeMap map[string][]HashRange
 
 type HashRange struct {
@@ -36,9 +38,69 @@ func init() {
 	if hashSlot.redistributeMutex == nil {
 		hashSlot.redistributeMutex = &sync.Mutex{}
 	}
+	if hashSlot.slotsMutex == nil {
+		hashSlot.slotsMutex = &sync.RWMutex{}
+	}
+}
+
+// hashRangesOf : @address 가 담당하는 해쉬 슬롯 구간들의 복사본
+//
+func hashRangesOf(address string) []HashRange {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return append([]HashRange(nil), clientHashRangeMap[address]...)
+}
+
+// appendHashRange : @address 가 담당하는 해쉬 슬롯 구간에 @hashRange 추가
+//
+func appendHashRange(address string, hashRange HashRange) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	clientHashRangeMap[address] = append(clientHashRangeMap[address], hashRange)
+}
+
+// setHashRangeStart : @address 의 @idx 번째 해쉬 슬롯 구간 시작을 @start 로 변경
+//
+func setHashRangeStart(address string, idx int, start uint16) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	if idx < len(clientHashRangeMap[address]) {
+		clientHashRangeMap[address][idx].startIndex = start
+	}
+}
+
+// moveHashRanges : @fromAddress 의 해쉬 슬롯 구간들을 @toAddress 로 옮긴다 (@fromAddress 는 제거)
+//
+func moveHashRanges(fromAddress string, toAddress string) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	clientHashRangeMap[toAddress] = append(clientHashRangeMap[toAddress], clientHashRangeMap[fromAddress]...)
+	delete(clientHashRangeMap, fromAddress)
+}
+
+// removeHashRanges : @address 의 해쉬 슬롯 구간 제거 (Garbage Collect)
+//
+func removeHashRanges(address string) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	delete(clientHashRangeMap, address)
 }
 
 func (hashSlot HashSlot) get(slotIndex uint16) *RedisClient {
+
+	hashSlot.slotsMutex.RLock()
+	defer hashSlot.slotsMutex.RUnlock()
+
 	return hashSlot.slots[slotIndex]
 }
 
@@ -61,6 +123,8 @@ func (hashSlot *HashSlot) assign(redisClient *RedisClient, start uint16, end uin
 		end,
 	)
 
+	hashSlot.slotsMutex.Lock()
+
 	var i uint16
 	nextSlotIndex := start + 16
 	// Replace Hash Map With Slave Client
@@ -88,6 +152,10 @@ func (hashSlot *HashSlot) assign(redisClient *RedisClient, start uint16, end uin
 		hashSlot.slots[i] = redisClient
 	}
 
+	hashSlot.slotsMutex.Unlock()
+
+	bumpTopologyEpoch()
+
 	tools.InfoLogger.Printf(msg.HashSlotAssignFinish, redisClient.Address)
 
 }
@@ -98,16 +166,18 @@ func (hashSlot *HashSlot) assign(redisClient *RedisClient, start uint16, end uin
 func (hashSlot *HashSlot) distributeFrom(srcClient *RedisClient) error {
 
 	tools.InfoLogger.Printf(msg.HashSlotRedistributeStart, srcClient.Address)
-	tools.InfoLogger.Printf(msg.DeadRedisNodeInfo, srcClient.Address, srcClient.Role)
+	tools.InfoLogger.Printf(msg.DeadRedisNodeInfo, srcClient.Address, srcClient.currentRole())
 
-	if len(clientHashRangeMap[srcClient.Address]) == 0 {
+	srcHashRanges := hashRangesOf(srcClient.Address)
+	if len(srcHashRanges) == 0 {
 		return fmt.Errorf(msg.NoHashRangeIsAssigned, srcClient.Address)
 	}
 
 	hashSlot.redistributeMutex.Lock()
 	defer hashSlot.redistributeMutex.Unlock()
 
-	restOfMasterNumber := len(redisMasterClients) - 1
+	masterClients := getMasterClientList()
+	restOfMasterNumber := len(masterClients) - 1
 
 	if restOfMasterNumber < 1 {
 		tools.ErrorLogger.Println(msg.NoMasterClients)
@@ -118,7 +188,7 @@ func (hashSlot *HashSlot) distributeFrom(srcClient *RedisClient) error {
 	}
 
 	// srcClient가 담당하던 해쉬 슬롯 범위에 대해
-	for _, eachHashRangeOfClient := range clientHashRangeMap[srcClient.Address] {
+	for _, eachHashRangeOfClient := range srcHashRanges {
 
 		srcHashSlotStart := eachHashRangeOfClient.startIndex
 		srcHashSlotEnd := eachHashRangeOfClient.endIndex
@@ -133,7 +203,7 @@ func (hashSlot *HashSlot) distributeFrom(srcClient *RedisClient) error {
 
 		i := 0 // 임의의 마스터 클라이언트 인덱스
 		// 다른 마스터에게 해쉬 슬롯 균일 분배
-		for idx, eachMasterNode := range redisMasterClients {
+		for idx, eachMasterNode := range masterClients {
 
 			if eachMasterNode.Address != srcClient.Address {
 
@@ -148,22 +218,21 @@ func (hashSlot *HashSlot) distributeFrom(srcClient *RedisClient) error {
 
 				hashSlotStart := normalizedHashSlotStart + srcHashSlotStart
 				hashSlotEnd := normalizedhashSlotEnd + srcHashSlotStart
-				hashSlot.assign(redisMasterClients[idx], hashSlotStart, hashSlotEnd)
+				hashSlot.assign(masterClients[idx], hashSlotStart, hashSlotEnd)
 
 				newHashRange := HashRange{
 					startIndex: hashSlotStart,
 					endIndex:   hashSlotEnd,
 				}
 
-				clientHashRangeMap[eachMasterNode.Address] = append(
-					clientHashRangeMap[eachMasterNode.Address],
-					newHashRange,
-				)
+				appendHashRange(eachMasterNode.Address, newHashRange)
 				i++
 			}
 		}
 	}
 
+	bumpTopologyEpoch()
+
 	// srcClient가 저장하고 있던 데이터 Migration
 	if err := srcClient.migrateDataToOthers(); err != nil {
 		return err
@@ -183,21 +252,21 @@ func (hashSlot *HashSlot) distributeFrom(srcClient *RedisClient) error {
 
 func PrintCurrentMasterSlaves() {
 
-	for _, eachMaster := range redisMasterClients {
+	for _, eachMaster := range getMasterClientList() {
 		tools.InfoLogger.Printf(msg.RefreshedMasters, eachMaster.Address)
 		tools.InfoLogger.Printf(
 			msg.RedisRole,
 			eachMaster.Address,
-			eachMaster.Role,
+			eachMaster.currentRole(),
 		)
 	}
 
-	for _, eachSlave := range redisSlaveClients {
+	for _, eachSlave := range getSlaveClientList() {
 		tools.InfoLogger.Printf(msg.RefreshedSlaves, eachSlave.Address)
 		tools.InfoLogger.Printf(
 			msg.RedisRole,
 			eachSlave.Address,
-			eachSlave.Role,
+			eachSlave.currentRole(),
 		)
 	}
 }
@@ -211,17 +280,21 @@ func (hashSlot *HashSlot) distributeTo(destClient *RedisClient) bool {
 
 	notDistributed := false
 
-	for _, eachMaster := range redisMasterClients {
+	masterClients := getMasterClientList()
+
+	for _, eachMaster := range masterClients {
 		fmt.Printf("distributeTo() : 소스 마스터 노드 주소 - %s\n", eachMaster.Address)
 		// 새로 추가된 마스터가 아닌 경우
 		if eachMaster.Address != destClient.Address {
 
+			srcHashRanges := hashRangesOf(eachMaster.Address)
+
 			fmt.Printf(
 				"distributeTo() : 소스 마스터 할당 해쉬 슬롯 범위 개수 : %d\n",
-				len(clientHashRangeMap[eachMaster.Address]),
+				len(srcHashRanges),
 			)
 
-			for _, eachRange := range clientHashRangeMap[eachMaster.Address] {
+			for _, eachRange := range srcHashRanges {
 
 				fmt.Printf(
 					"distributeTo() : 소스 마스터 할당 해쉬 슬롯 범위 (%d ~ %d)\n",
@@ -231,19 +304,22 @@ func (hashSlot *HashSlot) distributeTo(destClient *RedisClient) bool {
 			}
 
 			// 기존 마스터가 담당하는 해쉬 슬롯 범위들
-			for idx, eachRange := range clientHashRangeMap[eachMaster.Address] {
+			for idx, eachRange := range srcHashRanges {
 
 				srcHashSlotRange := eachRange.endIndex - eachRange.startIndex + 1
 
 				// 새로운 마스터가 할당 받은 해쉬슬롯 크기 : (기존 마스터 담당 해쉬 슬롯 / n)
 				destHashSlotRange := uint16(
-					float64(1) / float64(len(redisMasterClients)) * float64(srcHashSlotRange),
+					float64(1) / float64(len(masterClients)) * float64(srcHashSlotRange),
 				)
 				destHashSlotStart := eachRange.startIndex
 				destHashSlotEnd := destHashSlotStart + destHashSlotRange
 
 				// To-Do :해쉬 슬롯을 더 이상 나눌 수 없을 정도로 레디스 클라이언트의 개수가 많아 질 때 에러
 
+				// reshardData() 로 데이터가 옮겨질 때까지 기존 마스터 소유로 보고 (CLUSTER SLOTS / ASK)
+				startSlotMigration(destHashSlotStart, destHashSlotEnd, eachMaster, destClient)
+
 				hashSlot.assign(destClient, destHashSlotStart, destHashSlotEnd)
 
 				newHashRange := HashRange{
@@ -251,13 +327,10 @@ func (hashSlot *HashSlot) distributeTo(destClient *RedisClient) bool {
 					endIndex:   destHashSlotEnd,
 				}
 
-				clientHashRangeMap[destClient.Address] = append(
-					clientHashRangeMap[destClient.Address],
-					newHashRange,
-				)
+				appendHashRange(destClient.Address, newHashRange)
 
 				// 기존 마스터가 나눠준 해쉬 슬롯 범위에 맞게 수정
-				clientHashRangeMap[eachMaster.Address][idx].startIndex = destHashSlotEnd
+				setHashRangeStart(eachMaster.Address, idx, destHashSlotEnd)
 
 				tools.InfoLogger.Printf(
 					msg.HashSlotAssignResult,
@@ -274,5 +347,7 @@ func (hashSlot *HashSlot) distributeTo(destClient *RedisClient) bool {
 		}
 	}
 
+	bumpTopologyEpoch()
+
 	return notDistributed
 }
@@ -0,0 +1,464 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 노드 상태 감지 (Health Detector)
+ * 요청 처리와 별개로 HealthCheckIntervalMs 마다 모든 노드에 PING, 성공한 PING 을 heartbeat 로 Phi Accrual (phi_accrual.go) 계산
+ *   - up      : phi < PHI_SUSPECT_THRESHOLD
+ *   - suspect : phi >= PHI_SUSPECT_THRESHOLD (요청은 그대로 보낸다)
+ *   - down    : phi >= PHI_DOWN_THRESHOLD, FAILOVER_MIN_DOWN_MS 이상 계속되면 마스터 Failover (handleIfDead, 모니터 서버 투표) 를 고루틴으로 시작
+ * 반복 전환 (flapping) 방지
+ *   - down 인 노드는 PING 이 HealthRecoveryHeartbeats 번 연속 성공해야 up
+ *   - Failover 로 승격된 마스터는 FAILOVER_COOLDOWN_MS 동안 다시 Failover 하지 않는다
+ * 요청 (GetRedisClient) 은 해쉬 슬롯 조회와 캐시된 상태 확인만 한다, down 인 마스터의 요청은 Failover 가 끝날 때까지 ErrNodeDown
+ * PING 은 Connection Pool 과 별개의 노드 별 전용 연결로 보낸다 (요청이 많아 Pool 이 가득 차도 오판하지 않도록)
+ * 모니터 서버도 같은 방식으로 노드를 확인하고 (Failover 없이), 상태 변경을 구독한 인터페이스 서버에 알린다 (monitor_probe.go)
+ */
+
+// NodeState : Health Detector 가 판단한 노드 상태
+type NodeState string
+
+const (
+	NodeUp      NodeState = "up"
+	NodeSuspect NodeState = "suspect"
+	NodeDown    NodeState = "down"
+)
+
+// ErrNodeDown : 담당 마스터가 down 상태 (Failover 진행 중)
+var ErrNodeDown = errors.New(msg.NodeDown)
+
+// NodeStateEvent : 노드 상태 변경 (모니터 서버 -> 인터페이스 서버 알림 형식)
+type NodeStateEvent struct {
+	Address  string    `json:"address"`
+	Previous NodeState `json:"previous"`
+	Current  NodeState `json:"current"`
+	Phi      float64   `json:"phi"`
+	Time     time.Time `json:"time"`
+	// Monitor : 알린 모니터 서버 주소 (모니터 서버가 채운다)
+	Monitor string `json:"monitor,omitempty"`
+}
+
+// HealthRecord : 노드 별 최근 확인 결과 (HealthHistorySize 개)
+type HealthRecord struct {
+	Time  time.Time `json:"time"`
+	State NodeState `json:"state"`
+	Phi   float64   `json:"phi"`
+	// Error : PING 실패 이유, 성공이면 빈 문자열
+	Error string `json:"error,omitempty"`
+}
+
+type nodeHealth struct {
+	state NodeState
+	// phi : 마지막 확인 때 계산한 phi
+	phi     float64
+	history *heartbeatHistory
+	// isLastProbeOk, successfulProbes : 마지막 PING 성공 여부, 연속 성공 횟수 (down 에서 up 으로 돌아올 때 사용)
+	isLastProbeOk    bool
+	successfulProbes int
+	// downSince : down 이 된 시각
+	downSince time.Time
+	// promotedAt : Failover 로 마스터가 된 시각
+	promotedAt time.Time
+	// isFailingOver : Failover 고루틴 진행 중
+	isFailingOver bool
+	// records : 최근 확인 결과 (HealthHistorySize 개 순환)
+	records     []HealthRecord
+	recordIndex int
+	// probeMutex, connection : PING 전용 연결, 한 번에 하나의 확인만 사용
+	probeMutex sync.Mutex
+	connection redis.Conn
+}
+
+// nodeHealthMap : 노드 주소 -> 상태, 확인 전인 노드는 up (연결 설정 시 PING 으로 확인)
+var nodeHealthMap map[string]*nodeHealth
+var nodeHealthMutex *sync.RWMutex
+
+// nodeStateListeners : 상태 변경 시 호출 (모니터 서버의 알림 전송 등), Health Detector 시작 전에 등록
+var nodeStateListeners []func(NodeStateEvent)
+
+func init() {
+	if nodeHealthMap == nil {
+		nodeHealthMap = make(map[string]*nodeHealth)
+	}
+	if nodeHealthMutex == nil {
+		nodeHealthMutex = &sync.RWMutex{}
+	}
+}
+
+// GetNodeState : @address 노드의 캐시된 상태
+//
+func GetNodeState(address string) NodeState {
+
+	nodeHealthMutex.RLock()
+	defer nodeHealthMutex.RUnlock()
+
+	health, isSet := nodeHealthMap[address]
+	if !isSet {
+		return NodeUp
+	}
+
+	return health.state
+}
+
+// isFailingOver : @address 마스터의 Failover 가 진행 중인지
+//
+func isFailingOver(address string) bool {
+
+	nodeHealthMutex.RLock()
+	defer nodeHealthMutex.RUnlock()
+
+	health, isSet := nodeHealthMap[address]
+
+	return isSet && health.isFailingOver
+}
+
+// resetNodeHealth : 새로 연결한 노드는 up 상태로 heartbeat 기록을 새로 시작 (PING 전용 연결은 다음 확인 때 다시 연결)
+//
+func resetNodeHealth(address string) {
+
+	nodeHealthMutex.Lock()
+	defer nodeHealthMutex.Unlock()
+
+	if health, isSet := nodeHealthMap[address]; isSet {
+		health.restart(time.Now())
+	}
+}
+
+// healthOf : @address 노드의 상태 (없으면 지금 heartbeat 를 받은 up 상태로 생성, 연결 설정 시 PING 으로 확인했으므로)
+//
+func healthOf(address string) *nodeHealth {
+
+	nodeHealthMutex.Lock()
+	defer nodeHealthMutex.Unlock()
+
+	health, isSet := nodeHealthMap[address]
+	if !isSet {
+		health = &nodeHealth{}
+		health.restart(time.Now())
+		nodeHealthMap[address] = health
+	}
+
+	return health
+}
+
+// restart : @now 에 heartbeat 를 받은 up 상태로 초기화, nodeHealthMutex 를 잡고 호출
+//
+func (health *nodeHealth) restart(now time.Time) {
+	health.state = NodeUp
+	health.phi = 0
+	health.history = newHeartbeatHistory(now)
+	health.isLastProbeOk = true
+	health.successfulProbes = 0
+	health.downSince = time.Time{}
+}
+
+// StartHealthDetector : HealthCheckIntervalMs 마다 모든 노드 상태 확인 고루틴 시작, down 인 마스터는 Failover
+//
+func StartHealthDetector() {
+	runHealthDetector(func(masterClient *RedisClient) {
+		masterClient.startFailover(false)
+	})
+}
+
+// runHealthDetector : HealthCheckIntervalMs 마다 checkNodeHealth, @onMasterDown 이 nil 이면 상태만 기록
+//
+func runHealthDetector(onMasterDown func(*RedisClient)) {
+
+	go func() {
+
+		ticker := time.NewTicker(configs.HealthCheckIntervalMs * time.Millisecond)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			checkNodeHealth(onMasterDown)
+		}
+	}()
+}
+
+// checkNodeHealth : 모든 마스터 / 슬레이브에 동시에 PING, 모두 끝날 때까지 기다린다
+//
+func checkNodeHealth(onMasterDown func(*RedisClient)) {
+
+	nodes := append(getMasterClientList(), getSlaveClientList()...)
+
+	waitGroup := sync.WaitGroup{}
+
+	for _, eachNode := range nodes {
+
+		waitGroup.Add(1)
+
+		go func(node *RedisClient) {
+			defer waitGroup.Done()
+
+			state := recordHealthProbe(node.Address, probeNode(node.Address), time.Now())
+			if state == NodeDown && onMasterDown != nil && node.currentRole() == MasterRole {
+				onMasterDown(node)
+			}
+		}(eachNode)
+	}
+
+	waitGroup.Wait()
+}
+
+// probeNode : @address 노드의 전용 연결로 PING
+// 기존 연결이 실패하면 (노드 재시작 등) 한 번 다시 연결해서 확인
+//
+func probeNode(address string) error {
+
+	health := healthOf(address)
+	timeout := time.Duration(getNodeTimeouts(address).ConnectMs) * time.Millisecond
+
+	health.probeMutex.Lock()
+	defer health.probeMutex.Unlock()
+
+	for attempt := 0; attempt < 2; attempt++ {
+
+		isReused := health.connection != nil
+
+		if !isReused {
+			connection, err := DialRedis(address)
+			if err != nil {
+				return err
+			}
+			health.connection = connection
+		}
+
+		_, err := redis.DoWithTimeout(health.connection, timeout, "PING")
+		if err == nil {
+			return nil
+		}
+
+		health.connection.Close()
+		health.connection = nil
+
+		if !isReused {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordHealthProbe : @now 의 PING 결과 기록 후 현재 상태, 상태가 바뀌면 nodeStateListeners 호출
+//
+func recordHealthProbe(address string, err error, now time.Time) NodeState {
+
+	health := healthOf(address)
+
+	nodeHealthMutex.Lock()
+
+	if err == nil {
+		health.history.heartbeat(now, health.isLastProbeOk)
+		health.successfulProbes++
+	} else {
+		health.successfulProbes = 0
+	}
+	health.isLastProbeOk = err == nil
+
+	previousState := health.state
+	health.phi = health.history.phi(now)
+
+	switch {
+	case health.phi >= phiDownThreshold:
+		health.state = NodeDown
+
+	case health.phi >= phiSuspectThreshold:
+		// down 은 PING 이 다시 성공할 때까지 유지
+		if health.state != NodeDown {
+			health.state = NodeSuspect
+		}
+
+	case health.state != NodeDown || health.successfulProbes >= configs.HealthRecoveryHeartbeats:
+		health.state = NodeUp
+	}
+
+	if health.state == NodeDown && previousState != NodeDown {
+		health.downSince = now
+	}
+
+	health.addRecord(now, err)
+
+	event := NodeStateEvent{
+		Address:  address,
+		Previous: previousState,
+		Current:  health.state,
+		Phi:      health.phi,
+		Time:     now,
+	}
+
+	nodeHealthMutex.Unlock()
+
+	if event.Current != event.Previous {
+		tools.InfoLogger.Printf(msg.NodeStateChanged, address, event.Previous, event.Current, event.Phi)
+
+		for _, eachListener := range nodeStateListeners {
+			eachListener(event)
+		}
+	}
+
+	return event.Current
+}
+
+// addRecord : 확인 결과 기록, nodeHealthMutex 를 잡고 호출
+//
+func (health *nodeHealth) addRecord(now time.Time, err error) {
+
+	record := HealthRecord{Time: now, State: health.state, Phi: health.phi}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	if len(health.records) < configs.HealthHistorySize {
+		health.records = append(health.records, record)
+		return
+	}
+
+	health.records[health.recordIndex] = record
+	health.recordIndex = (health.recordIndex + 1) % configs.HealthHistorySize
+}
+
+// GetHealthHistory : @address 노드의 최근 확인 결과 (오래된 순)
+//
+func GetHealthHistory(address string) []HealthRecord {
+
+	nodeHealthMutex.RLock()
+	defer nodeHealthMutex.RUnlock()
+
+	health, isSet := nodeHealthMap[address]
+	if !isSet {
+		return []HealthRecord{}
+	}
+
+	records := append([]HealthRecord{}, health.records[health.recordIndex:]...)
+
+	return append(records, health.records[:health.recordIndex]...)
+}
+
+// canFailover : down 이 failoverMinDown 이상 계속되었고, 최근에 승격된 마스터가 아니면 true, nodeHealthMutex 를 잡고 호출
+// @isReported : 모니터 서버가 down 을 알렸고 이 서버의 PING 도 실패한 경우, down 상태 / 최소 down 시간은 확인하지 않는다
+//
+func (health *nodeHealth) canFailover(now time.Time, isReported bool) bool {
+
+	if health.isFailingOver {
+		return false
+	}
+
+	if !isReported && (health.state != NodeDown || now.Sub(health.downSince) < failoverMinDown) {
+		return false
+	}
+
+	return health.promotedAt.IsZero() || now.Sub(health.promotedAt) >= failoverCooldown
+}
+
+// startFailover : down 상태인 masterClient 인스턴스의 Failover 를 고루틴으로 시작 (canFailover 가 아니면 무시)
+// 모니터 서버 투표 (handleIfDead) 에서 과반수가 살아있다고 판단하면 다시 up
+//
+func (masterClient *RedisClient) startFailover(isReported bool) {
+
+	health := healthOf(masterClient.Address)
+
+	nodeHealthMutex.Lock()
+	if !health.canFailover(time.Now(), isReported) {
+		nodeHealthMutex.Unlock()
+		return
+	}
+	health.isFailingOver = true
+	phi := health.phi
+	nodeHealthMutex.Unlock()
+
+	go func() {
+
+		defer func() {
+			nodeHealthMutex.Lock()
+			health.isFailingOver = false
+			nodeHealthMutex.Unlock()
+		}()
+
+		groupMutex := mutexOf(masterClient.Address)
+		groupMutex.Lock()
+		defer groupMutex.Unlock()
+
+		// 다른 경로 (슬레이브 이동, 노드 추가) 로 이미 마스터가 아니게 된 경우
+		if masterClient.currentRole() != MasterRole {
+			return
+		}
+
+		tools.InfoLogger.Printf(msg.HealthFailoverStart, masterClient.Address, phi)
+
+		slaveClients := getSlavesOf(masterClient.Address)
+
+		if err := masterClient.handleIfDead(); err != nil {
+			tools.ErrorLogger.Printf(msg.HealthFailoverFail, masterClient.Address, err.Error())
+			return
+		}
+
+		nodeHealthMutex.Lock()
+		defer nodeHealthMutex.Unlock()
+
+		// 승격 / 재분배 없이 끝났으면 과반수가 살아있다고 판단 (재연결 성공)
+		if masterClient.currentRole() == MasterRole {
+			health.restart(time.Now())
+			return
+		}
+
+		// 승격된 슬레이브는 마스터로 heartbeat 기록을 새로 시작하고, 당분간 다시 Failover 하지 않는다
+		for _, eachSlave := range slaveClients {
+			if eachSlave.currentRole() != MasterRole {
+				continue
+			}
+			if promotedHealth, isSet := nodeHealthMap[eachSlave.Address]; isSet {
+				promotedHealth.restart(time.Now())
+				promotedHealth.promotedAt = time.Now()
+			}
+		}
+	}()
+}
+
+// HandleMonitorEvent : 모니터 서버가 알린 상태 변경 처리
+// 마스터가 down 이면 바로 PING 으로 확인하고, 이 서버도 응답을 받지 못하면 최소 down 시간을 기다리지 않고 Failover 시작
+// (Failover 는 handleIfDead 에서 모니터 서버 과반수 투표로 다시 확인한다)
+//
+func HandleMonitorEvent(event NodeStateEvent) {
+
+	tools.InfoLogger.Printf(msg.MonitorEventReceived, event.Monitor, event.Address, event.Previous, event.Current, event.Phi)
+
+	if event.Current != NodeDown {
+		return
+	}
+
+	masterClient, err := GetMasterWithAddress(event.Address)
+	if err != nil {
+		return
+	}
+
+	probeErr := probeNode(event.Address)
+	recordHealthProbe(event.Address, probeErr, time.Now())
+
+	if probeErr == nil {
+		return
+	}
+
+	masterClient.startFailover(true)
+}
+
+// getNodeHealthError : 요청 경로의 상태 확인, down 이면 ErrNodeDown
+//
+func getNodeHealthError(address string) error {
+
+	if GetNodeState(address) == NodeDown {
+		return fmt.Errorf("%w (%s)", ErrNodeDown, address)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/tools"
+)
+
+func TestPhiOf(t *testing.T) {
+
+	// 평균 간격 근처에서는 작고, 응답이 없는 시간이 길어질수록 계속 커진다
+	previousPhi := phiOf(0, 2000, 500)
+	for elapsed := 500.0; elapsed <= 10000; elapsed += 500 {
+		phi := phiOf(elapsed, 2000, 500)
+		if phi < previousPhi {
+			t.Fatalf("phi decreased at %.0fms : %.2f < %.2f", elapsed, phi, previousPhi)
+		}
+		previousPhi = phi
+	}
+
+	if phi := phiOf(2000, 2000, 500); math.Abs(phi-math.Log10(2)) > 0.01 {
+		t.Fatalf("expected phi(mean) = log10(2), got %.3f", phi)
+	}
+	if phi := phiOf(100000, 2000, 500); !math.IsInf(phi, 1) {
+		t.Fatalf("expected +Inf for a very long pause, got %.2f", phi)
+	}
+}
+
+func TestRecordHealthProbe(t *testing.T) {
+
+	// 상태 변경 로그
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+
+	address := "10.0.4.1:8000"
+	defer func() {
+		nodeHealthMutex.Lock()
+		delete(nodeHealthMap, address)
+		nodeHealthMutex.Unlock()
+	}()
+
+	if state := GetNodeState(address); state != NodeUp {
+		t.Fatalf("expected unchecked node to be up, got %s", state)
+	}
+
+	// 상태 변경 알림
+	changedStates := []NodeState{}
+	nodeStateListeners = append(nodeStateListeners, func(event NodeStateEvent) {
+		if event.Address == address {
+			changedStates = append(changedStates, event.Current)
+		}
+	})
+	defer func() {
+		nodeStateListeners = nodeStateListeners[:len(nodeStateListeners)-1]
+	}()
+
+	health := healthOf(address)
+	start := health.history.lastHeartbeat
+	second := func(n int) time.Time {
+		return start.Add(time.Duration(n) * time.Second)
+	}
+
+	// HealthCheckIntervalMs 간격의 heartbeat
+	for i := 1; i <= 10; i++ {
+		recordHealthProbe(address, nil, second(i))
+	}
+
+	// PING 한두 번 실패는 허용 (PhiAcceptablePauseMs)
+	probeError := errors.New("connection refused")
+	expectedStates := []NodeState{NodeUp, NodeUp, NodeUp, NodeSuspect, NodeDown}
+	for i, expected := range expectedStates {
+		if state := recordHealthProbe(address, probeError, second(11+i)); state != expected {
+			t.Fatalf("%ds after last heartbeat : expected %s, got %s (phi %.2f)", i+1, expected, state, health.phi)
+		}
+	}
+	if err := getNodeHealthError(address); !errors.Is(err, ErrNodeDown) {
+		t.Fatalf("expected ErrNodeDown, got %v", err)
+	}
+
+	// down 이 failoverMinDown 이상 계속되어야 Failover
+	if health.canFailover(second(15).Add(failoverMinDown-time.Millisecond), false) {
+		t.Fatal("failover should wait for the minimum down time")
+	}
+	if !health.canFailover(second(15).Add(failoverMinDown), false) {
+		t.Fatal("expected failover after the minimum down time")
+	}
+
+	// 모니터 서버가 알린 down 은 최소 down 시간을 기다리지 않는다
+	if !health.canFailover(second(15), true) {
+		t.Fatal("expected failover for a reported down master")
+	}
+
+	// 최근에 승격된 마스터는 다시 Failover 하지 않는다
+	health.promotedAt = second(15)
+	if health.canFailover(second(15).Add(failoverCooldown-time.Millisecond), false) {
+		t.Fatal("failover should wait for the cooldown after promotion")
+	}
+	health.promotedAt = time.Time{}
+
+	// down 에서 up 으로 돌아오려면 HealthRecoveryHeartbeats 번 연속 성공
+	for i := 1; i < configs.HealthRecoveryHeartbeats; i++ {
+		if state := recordHealthProbe(address, nil, second(15+i)); state != NodeDown {
+			t.Fatalf("expected down after %d heartbeats, got %s", i, state)
+		}
+	}
+	if state := recordHealthProbe(address, nil, second(15+configs.HealthRecoveryHeartbeats)); state != NodeUp {
+		t.Fatalf("expected up after %d heartbeats, got %s", configs.HealthRecoveryHeartbeats, state)
+	}
+
+	expectedChanges := []NodeState{NodeSuspect, NodeDown, NodeUp}
+	if len(changedStates) != len(expectedChanges) {
+		t.Fatalf("expected state changes %v, got %v", expectedChanges, changedStates)
+	}
+	for i := range expectedChanges {
+		if changedStates[i] != expectedChanges[i] {
+			t.Fatalf("expected state changes %v, got %v", expectedChanges, changedStates)
+		}
+	}
+
+	records := GetHealthHistory(address)
+	if len(records) != 10+len(expectedStates)+configs.HealthRecoveryHeartbeats {
+		t.Fatalf("unexpected history size %d", len(records))
+	}
+	if lastRecord := records[len(records)-1]; lastRecord.State != NodeUp || lastRecord.Error != "" {
+		t.Fatalf("unexpected last record : %+v", lastRecord)
+	}
+	if failedRecord := records[len(records)-1-configs.HealthRecoveryHeartbeats]; failedRecord.State != NodeDown || failedRecord.Error == "" {
+		t.Fatalf("unexpected failed record : %+v", failedRecord)
+	}
+
+	// 장애 동안의 긴 간격은 heartbeat 간격으로 기록하지 않는다
+	for _, eachInterval := range health.history.intervals {
+		if eachInterval > 2*configs.HealthCheckIntervalMs {
+			t.Fatalf("unexpected interval recorded : %.0fms", eachInterval)
+		}
+	}
+}
@@ -10,11 +10,28 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // dataLoggers gets a logger by passed-key of Each Node address
 var dataLoggers map[string] /* key = each Node's address*/ *log.Logger
 
+// dataLoggerMutex : dataLoggers 보호 (요청 / 복제 전송 고루틴의 기록과 Failover 의 로그 생성 / 삭제)
+var dataLoggerMutex *sync.RWMutex
+
+// dataLogPosition : 데이터 로그의 위치 (부분 재동기화용)
+type dataLogPosition struct {
+	// logID : 로그 파일 생성 시각 (UnixNano), 파일이 새로 만들어지면 (재분배, 삭제 후 생성) 바뀐다
+	logID uint64
+	// line : 기록된 줄 수
+	line int64
+}
+
+// dataLogPositions : 노드 주소 -> 현재 데이터 로그 위치
+var dataLogPositions map[string]dataLogPosition
+var dataLogPositionMutex *sync.Mutex
+
 type logFormat struct {
 	KeyValuePair
 	Command string
@@ -30,15 +47,8 @@ const (
 	logDirectory = "./internal/cluster/dump"
 
 	// dataLogFormat : 순서대로 (해쉬값, 명령, Key, Value)
-	dataLogFormat = "%d %s %s %s"
-)
-
-const (
-	/* constants for "index" of Data Log each lines */
-	hashIndexWord = iota
-	commandWord
-	keyWord
-	valueWord
+	// Key, Value 는 따옴표로 감싸 기록 (strconv.Quote), 공백 / 줄바꿈이 있는 값도 한 줄 한 단어로 남는다
+	dataLogFormat = "%d %s %q %q"
 )
 
 // KeyValueMap : Key -> Value map
@@ -56,6 +66,15 @@ func init() {
 	if dataLoggers == nil {
 		dataLoggers = make(map[string]*log.Logger)
 	}
+	if dataLoggerMutex == nil {
+		dataLoggerMutex = &sync.RWMutex{}
+	}
+	if dataLogPositions == nil {
+		dataLogPositions = make(map[string]dataLogPosition)
+	}
+	if dataLogPositionMutex == nil {
+		dataLogPositionMutex = &sync.Mutex{}
+	}
 }
 
 // SetUpModificationLogger 는 Data Modification이 일어날 때 파일에 기록을 하기 위한 로거 세터
@@ -77,6 +96,9 @@ func SetUpModificationLogger(nodeAddressList []string) {
 func createDataLogFile(address string) error {
 	filePath := fmt.Sprintf("%s/%s", logDirectory, address)
 
+	dataLoggerMutex.Lock()
+	defer dataLoggerMutex.Unlock()
+
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		fpLog, err := os.OpenFile(filePath,
 			os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
@@ -85,16 +107,23 @@ func createDataLogFile(address string) error {
 		}
 
 		dataLoggers[address] = log.New(fpLog, "", 0)
+
+		dataLogPositionMutex.Lock()
+		dataLogPositions[address] = dataLogPosition{logID: uint64(time.Now().UnixNano())}
+		dataLogPositionMutex.Unlock()
 	}
 
 	return nil
 }
 
-func (redisClient RedisClient) RecordModificationLog(command string, key string, value string) error {
+func (redisClient *RedisClient) RecordModificationLog(command string, key string, value string) error {
 
 	tools.InfoLogger.Printf(msg.RecordDataLogStart, redisClient.Address)
 
+	dataLoggerMutex.RLock()
 	targetDataLogger, isSet := dataLoggers[redisClient.Address]
+	dataLoggerMutex.RUnlock()
+
 	if isSet == false {
 		return fmt.Errorf(msg.DataLoggerSetupError)
 	}
@@ -108,13 +137,73 @@ func (redisClient RedisClient) RecordModificationLog(command string, key string,
 		value,
 	)
 
+	// 기록 후 증가 (읽는 쪽은 기록이 끝난 줄까지만 본다)
+	dataLogPositionMutex.Lock()
+	if position, isSet := dataLogPositions[redisClient.Address]; isSet {
+		position.line++
+		dataLogPositions[redisClient.Address] = position
+	}
+	dataLogPositionMutex.Unlock()
+
 	return nil
 }
 
+// currentDataLogPosition : @address 노드 데이터 로그의 현재 위치, 이 서버가 만든 로그 파일이 아니면 logID = 0
+//
+func currentDataLogPosition(address string) dataLogPosition {
+
+	dataLogPositionMutex.Lock()
+	defer dataLogPositionMutex.Unlock()
+
+	return dataLogPositions[address]
+}
+
+// readDataLogLines : @address 노드 데이터 로그의 (@from, @to] 번째 줄 (1부터 시작)
+// 로그 파일이 @to 줄보다 짧으면 에러
+//
+func readDataLogLines(address string, from int64, to int64) ([]logFormat, error) {
+
+	filePath := fmt.Sprintf("%s/%s", logDirectory, address)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	logs := []logFormat{}
+	lineNumber := int64(0)
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber < to && scanner.Scan() {
+
+		lineNumber++
+		if lineNumber <= from {
+			continue
+		}
+
+		_, eachLog, err := parseDataLogLine(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, eachLog)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(msg.FileScannerError, err.Error())
+	}
+
+	if lineNumber < to {
+		return nil, fmt.Errorf(msg.DataLogTooShort, address, lineNumber, to)
+	}
+
+	return logs, nil
+}
+
 // getLatestDataFromLog : 인스턴스의 데이터 로그파일을 읽어 @dataContainer에 (key, value)로 저장한다.
 // 동일한 Key 값에 대해서는 최신의 데이터가 저장된다.
 //
-func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap) error {
+func (redisClient *RedisClient) getLatestDataFromLog(dataContainer HashToDataMap) error {
 
 	tools.InfoLogger.Printf(msg.ReadDataLogStart, redisClient.Address)
 
@@ -132,21 +221,17 @@ func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap)
 	// 로그 파일의 끝까지 한 줄 씩 읽는다.
 	for scanner.Scan() {
 
-		// 공백 기준으로 Split
-		words := strings.Fields(scanner.Text())
-		hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
+		hashIndex, eachLog, err := parseDataLogLine(scanner.Text())
 		if err != nil {
-			return fmt.Errorf(msg.ParseHashIndexStringError)
+			return err
 		}
 
-		hashIndex := uint16(hashIndexIn64)
-
 		tools.InfoLogger.Printf(
 			msg.ReadDataLogEachLine,
 			hashIndex,
-			words[commandWord],
-			words[keyWord],
-			words[valueWord],
+			eachLog.Command,
+			eachLog.Key,
+			eachLog.Value,
 		)
 
 		if _, isSet := dataContainer[hashIndex]; !isSet {
@@ -155,15 +240,21 @@ func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap)
 
 		// 데이터 로그 => @dataContainer에 기록
 		// 가장 최신의 데이터만 기록에 남음 (이전 데이터 덮어씌움)
-		switch words[commandWord] {
+		switch eachLog.Command {
 		case "SET":
-			dataContainer[hashIndex][words[keyWord]] = words[valueWord]
+			dataContainer[hashIndex][eachLog.Key] = eachLog.Value
 			break
 		case "DEL":
-			delete(dataContainer[hashIndex], words[keyWord])
+			delete(dataContainer[hashIndex], eachLog.Key)
+			break
+		case "EXPIREAT":
+			// 이미 만료된 Key 만 제외, 남은 Key 는 만료 시각 없이 복사된다 (멤캐시 항목은 값의 만료 시각으로 확인)
+			if expiresAt, err := strconv.ParseInt(eachLog.Value, 10, 64); err == nil && expiresAt <= time.Now().Unix() {
+				delete(dataContainer[hashIndex], eachLog.Key)
+			}
 			break
 		default:
-			return fmt.Errorf(msg.UnsupportedCommand, words[commandWord])
+			return fmt.Errorf(msg.UnsupportedCommand, eachLog.Command)
 		}
 	}
 
@@ -177,7 +268,7 @@ func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap)
 }
 
 // readDataLogs reads Node's data log file and records the information in @hashIndexToKeyValuePairMap
-func (redisClient RedisClient) readDataLogs(hashIndexToLogFormatMap map[uint16][]logFormat) error {
+func (redisClient *RedisClient) readDataLogs(hashIndexToLogFormatMap map[uint16][]logFormat) error {
 	filePath := fmt.Sprintf("%s/%s", logDirectory, redisClient.Address)
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -187,32 +278,17 @@ func (redisClient RedisClient) readDataLogs(hashIndexToLogFormatMap map[uint16][
 
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		words := strings.Fields(scanner.Text())
-		hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
+		hashIndex, logFormat, err := parseDataLogLine(scanner.Text())
 		if err != nil {
-			return fmt.Errorf(msg.ParseHashIndexStringError)
-		}
-
-		hashIndex := uint16(hashIndexIn64)
-
-		var logFormat logFormat
-		logFormat.Key = words[keyWord]
-		logFormat.Value = words[valueWord]
-		logFormat.Command = words[commandWord]
-
-		// 공백을 기준으로 split을 하므로, Value 값이 쪼개진 경우 처리
-		if len(words) > 4 {
-			for i := 4; i < len(words); i++ {
-				logFormat.Value += fmt.Sprintf(" %s", words[i])
-			}
+			return err
 		}
 
 		tools.InfoLogger.Printf(
 			msg.ReadDataLogEachLine,
 			hashIndex,
-			words[commandWord],
-			words[keyWord],
-			words[valueWord],
+			logFormat.Command,
+			logFormat.Key,
+			logFormat.Value,
 		)
 
 		hashIndexToLogFormatMap[hashIndex] = append(
@@ -228,7 +304,76 @@ func (redisClient RedisClient) readDataLogs(hashIndexToLogFormatMap map[uint16][
 	return nil
 }
 
-func (redisClient RedisClient) createDataLogFile() error {
+// parseDataLogLine : 데이터 로그 한 줄 => (해쉬값, 명령 / Key / Value)
+// 따옴표 없이 기록된 이전 형식은 공백으로 나누고, Value 는 나머지 단어를 공백 하나로 잇는다
+//
+func parseDataLogLine(line string) (uint16, logFormat, error) {
+
+	var eachLog logFormat
+
+	// 해쉬값, 명령, (Key, Value)
+	words := strings.SplitN(line, " ", 3)
+	if len(words) < 3 {
+		return 0, eachLog, fmt.Errorf(msg.InvalidDataLogLine, line)
+	}
+
+	hashIndex, err := strconv.ParseUint(words[0], 10, 16)
+	if err != nil {
+		return 0, eachLog, fmt.Errorf(msg.ParseHashIndexStringError)
+	}
+	eachLog.Command = words[1]
+
+	if !strings.HasPrefix(words[2], `"`) {
+		keyValueWords := strings.Fields(words[2])
+		if len(keyValueWords) < 2 {
+			return 0, eachLog, fmt.Errorf(msg.InvalidDataLogLine, line)
+		}
+
+		eachLog.Key = keyValueWords[0]
+		eachLog.Value = strings.Join(keyValueWords[1:], " ")
+
+		return uint16(hashIndex), eachLog, nil
+	}
+
+	key, rest, err := unquotePrefix(words[2])
+	if err != nil || !strings.HasPrefix(rest, " ") {
+		return 0, eachLog, fmt.Errorf(msg.InvalidDataLogLine, line)
+	}
+
+	value, rest, err := unquotePrefix(rest[1:])
+	if err != nil || rest != "" {
+		return 0, eachLog, fmt.Errorf(msg.InvalidDataLogLine, line)
+	}
+
+	eachLog.Key = key
+	eachLog.Value = value
+
+	return uint16(hashIndex), eachLog, nil
+}
+
+// unquotePrefix : @text 앞의 따옴표 문자열 (strconv.Quote) 을 풀어 나머지 부분과 함께 반환
+//
+func unquotePrefix(text string) (string, string, error) {
+
+	if !strings.HasPrefix(text, `"`) {
+		return "", text, strconv.ErrSyntax
+	}
+
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			// 이스케이프된 다음 글자는 건너뛴다
+			i++
+		case '"':
+			value, err := strconv.Unquote(text[:i+1])
+			return value, text[i+1:], err
+		}
+	}
+
+	return "", text, strconv.ErrSyntax
+}
+
+func (redisClient *RedisClient) createDataLogFile() error {
 
 	if err := createDataLogFile(redisClient.Address); err != nil {
 		return err
@@ -237,14 +382,21 @@ func (redisClient RedisClient) createDataLogFile() error {
 	return nil
 }
 
-func (redisClient RedisClient) removeDataLogFile() error {
+func (redisClient *RedisClient) removeDataLogFile() error {
 	filePath := fmt.Sprintf("%s/%s", logDirectory, redisClient.Address)
 
+	dataLoggerMutex.Lock()
+	defer dataLoggerMutex.Unlock()
+
 	if err := os.Remove(filePath); err != nil {
 		return fmt.Errorf(msg.RemoveLogFileError, filePath, err.Error())
 	}
 
 	delete(dataLoggers, redisClient.Address)
 
+	dataLogPositionMutex.Lock()
+	delete(dataLogPositions, redisClient.Address)
+	dataLogPositionMutex.Unlock()
+
 	return nil
 }
@@ -0,0 +1,107 @@
+package cluster
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"hash_interface/tools"
+)
+
+// setUpTestDataLogDirectory : 테스트 작업 디렉토리에 데이터 로그 디렉토리 생성, 반환된 함수로 정리
+//
+func setUpTestDataLogDirectory(t *testing.T) func() {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	// logDirectory 의 첫 디렉토리 (테스트 작업 디렉토리 기준)
+	topDirectory := strings.Split(strings.TrimPrefix(logDirectory, "./"), "/")[0]
+	_, err := os.Stat(topDirectory)
+	isCreated := os.IsNotExist(err)
+
+	if err := os.MkdirAll(logDirectory, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if isCreated {
+			os.RemoveAll(topDirectory)
+		}
+	}
+}
+
+func TestDataLoggerConcurrentAccess(t *testing.T) {
+
+	defer setUpTestDataLogDirectory(t)()
+
+	recordingClient := &RedisClient{Address: "10.0.6.1:8000"}
+	recreatedClient := &RedisClient{Address: "10.0.6.2:8000"}
+
+	if err := createDataLogFile(recordingClient.Address); err != nil {
+		t.Fatal(err)
+	}
+	defer recordingClient.removeDataLogFile()
+
+	// 요청 / 복제 전송 고루틴의 기록과 Failover 의 로그 생성 / 삭제가 동시에 일어난다
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(2)
+
+	go func() {
+		defer waitGroup.Done()
+
+		for i := 0; i < 200; i++ {
+			if err := recordingClient.RecordModificationLog("SET", "k", "v"); err != nil {
+				t.Error(err)
+				return
+			}
+			recreatedClient.RecordModificationLog("SET", "k", "v")
+		}
+	}()
+
+	go func() {
+		defer waitGroup.Done()
+
+		for i := 0; i < 50; i++ {
+			createDataLogFile(recreatedClient.Address)
+			recreatedClient.removeDataLogFile()
+		}
+	}()
+
+	waitGroup.Wait()
+
+	if position := currentDataLogPosition(recordingClient.Address); position.line != 200 {
+		t.Fatalf("expected 200 records, got %d", position.line)
+	}
+}
+
+func TestParseDataLogLine(t *testing.T) {
+
+	testCases := []struct {
+		line      string
+		hashIndex uint16
+		log       logFormat
+	}{
+		{`12 SET "k" "a  b\nc"`, 12, logFormat{KeyValuePair{"k", "a  b\nc"}, "SET"}},
+		{`12 SET "k \"1\"" ""`, 12, logFormat{KeyValuePair{`k "1"`, ""}, "SET"}},
+		{`7 DEL "k" "-"`, 7, logFormat{KeyValuePair{"k", "-"}, "DEL"}},
+		// 따옴표 없이 기록된 이전 형식
+		{`12 SET k a b`, 12, logFormat{KeyValuePair{"k", "a b"}, "SET"}},
+	}
+
+	for _, eachCase := range testCases {
+		hashIndex, eachLog, err := parseDataLogLine(eachCase.line)
+		if err != nil || hashIndex != eachCase.hashIndex || eachLog != eachCase.log {
+			t.Errorf("%q : unexpected result %d %+v (%v)", eachCase.line, hashIndex, eachLog, err)
+		}
+	}
+
+	for _, invalidLine := range []string{"", "12 SET", "12 SET k", `12 SET "k`, `12 SET "k" "v" x`, `x SET "k" "v"`} {
+		if _, _, err := parseDataLogLine(invalidLine); err == nil {
+			t.Errorf("%q must be invalid", invalidLine)
+		}
+	}
+}
@@ -32,6 +32,43 @@ const (
 	ConnectionCloseFailure          = "RemoveFromList() : 레디스(%s) 커넥션 닫기 에러"
 	RedisRoleNotInit                = "RemoveFromList() : Redis Client(%s) Role has not been set!"
 	NoClientInList                  = "RemoveFromList() : Client(%s) not in its %s list"
+	KeyNotFound                     = "요청한 Key 가 존재하지 않습니다"
+	InvalidReadPreference           = "지원하지 않는 Read Preference 입니다 (master / prefer-replica / replica-only)"
+	ReplicaUnavailable              = "복제 지연 허용 범위 이내의 슬레이브가 없습니다"
+	InvalidSessionToken             = "세션 토큰 형식이 올바르지 않습니다"
+	ReplicaReadFail                 = "슬레이브 조회 실패 (Key : %s) - %s"
+	InvalidWriteAck                 = "지원하지 않는 복제 수준 (ack) 입니다 (master / replica / all)"
+	ReplicationAckFail              = "요청한 복제 수준 (ack) 을 만족하지 못했습니다 - 마스터에는 반영됨"
+	ReplicationAckResult            = "마스터(%s) 복제 수준(%s) 미달 - 슬레이브 반영 %d / %d"
+	ReplicateCommandFail            = "슬레이브(%s) 명령 전파 실패 - %s"
+	InvalidReplicationMode          = "지원하지 않는 복제 모드 : %s (stream / native)"
+	ReplicaOfFail                   = "슬레이브(%s) REPLICAOF 마스터(%s) 실패 - %s"
+	ReplicaConfigFail               = "복제 설정 (CONFIG SET %s) 실패 - %s"
+	ResyncPositionNotFound          = "슬레이브에 마스터 데이터 로그 위치가 없습니다"
+	InvalidResyncPosition           = "마스터 데이터 로그 위치 형식 오류 : %s"
+	ResyncTailUnavailable           = "마스터 데이터 로그에서 슬레이브 위치 (%v) 이후를 읽을 수 없습니다 (현재 : %v)"
+	ClearResyncPositionFail         = "승격된 노드(%s)의 데이터 로그 위치 삭제 실패 - %s"
+	ReplicaInfoFail                 = "슬레이브(%s) 복제 상태 (INFO replication) 확인 실패 - %s"
+	AddStandbyParameterError        = "NodeConnectionSetup() : StandbySetup option needs 1 address"
+	InvalidMinReplicas              = "마스터 별 최소 슬레이브 수 형식 오류 : %s (0 이상 정수)"
+	ReplicaMigrationFail            = "슬레이브(%s) 마스터(%s) 로 이동 실패 - %s"
+	StandbyNotFound                 = "새로 추가된 대기 노드 찾지 못함"
+	ReplicaMigrationStale           = "슬레이브 이동 계획 이후 마스터 / 슬레이브 구성이 바뀌었습니다 (Failover 등)"
+	NodeConfigParseError            = "레디스 노드 설정 파일(%s) 파싱 에러 - %s"
+	NodeTLSConfigError              = "레디스 노드(%s) TLS 설정 에러 - %s"
+	NodeAuthFail                    = "레디스 노드(%s) AUTH (user : %s) 실패 - %s"
+	InvalidPoolSize                 = "Connection Pool 설정 (%s) 형식 오류 : %s (0 이상 정수)"
+	NodePoolNotSetUp                = "레디스 노드(%s) Connection Pool 이 설정되지 않았습니다"
+	CircuitOpen                     = "레디스 노드의 Circuit Breaker 가 열려 있습니다 (연속된 연결 / 타임아웃 에러)"
+	CircuitOpened                   = "레디스 노드(%s) Circuit Breaker 열림 - 연속 에러 %d 회, 마지막 에러 : %s"
+	NodeDown                        = "담당 레디스 노드가 응답하지 않아 Failover 진행 중입니다"
+	HealthFailoverFail              = "레디스 마스터(%s) Failover 실패 (다음 확인 때 다시 시도) - %s"
+	InvalidPhiThreshold             = "장애 감지 설정 (%s) 형식 오류 : %s (0 보다 큰 실수)"
+	PhiThresholdOrder               = "suspect phi (%.2f) 는 down phi (%.2f) 보다 클 수 없습니다"
+	InvalidDetectorDuration         = "장애 감지 설정 (%s) 형식 오류 : %s (0 이상 정수, ms)"
+	InvalidCallbackURL              = "상태 변경 알림 Callback URL 형식 오류 : %s (http:// 또는 https://)"
+	CallbackURLNotAllowed           = "상태 변경 알림 Callback URL (%s) 은 모니터 서버에 설정된 인터페이스 서버 URL (MONITOR_EVENT_CALLBACK_URLS) 이 아닙니다"
+	SubscriberUnauthorized          = "상태 변경 알림 구독 요청 인증 실패 (MONITOR_SUBSCRIBE_TOKEN 또는 mutual TLS 인증서 필요)"
 
 	/* Data Log Related Messages*/
 	CreateLogFileError        = "데이터 로그파일 생성 오류"
@@ -42,11 +79,17 @@ const (
 	FileScannerError          = "데이터 로그 스캐너 에러 - %s"
 	RemoveLogFileError        = "데이터 로그 파일 %s 삭제 에러 - %s"
 	LogFailWhileMigration     = "노드(%s)의 데이터 로그 기록 중 에러"
+	RecordDataLogFail         = "노드(%s)의 데이터 로그 기록 실패 - %s"
+	DataLogTooShort           = "노드(%s)의 데이터 로그가 %d 줄 뿐입니다 (필요 : %d 줄)"
+	ScanKeysFail              = "노드(%s) Key 스캔 실패 - %s"
+	InvalidDataLogLine        = "데이터 로그 형식 오류 : %q"
 
 	/* Monitor server Messages */
 	UnsupportedMonitorRequest = "Moniter Client ask() : 지원하지 않는 옵션"
 	MonitorRequestTimeout     = "모니터 서버(%s) 요청 타임아웃(3sec) 에러"
-	CreateRequestError        = "requestUnregister() : 요청 생성 에러 %s"
+	CreateRequestError        = "모니터 서버(%s) 요청 생성 에러 - %s"
+	MonitorSubscribeFail      = "모니터 서버(%s) 상태 변경 알림 구독 실패 - %s"
+	MonitorEventPostFail      = "상태 변경 알림 전송 실패 (%s) - %s"
 
 	DockerInitFail    = "docker client init error"
 	ContainerNotFound = "No Such Container with IP : %s"
@@ -38,6 +38,20 @@ const (
 	SlaveIsAlive                = "투표결과 : 슬레이브(%s) 살아있음"
 	StartReplicaiton            = "슬레이브로 Replicate 시작"
 	EndReplication              = "슬레이브로 Replicate 종료"
+	ReplicaSenderStart          = "복제 스트림 시작 : 마스터 (%s) ==> 슬레이브 (%s)"
+	ReplicaSenderStop           = "복제 스트림 종료 : 마스터 (%s) ==> 슬레이브 (%s)"
+	ReplicaFullSync             = "슬레이브 (%s) 가 Backlog 범위를 벗어나 마스터 (%s) 데이터 전체 복사"
+	PartialResyncStart          = "슬레이브 (%s) 부분 재동기화 : 마스터 (%s) 데이터 로그 %d 줄 (%d 줄 이후) 적용"
+	FullResyncStart             = "슬레이브 (%s) 마스터 (%s) 데이터 전체 복사 - %s"
+	ReplicaOfMaster             = "슬레이브 (%s) ==REPLICAOF==> 마스터 (%s)"
+	ReplicaOfNoOne              = "슬레이브 (%s) REPLICAOF NO ONE (마스터 승격)"
+	ReplicaMonitorStart         = "복제 상태 감시 시작 (INFO replication) : 마스터 (%s) ==> 슬레이브 (%s)"
+	ReplicaMonitorStop          = "복제 상태 감시 종료 (INFO replication) : 마스터 (%s) ==> 슬레이브 (%s)"
+	ReplicaMigrationStart       = "슬레이브 이동 : %s (%s) ==> 마스터 (%s), 마스터 별 최소 슬레이브 수 %d"
+	ReplicaMigrationSuccess     = "슬레이브 (%s) 마스터 (%s) 로 이동 완료"
+	CircuitClosed               = "레디스 노드 (%s) Circuit Breaker 닫힘 (확인 요청 성공)"
+	NodeStateChanged            = "레디스 노드 (%s) 상태 변경 : %s -> %s (phi %.2f)"
+	HealthFailoverStart         = "레디스 마스터 (%s) down (phi %.2f), Failover 시작"
 
 	/* Data Log Related Messages */
 	RecordDataLogStart  = "%s 노드에 데이터 수정사항 로그 저장"
@@ -45,7 +59,11 @@ const (
 	ReadDataLogEachLine = "readDataLogs() : data log file read result : %d %s %s %s"
 
 	/* Monitor server Messages */
-	NewConnectRequest = "monitorClient askConnect() : %s 노드에 대해 새로 연결 요청"
+	NewConnectRequest      = "monitorClient askConnect() : %s 노드에 대해 새로 연결 요청"
+	MonitorEventReceived   = "모니터 서버 (%s) 알림 : 레디스 노드 (%s) %s -> %s (phi %.2f)"
+	EventSubscriberAdded   = "상태 변경 알림 구독 추가 : %s"
+	EventSubscriberExpired = "상태 변경 알림 구독 만료 : %s"
+	EventSubscriptionOff   = "허용된 Callback URL (MONITOR_EVENT_CALLBACK_URLS) 또는 구독 인증 수단 (MONITOR_SUBSCRIBE_TOKEN / TLS) 이 없어 상태 변경 알림 구독을 받지 않습니다"
 
 	ContainerStatus   = "RestartRedisContainer() : Redis container(%s) status : %s"
 	ContainerRestart  = "RestartRedisContainer() : Redis container(%s) restart"
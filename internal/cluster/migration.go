@@ -1,8 +1,11 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 	"github.com/gomodule/redigo/redis"
+	"strconv"
+	"time"
 
 	msg "hash_interface/internal/cluster/message"
 	"hash_interface/tools"
@@ -11,11 +14,11 @@ import (
 /************* Docker를 계속 restart 해준다는 가정 ******************/
 
 // migrateDataToOthers : deadClient 인스턴스의 데이터를 다른 마스터-슬레이브 세트에 할당
-//  - 과정 :
-//  1. deadClient의 데이터 로그 파일 읽기 => 최신 데이터 현황 생성
-//  2. deadClient를 제외한 다른 마스터에 데이터 분배
+//   - 과정 :
+//     1. deadClient의 데이터 로그 파일 읽기 => 최신 데이터 현황 생성
+//     2. deadClient를 제외한 다른 마스터에 데이터 분배
 //
-func (deadClient RedisClient) migrateDataToOthers() error {
+func (deadClient *RedisClient) migrateDataToOthers() error {
 
 	// deadClient의 로그 파일 읽기 => 최신 데이터 현황 생성
 	deadClientDataContainer := make(HashToDataMap)
@@ -29,16 +32,15 @@ func (deadClient RedisClient) migrateDataToOthers() error {
 		return err
 	}
 
-	// deadClient의 슬레이브의 로그 파일이 존재한다면 삭제
-	deadSlave, isExist := masterSlaveMap[deadClient.Address]
-	if isExist {
+	// deadClient의 슬레이브들의 로그 파일이 존재한다면 삭제
+	for _, deadSlave := range getSlavesOf(deadClient.Address) {
 		deadSlave.removeDataLogFile()
 	}
 
 	// deadClient의 데이터를, 해쉬 슬롯에 새로 매핑된 다른 마스터에 할당
 	for hashIndex, keyValueMap := range deadClientDataContainer {
 
-		newMappedClient := hashSlot.slots[hashIndex]
+		newMappedClient := hashSlot.get(hashIndex)
 
 		for eachKey, eachValue := range keyValueMap {
 
@@ -51,19 +53,15 @@ func (deadClient RedisClient) migrateDataToOthers() error {
 			)
 
 			// 레디스에 저장
-			_, err := redis.String(newMappedClient.Connection.Do("SET", eachKey, eachValue))
+			_, err := redis.String(newMappedClient.Do("SET", eachKey, eachValue))
 			if err != nil {
 				return err
 			}
 
-			// 저장 목표 마스터가 중간에 죽어도, 로그 파일에는 기록을 남김
-			err = newMappedClient.RecordModificationLog("SET", eachKey, eachValue)
-			if err != nil {
+			// 저장 목표 마스터가 중간에 죽어도, 로그 파일에는 기록을 남기고 슬레이브에게도 전파
+			if _, err := newMappedClient.recordWrite("SET", eachKey, eachValue); err != nil {
 				return fmt.Errorf(msg.LogFailWhileMigration, deadClient.Address)
 			}
-
-			// 저장 목표 마스터의 슬레이브에게도 전파
-			newMappedClient.ReplicateToSlave("SET", eachKey, eachValue)
 		}
 	}
 
@@ -73,9 +71,9 @@ func (deadClient RedisClient) migrateDataToOthers() error {
 
 // reshardData : 모든 마스터 클라이언트의 최신 데이터 로그 생성 & 현재 해쉬슬롯 기준 데이터 재분배
 //
-func (redisClient RedisClient) reshardData() error {
+func (redisClient *RedisClient) reshardData() error {
 
-	for _, srcMasterClient := range redisMasterClients {
+	for _, srcMasterClient := range getMasterClientList() {
 
 		// 마스터 클라이언트의 로그 파일 읽기 => 최신 데이터 현황 생성
 		dataOfSrcMaster := make(HashToDataMap)
@@ -95,7 +93,7 @@ func (redisClient RedisClient) reshardData() error {
 		// 마스터 클라이언트의 데이터를, 갱신된 해쉬 슬롯에 매핑된 마스터들에게 할당
 		for hashIndex, keyValueMap := range dataOfSrcMaster {
 
-			newMappedClient := hashSlot.slots[hashIndex]
+			newMappedClient := hashSlot.get(hashIndex)
 
 			// 갱신된 해쉬 슬롯에 매핑된 마스터가 변하지 않은 경우
 			if newMappedClient.Address == srcMasterClient.Address {
@@ -115,7 +113,7 @@ func (redisClient RedisClient) reshardData() error {
 
 					tools.ErrorLogger.Printf("데이터 로그 key : %s, value : %s", eachKey, eachValue)
 
-					redisResponse, err := redis.String(srcMasterClient.Connection.Do("GET", eachKey))
+					redisResponse, err := redis.String(srcMasterClient.Do("GET", eachKey))
 					if err == redis.ErrNil {
 						redisResponse = "nil(없음)"
 
@@ -126,7 +124,7 @@ func (redisClient RedisClient) reshardData() error {
 					tools.InfoLogger.Printf("키 : %s, 값 : %s", eachKey, redisResponse)
 
 					// 기존 데이터 주인이었던 마스터 클라이언트에서는 제거
-					_, err = srcMasterClient.Connection.Do("DEL", eachKey)
+					_, err = srcMasterClient.Do("DEL", eachKey)
 					if err != nil {
 						tools.ErrorLogger.Printf("데이터 삭제간 에러!")
 						return fmt.Errorf(msg.DeleteDataFail, err.Error())
@@ -141,16 +139,13 @@ func (redisClient RedisClient) reshardData() error {
 					)
 
 					// 새로 매핑된 마스터에 저장
-					_, err = redis.String(newMappedClient.Connection.Do("SET", eachKey, eachValue))
+					_, err = redis.String(newMappedClient.Do("SET", eachKey, eachValue))
 
-					// 새로 매핑된 마스터가 중간에 죽어도, 로그 파일에는 기록을 해놓는다
-					err = newMappedClient.RecordModificationLog("SET", eachKey, eachValue)
-					if err != nil {
+					// 새로 매핑된 마스터가 중간에 죽어도, 로그 파일에는 기록을 해놓고
+					// 데이터를 redisClient로 옮긴 후, redisClient의 슬레이브에게도 전파
+					if _, err := newMappedClient.recordWrite("SET", eachKey, eachValue); err != nil {
 						return fmt.Errorf(msg.LogFailWhileMigration, newMappedClient.Address)
 					}
-
-					// 데이터를 redisClient로 옮긴 후, redisClient의 슬레이브에게도 전파
-					newMappedClient.ReplicateToSlave("SET", eachKey, eachValue)
 				}
 			}
 		}
@@ -161,41 +156,80 @@ func (redisClient RedisClient) reshardData() error {
 }
 
 // copyDataTo : masterClient의 데이터를 슬레이브에 복사
-//  데이터 로그파일을 읽어 최신 데이터 만을 복사한다
+//  마스터 레디스를 SCAN 하여 문자열 Key 의 현재 값과 남은 만료 시간을 복사한다
 //
-func (masterClient RedisClient) copyDataTo(slaveClient RedisClient) error {
+func (masterClient *RedisClient) copyDataTo(slaveClient *RedisClient) error {
+
+	// 복사를 시작한 시점까지의 쓰기 / 데이터 로그 위치를 반영한 것으로 본다
+	// 이후의 쓰기는 복제 스트림으로 다시 전송되므로, 복사 중 바뀐 Key 도 마지막에는 마스터와 같아진다
+	copiedState, copiedPosition := masterClient.replicationSnapshot()
 
-	// masterClient의 최신 데이터 현황 생성
-	masterDataContainer := make(HashToDataMap)
-	if err := masterClient.getLatestDataFromLog(masterDataContainer); err != nil {
+	ctx := context.Background()
+
+	masterKeys, err := masterClient.scanKeys(ctx, "*")
+	if err != nil {
 		return err
 	}
 
-	for _, keyValueMap := range masterDataContainer {
+	for _, eachKey := range masterKeys {
 
-		for eachKey, eachValue := range keyValueMap {
+		// 마스터로 승격되기 전에 남은 로그 위치는 복사하지 않는다
+		if eachKey == resyncPositionKey {
+			continue
+		}
 
-			tools.InfoLogger.Printf(
-				msg.CopyFromMasterToSlave,
-				masterClient.Address,
-				eachKey,
-				eachValue,
-				slaveClient.Address,
-			)
+		eachValue, err := redis.String(masterClient.DoContext(ctx, "GET", eachKey))
+		if err == redis.ErrNil || isWrongTypeError(err) {
+			// 스캔 이후 삭제된 Key, 문자열이 아닌 Key
+			continue
 
-			// 슬레이브에 데이터 복사
-			_, err := redis.String(slaveClient.Connection.Do("SET", eachKey, eachValue))
-			if err != nil {
-				return err
-			}
+		} else if err != nil {
+			return err
+		}
 
-			// 슬레이브가 중간에 죽어도, 로그 파일에는 기록을 해놓는다
-			err = slaveClient.RecordModificationLog("SET", eachKey, eachValue)
-			if err != nil {
+		// -1 : 만료 없음, -2 : 그 사이 삭제됨 (0 이하면 만료 없이 저장, 삭제는 복제 스트림으로 전달)
+		ttlMs, err := redis.Int64(masterClient.DoContext(ctx, "PTTL", eachKey))
+		if err != nil {
+			return err
+		}
+
+		tools.InfoLogger.Printf(
+			msg.CopyFromMasterToSlave,
+			masterClient.Address,
+			eachKey,
+			eachValue,
+			slaveClient.Address,
+		)
+
+		// 슬레이브에 데이터 복사
+		if ttlMs > 0 {
+			_, err = redis.String(slaveClient.Do("SET", eachKey, eachValue, "PX", ttlMs))
+		} else {
+			_, err = redis.String(slaveClient.Do("SET", eachKey, eachValue))
+		}
+		if err != nil {
+			return err
+		}
+
+		// 슬레이브가 중간에 죽어도, 로그 파일에는 기록을 해놓는다
+		if err := slaveClient.RecordModificationLog("SET", eachKey, eachValue); err != nil {
+			tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
+		}
+
+		if ttlMs > 0 {
+			expiresAt := time.Now().Add(time.Duration(ttlMs) * time.Millisecond).Unix()
+			if err := slaveClient.RecordModificationLog("EXPIREAT", eachKey, strconv.FormatInt(expiresAt, 10)); err != nil {
 				tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
 			}
 		}
 	}
 
+	setReplicationState(slaveClient.Address, copiedState)
+
+	// 슬레이브가 다시 돌아오면 이 위치 이후만 적용 (resync.go)
+	if err := slaveClient.setResyncPosition(copiedPosition); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -19,6 +19,7 @@ type MasterSlaveMessage struct {
 	isCurrentSlaveDead bool
 }
 
+// @Deprecated : StartHealthDetector (health.go) 사용
 // StartMonitorNodes : 매 초 Redis Client들의 상태 확인/처리
 func StartMonitorNodes() {
 	errorChannel = make(chan error)
@@ -33,7 +34,7 @@ func StartMonitorNodes() {
 					errorChannel <- err
 				}
 
-				for _, eachMasterClient := range redisMasterClients {
+				for _, eachMasterClient := range getMasterClientList() {
 					go eachMasterClient.handleIfDeadWithLock(errorChannel)
 				}
 
@@ -2,12 +2,14 @@ package cluster
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"hash_interface/configs"
+	"hash_interface/internal/auth"
 	msg "hash_interface/internal/cluster/message"
 	"hash_interface/tools"
 )
@@ -15,10 +17,17 @@ import (
 // MonitorClient : Monitor Server들에게 요청을 보낼 Client
 type MonitorClient struct {
 	ServerAddressList []string
+
+	// scheme : TLS 설정 시 https://
+	scheme     string
+	httpClient *http.Client
 }
 
 var monitorClient MonitorClient
 
+// monitorSubscribeToken : 모니터 서버 구독 요청 토큰 (MONITOR_SUBSCRIBE_TOKEN), 빈 문자열이면 헤더 없이 요청
+var monitorSubscribeToken string
+
 // Question : 모니터 서버에게 요청할 수 있는 내용 옵션 종류
 type Question uint8
 
@@ -46,11 +55,38 @@ func init() {
 			configs.MonitorNodeTwoAddress,
 		}
 	}
+	if monitorClient.scheme == "" {
+		monitorClient.scheme = configs.HTTP
+	}
+	if monitorClient.httpClient == nil {
+		monitorClient.httpClient = &http.Client{}
+	}
+}
+
+// SetUpMonitorClientTLS : 모니터 서버와 mutual TLS 로 통신
+// 모니터 서버 인증서는 CA 로 검증하고 (위조된 호스트의 투표 차단),
+// 인터페이스 서버의 인증서를 클라이언트 인증서로 제시한다.
+//
+func SetUpMonitorClientTLS(tlsFiles tools.TLSFiles) error {
+
+	tlsConfig, err := tlsFiles.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	monitorClient.scheme = configs.HTTPS
+	monitorClient.httpClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	return nil
 }
 
 // askConnect : 모니터 서버들에게 @redisNode에 대한 연결 setup 요청
 //
-func (monitorClient MonitorClient) ask(redisNode RedisClient, question Question) (int, error) {
+func (monitorClient MonitorClient) ask(redisNode *RedisClient, question Question) (int, error) {
 
 	numberOfmonitorNode := len(monitorClient.ServerAddressList)
 
@@ -141,7 +177,7 @@ func (monitorClient MonitorClient) ask(redisNode RedisClient, question Question)
 //
 func (monitorClient MonitorClient) requestTest(monitorServerIp, redisNodeIp string, outputChannel chan<- MonitorServerResponse) {
 
-	requestURI := fmt.Sprintf("http://%s/monitor/%s", monitorServerIp, redisNodeIp)
+	requestURI := fmt.Sprintf("%s%s/monitor/%s", monitorClient.scheme, monitorServerIp, redisNodeIp)
 
 	tools.InfoLogger.Println(msg.RequestTargetMonitor, requestURI)
 
@@ -149,7 +185,7 @@ func (monitorClient MonitorClient) requestTest(monitorServerIp, redisNodeIp stri
 	go serverTimoutChecker(monitorServerIp, outputChannel)
 
 	// 모니터 서버에 요청
-	response, err := http.Get(requestURI)
+	response, err := monitorClient.httpClient.Get(requestURI)
 	if err != nil {
 		tools.ErrorLogger.Printf(msg.ResponseMonitorError, monitorServerIp, err)
 
@@ -170,10 +206,10 @@ func (monitorClient MonitorClient) requestTest(monitorServerIp, redisNodeIp stri
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
 
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
-			monitorServerResponse.ErrorMsg,
+			err.Error(),
 		)
 
 		outputChannel <- MonitorServerResponse{
@@ -196,12 +232,10 @@ func (monitorClient MonitorClient) requestTest(monitorServerIp, redisNodeIp stri
 
 func (monitorClient MonitorClient) requestNewConnect(monitorServerIp, redisNodeIp string, outputChannel chan<- MonitorServerResponse) {
 
-	requestURI := fmt.Sprintf("http://%s/monitor/connect/%s", monitorServerIp, redisNodeIp)
+	requestURI := fmt.Sprintf("%s%s/monitor/connect/%s", monitorClient.scheme, monitorServerIp, redisNodeIp)
 
 	tools.InfoLogger.Println(msg.RequestTargetMonitor, requestURI)
 
-	client := &http.Client{}
-
 	var requestBody bytes.Buffer
 
 	registerRequest, err := http.NewRequest("POST", requestURI, &requestBody)
@@ -225,7 +259,7 @@ func (monitorClient MonitorClient) requestNewConnect(monitorServerIp, redisNodeI
 	// 서버 요청 타임아웃 설정
 	go serverTimoutChecker(monitorServerIp, outputChannel)
 
-	response, err := client.Do(registerRequest)
+	response, err := monitorClient.httpClient.Do(registerRequest)
 	if err != nil {
 		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
@@ -249,10 +283,10 @@ func (monitorClient MonitorClient) requestNewConnect(monitorServerIp, redisNodeI
 	decoder := json.NewDecoder(response.Body)
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
-			monitorServerResponse.ErrorMsg,
+			err.Error(),
 		)
 
 		outputChannel <- MonitorServerResponse{
@@ -275,16 +309,15 @@ func serverTimoutChecker(monitorServerIp string, outputChannel chan<- MonitorSer
 
 func (monitorClient MonitorClient) requestUnregister(monitorServerIp, redisNodeIp string, outputChannel chan<- MonitorServerResponse) {
 
-	requestURI := fmt.Sprintf("http://%s/monitor/connect/%s", monitorServerIp, redisNodeIp)
+	requestURI := fmt.Sprintf("%s%s/monitor/connect/%s", monitorClient.scheme, monitorServerIp, redisNodeIp)
 
 	tools.InfoLogger.Println(msg.RequestTargetMonitor, requestURI)
 
-	client := &http.Client{}
-
 	req, err := http.NewRequest("DELETE", requestURI, nil)
 	if err != nil {
 		tools.ErrorLogger.Printf(
 			msg.CreateRequestError,
+			monitorServerIp,
 			err,
 		)
 
@@ -302,7 +335,7 @@ func (monitorClient MonitorClient) requestUnregister(monitorServerIp, redisNodeI
 	go serverTimoutChecker(monitorServerIp, outputChannel)
 
 	// 모니터 서버에 요청
-	response, err := client.Do(req)
+	response, err := monitorClient.httpClient.Do(req)
 	if err != nil {
 		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
@@ -326,10 +359,10 @@ func (monitorClient MonitorClient) requestUnregister(monitorServerIp, redisNodeI
 	decoder := json.NewDecoder(response.Body)
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
-			monitorServerResponse.ErrorMsg,
+			err.Error(),
 		)
 
 		outputChannel <- MonitorServerResponse{
@@ -342,3 +375,64 @@ func (monitorClient MonitorClient) requestUnregister(monitorServerIp, redisNodeI
 		ErrorMsg: monitorServerResponse.ErrorMsg,
 	}
 }
+
+// StartMonitorSubscription : 모니터 서버들에 노드 상태 변경 알림 구독 (@callbackURL 로 POST 받음)
+// 구독은 MonitorSubscriptionTTLMs 후 만료되므로 MonitorSubscribeIntervalMs 마다 갱신 (모니터 서버 재시작 시에도 다시 구독)
+// @subscriberToken 이 있으면 구독 요청에 "Authorization: Bearer <토큰>" 헤더 추가 (없으면 mutual TLS 인증서로 인증)
+//
+func StartMonitorSubscription(callbackURL string, subscriberToken string) {
+
+	monitorSubscribeToken = subscriberToken
+
+	go func() {
+
+		ticker := time.NewTicker(configs.MonitorSubscribeIntervalMs * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			for _, eachMonitorServer := range monitorClient.ServerAddressList {
+				go monitorClient.requestSubscribe(eachMonitorServer, callbackURL)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// requestSubscribe : @monitorServerIp 모니터 서버에 구독 요청, 실패하면 다음 갱신 때 다시 시도
+//
+func (monitorClient MonitorClient) requestSubscribe(monitorServerIp, callbackURL string) {
+
+	requestURI := fmt.Sprintf("%s%s/monitor/subscribers", monitorClient.scheme, monitorServerIp)
+
+	requestBody, err := json.Marshal(EventSubscription{CallbackURL: callbackURL})
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorSubscribeFail, monitorServerIp, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), configs.MonitorEventTimeoutMs*time.Millisecond)
+	defer cancel()
+
+	request, err := http.NewRequest(http.MethodPost, requestURI, bytes.NewReader(requestBody))
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.CreateRequestError, monitorServerIp, err)
+		return
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set(configs.ContentType, configs.JsonContent)
+	if monitorSubscribeToken != "" {
+		request.Header.Set(auth.HeaderName, auth.BearerPrefix+monitorSubscribeToken)
+	}
+
+	response, err := monitorClient.httpClient.Do(request)
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorSubscribeFail, monitorServerIp, err.Error())
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		tools.ErrorLogger.Printf(msg.MonitorSubscribeFail, monitorServerIp, response.Status)
+	}
+}
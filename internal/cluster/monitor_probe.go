@@ -0,0 +1,259 @@
+package cluster
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/internal/auth"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+)
+
+/* 모니터 서버의 노드 확인 (모니터 서버에서만 사용)
+ * 등록된 모든 노드를 인터페이스 서버의 Health Detector 와 같은 방식 (PING + Phi Accrual) 으로 확인하고
+ * 최근 결과를 기록한다 (GetHealthHistory). Failover 는 하지 않는다.
+ * 상태가 바뀌면 구독한 인터페이스 서버들의 Callback URL 로 NodeStateEvent 를 POST
+ *   - 인터페이스 서버는 MonitorSubscribeIntervalMs 마다 다시 구독, MonitorSubscriptionTTLMs 동안 갱신하지 않은 구독은 삭제
+ *   - 구독 요청은 MONITOR_SUBSCRIBE_TOKEN 또는 mutual TLS 인증서로 인증 (AuthorizeSubscriber)
+ *   - Callback URL 은 모니터 서버에 설정된 목록 (MONITOR_EVENT_CALLBACK_URLS) 중 하나여야 한다
+ *   - MONITOR_EVENT_TOKEN 설정 시 "Authorization: Bearer <토큰>" 헤더로 전송 (인터페이스 서버의 admin 토큰)
+ */
+
+// EventSubscription : 인터페이스 서버의 구독 요청 형식
+type EventSubscription struct {
+	CallbackURL string `json:"callback_url"`
+}
+
+// eventSubscribers : Callback URL -> 구독 만료 시각
+var eventSubscribers map[string]time.Time
+var eventSubscriberMutex *sync.Mutex
+
+var eventHTTPClient *http.Client
+
+// eventToken : 알림 요청의 API 토큰, 빈 문자열이면 헤더 없이 전송
+var eventToken string
+
+// allowedCallbackURLs : 구독할 수 있는 Callback URL (MONITOR_EVENT_CALLBACK_URLS), 토큰은 이 URL 로만 보낸다
+var allowedCallbackURLs map[string]bool
+
+// subscribeToken : 구독 요청 토큰, 빈 문자열이면 mutual TLS 인증서가 확인된 요청만 구독
+var subscribeToken string
+
+// ErrSubscriberUnauthorized : 구독 요청 인증 실패
+var ErrSubscriberUnauthorized = errors.New(msg.SubscriberUnauthorized)
+
+func init() {
+	if eventSubscribers == nil {
+		eventSubscribers = make(map[string]time.Time)
+	}
+	if eventSubscriberMutex == nil {
+		eventSubscriberMutex = &sync.Mutex{}
+	}
+	if allowedCallbackURLs == nil {
+		allowedCallbackURLs = make(map[string]bool)
+	}
+	if eventHTTPClient == nil {
+		eventHTTPClient = &http.Client{Timeout: configs.MonitorEventTimeoutMs * time.Millisecond}
+	}
+}
+
+// SetUpMonitorProbing : 알림 요청의 API 토큰, 구독할 수 있는 Callback URL 목록 (쉼표 구분), 구독 요청 토큰
+// TLS 설정 시 인터페이스 서버 인증서를 CA 로 검증
+//
+func SetUpMonitorProbing(token string, callbackURLs string, subscriberToken string, tlsFiles tools.TLSFiles) error {
+
+	eventToken = token
+	subscribeToken = subscriberToken
+
+	allowedCallbackURLs = make(map[string]bool)
+	for _, eachURL := range strings.Split(callbackURLs, ",") {
+
+		eachURL = strings.TrimSpace(eachURL)
+		if eachURL == "" {
+			continue
+		}
+
+		if err := validateCallbackURL(eachURL); err != nil {
+			return err
+		}
+		allowedCallbackURLs[eachURL] = true
+	}
+
+	if len(allowedCallbackURLs) == 0 || (subscribeToken == "" && !tlsFiles.IsEnabled()) {
+		tools.InfoLogger.Println(msg.EventSubscriptionOff)
+	}
+
+	if !tlsFiles.IsEnabled() {
+		return nil
+	}
+
+	tlsConfig, err := tlsFiles.ClientConfig()
+	if err != nil {
+		return err
+	}
+
+	eventHTTPClient = &http.Client{
+		Timeout: configs.MonitorEventTimeoutMs * time.Millisecond,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	return nil
+}
+
+// StartMonitorProbing : 등록된 노드 확인 고루틴 시작, 상태가 바뀌면 구독자에게 알림
+//
+func StartMonitorProbing() {
+
+	nodeStateListeners = append(nodeStateListeners, publishNodeStateEvent)
+
+	runHealthDetector(nil)
+}
+
+// AuthorizeSubscriber : 구독 / 구독 해제 요청 인증
+// 구독 요청 토큰이 설정되어 있으면 "Authorization: Bearer <토큰>" 으로, 없으면 mutual TLS 로 확인된 인증서 (@isClientVerified) 로 인증
+//
+func AuthorizeSubscriber(authorizationHeader string, isClientVerified bool) error {
+
+	if subscribeToken == "" {
+		if !isClientVerified {
+			return ErrSubscriberUnauthorized
+		}
+		return nil
+	}
+
+	if !strings.HasPrefix(authorizationHeader, auth.BearerPrefix) {
+		return ErrSubscriberUnauthorized
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authorizationHeader, auth.BearerPrefix))
+	if subtle.ConstantTimeCompare([]byte(token), []byte(subscribeToken)) != 1 {
+		return ErrSubscriberUnauthorized
+	}
+
+	return nil
+}
+
+// AddEventSubscriber : @callbackURL 구독 추가 또는 만료 시각 갱신, 설정된 Callback URL 만 구독할 수 있다
+//
+func AddEventSubscriber(callbackURL string) error {
+
+	if err := validateCallbackURL(callbackURL); err != nil {
+		return err
+	}
+
+	if !allowedCallbackURLs[callbackURL] {
+		return fmt.Errorf(msg.CallbackURLNotAllowed, callbackURL)
+	}
+
+	eventSubscriberMutex.Lock()
+	defer eventSubscriberMutex.Unlock()
+
+	if _, isSet := eventSubscribers[callbackURL]; !isSet {
+		tools.InfoLogger.Printf(msg.EventSubscriberAdded, callbackURL)
+	}
+
+	eventSubscribers[callbackURL] = time.Now().Add(configs.MonitorSubscriptionTTLMs * time.Millisecond)
+
+	return nil
+}
+
+// validateCallbackURL : http(s):// 로 시작하고 Host 가 있는 URL 인지 확인
+//
+func validateCallbackURL(callbackURL string) error {
+
+	parsedURL, err := url.Parse(callbackURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf(msg.InvalidCallbackURL, callbackURL)
+	}
+
+	return nil
+}
+
+// RemoveEventSubscriber : @callbackURL 구독 삭제
+//
+func RemoveEventSubscriber(callbackURL string) {
+
+	eventSubscriberMutex.Lock()
+	defer eventSubscriberMutex.Unlock()
+
+	delete(eventSubscribers, callbackURL)
+}
+
+// activeEventSubscribers : 만료되지 않은 구독, 만료된 구독은 삭제
+//
+func activeEventSubscribers(now time.Time) []string {
+
+	eventSubscriberMutex.Lock()
+	defer eventSubscriberMutex.Unlock()
+
+	callbackURLs := []string{}
+
+	for eachURL, expiresAt := range eventSubscribers {
+		if now.After(expiresAt) {
+			tools.InfoLogger.Printf(msg.EventSubscriberExpired, eachURL)
+			delete(eventSubscribers, eachURL)
+			continue
+		}
+		callbackURLs = append(callbackURLs, eachURL)
+	}
+
+	return callbackURLs
+}
+
+// publishNodeStateEvent : 구독자 모두에게 @event 전송 (응답을 기다리지 않는다)
+//
+func publishNodeStateEvent(event NodeStateEvent) {
+
+	event.Monitor = configs.CurrentIP + ":" + strconv.Itoa(configs.Port)
+
+	requestBody, err := json.Marshal(event)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		return
+	}
+
+	for _, eachURL := range activeEventSubscribers(time.Now()) {
+		go postNodeStateEvent(eachURL, requestBody)
+	}
+}
+
+// postNodeStateEvent : @callbackURL 로 알림 POST
+//
+func postNodeStateEvent(callbackURL string, requestBody []byte) {
+
+	request, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(requestBody))
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorEventPostFail, callbackURL, err.Error())
+		return
+	}
+
+	request.Header.Set(configs.ContentType, configs.JsonContent)
+
+	// 토큰은 모니터 서버에 설정된 Callback URL 로만 보낸다
+	if eventToken != "" && allowedCallbackURLs[callbackURL] {
+		request.Header.Set(auth.HeaderName, auth.BearerPrefix+eventToken)
+	}
+
+	response, err := eventHTTPClient.Do(request)
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorEventPostFail, callbackURL, err.Error())
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		tools.ErrorLogger.Printf(msg.MonitorEventPostFail, callbackURL, response.Status)
+	}
+}
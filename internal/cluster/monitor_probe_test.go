@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/internal/auth"
+	"hash_interface/tools"
+)
+
+func TestPublishNodeStateEvent(t *testing.T) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	receivedEvents := make(chan NodeStateEvent, 1)
+	receivedTokens := make(chan string, 1)
+
+	subscriber := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var event NodeStateEvent
+		json.NewDecoder(req.Body).Decode(&event)
+		receivedTokens <- req.Header.Get(auth.HeaderName)
+		receivedEvents <- event
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer subscriber.Close()
+
+	if err := SetUpMonitorProbing("monitor-token", " "+subscriber.URL+", ", "subscribe-token", tools.TLSFiles{}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		SetUpMonitorProbing("", "", "", tools.TLSFiles{})
+		RemoveEventSubscriber(subscriber.URL)
+	}()
+
+	if err := SetUpMonitorProbing("monitor-token", "172.29.0.2:8888/monitor/events", "", tools.TLSFiles{}); err == nil {
+		t.Fatal("expected error for a callback URL without scheme")
+	}
+	if err := SetUpMonitorProbing("monitor-token", subscriber.URL, "subscribe-token", tools.TLSFiles{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 구독 요청은 토큰으로 인증
+	if err := AuthorizeSubscriber("", false); err != ErrSubscriberUnauthorized {
+		t.Fatalf("expected unauthorized without token, got %v", err)
+	}
+	if err := AuthorizeSubscriber(auth.BearerPrefix+"monitor-token", true); err != ErrSubscriberUnauthorized {
+		t.Fatalf("expected unauthorized with a wrong token, got %v", err)
+	}
+	if err := AuthorizeSubscriber(auth.BearerPrefix+"subscribe-token", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 설정된 Callback URL 만 구독할 수 있다 (다른 호스트 / 경로로 토큰을 보내지 않는다)
+	if err := AddEventSubscriber("http://172.29.0.100:8888/monitor/events"); err == nil {
+		t.Fatal("expected error for a callback URL that is not configured")
+	}
+	if err := AddEventSubscriber(subscriber.URL + "/clients"); err == nil {
+		t.Fatal("expected error for a different path on a configured host")
+	}
+	if err := AddEventSubscriber(subscriber.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	publishNodeStateEvent(NodeStateEvent{Address: "10.0.5.1:8000", Previous: NodeSuspect, Current: NodeDown, Phi: 9.5, Time: time.Now()})
+
+	select {
+	case event := <-receivedEvents:
+		if event.Address != "10.0.5.1:8000" || event.Current != NodeDown || event.Monitor == "" {
+			t.Fatalf("unexpected event : %+v", event)
+		}
+		if token := <-receivedTokens; token != auth.BearerPrefix+"monitor-token" {
+			t.Fatalf("unexpected authorization header : %s", token)
+		}
+	case <-time.After(configs.MonitorEventTimeoutMs * time.Millisecond):
+		t.Fatal("event was not delivered")
+	}
+
+	// 갱신하지 않은 구독은 만료
+	if subscribers := activeEventSubscribers(time.Now().Add(configs.MonitorSubscriptionTTLMs*time.Millisecond + time.Second)); len(subscribers) != 0 {
+		t.Fatalf("expected expired subscription to be removed, got %v", subscribers)
+	}
+
+	// 구독 요청 토큰이 없으면 mutual TLS 로 확인된 인증서만 허용
+	if err := SetUpMonitorProbing("", subscriber.URL, "", tools.TLSFiles{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AuthorizeSubscriber(auth.BearerPrefix+"subscribe-token", false); err != ErrSubscriberUnauthorized {
+		t.Fatalf("expected unauthorized without client certificate, got %v", err)
+	}
+	if err := AuthorizeSubscriber("", true); err != nil {
+		t.Fatal(err)
+	}
+}
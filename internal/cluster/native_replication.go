@@ -0,0 +1,477 @@
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 레디스 기본 복제 (native) 모드
+ * 인터페이스 서버가 쓰기를 슬레이브에 전송하지 않고, 레디스 복제 (REPLICAOF) 에 맡긴다.
+ * 레디스에 직접 쓴 데이터도 슬레이브에 반영되고, 인터페이스 서버의 쓰기 트래픽이 절반으로 준다.
+ *   - 초기 설정 / AddNewSlave / 슬레이브 재시작 : 슬레이브에 REPLICAOF <마스터 host> <마스터 port>
+ *   - promoteToMaster : 승격할 슬레이브에 REPLICAOF NO ONE
+ *   - 쓰기는 순번만 할당 (Backlog / 전송 고루틴 없음), 슬레이브 데이터 로그는 쓰기 시점에 기록
+ *   - 슬레이브 별 감시 고루틴이 INFO replication 으로 링크 상태와 Offset 을 확인하고,
+ *     (쓰기 순번, 마스터 master_repl_offset) 체크포인트를 슬레이브 slave_repl_offset 과 비교해 슬레이브 반영 순번을 갱신
+ *     (세션 토큰, 복제 지연, ack=replica / all 은 stream 모드와 같이 동작)
+ */
+
+// ReplicationMode : 슬레이브 복제 방식
+type ReplicationMode string
+
+const (
+	// ReplicationModeStream : 인터페이스 서버가 복제 Backlog 의 쓰기를 슬레이브에 전송 (기본값)
+	ReplicationModeStream ReplicationMode = "stream"
+	// ReplicationModeNative : 레디스 REPLICAOF 복제
+	ReplicationModeNative ReplicationMode = "native"
+)
+
+// replicationCheckpointLimit : 감시 고루틴이 보관하는 최대 체크포인트 수, 초과 시 오래된 것부터 버린다
+// (버려도 다음 체크포인트에서 반영 순번이 갱신되므로 지연이 크게 보일 뿐 틀린 값이 되지는 않는다)
+const replicationCheckpointLimit = 1024
+
+var replicationMode = ReplicationModeStream
+
+// replicationCheckpoint : 마스터가 @offset 까지 기록했을 때 반영되어 있던 쓰기 순번
+type replicationCheckpoint struct {
+	state  replicationState
+	offset int64
+}
+
+// replicaMonitor : (마스터, 슬레이브) 쌍의 INFO replication 감시 고루틴 상태
+type replicaMonitor struct {
+	masterAddress string
+	slaveClient   *RedisClient
+
+	// masterConnection, slaveConnection : 감시 전용 연결 (조회 요청과 공유하지 않는다)
+	masterConnection redis.Conn
+	slaveConnection  redis.Conn
+
+	checkpoints []replicationCheckpoint
+
+	mutex            sync.Mutex
+	linkStatus       string
+	masterReplOffset int64
+	slaveReplOffset  int64
+	lastError        string
+}
+
+// replicaMonitors : 슬레이브 주소 -> 감시 고루틴
+var replicaMonitors map[string]*replicaMonitor
+var replicaMonitorMutex *sync.Mutex
+
+func init() {
+	if replicaMonitors == nil {
+		replicaMonitors = make(map[string]*replicaMonitor)
+	}
+	if replicaMonitorMutex == nil {
+		replicaMonitorMutex = &sync.Mutex{}
+	}
+}
+
+// SetUpReplicationMode : 빈 문자열이면 stream, 노드 연결 설정 (NodeConnectionSetup) 전에 호출
+//
+func SetUpReplicationMode(mode string) error {
+
+	switch ReplicationMode(mode) {
+	case "":
+		replicationMode = ReplicationModeStream
+	case ReplicationModeStream, ReplicationModeNative:
+		replicationMode = ReplicationMode(mode)
+	default:
+		return fmt.Errorf(msg.InvalidReplicationMode, mode)
+	}
+
+	return nil
+}
+
+// GetReplicationMode : 현재 복제 모드
+func GetReplicationMode() ReplicationMode {
+	return replicationMode
+}
+
+// syncReplica : @slaveClient 를 masterClient 인스턴스의 데이터와 맞춘다
+// stream 모드는 데이터 로그로 부분 재동기화 또는 전체 복사 (resyncReplica),
+// native 모드는 REPLICAOF (부분 재동기화는 레디스가 처리) 후 슬레이브 데이터 로그를 새로 만든다
+//
+func (masterClient *RedisClient) syncReplica(slaveClient *RedisClient) error {
+
+	if replicationMode != ReplicationModeNative {
+		return masterClient.resyncReplica(slaveClient)
+	}
+
+	if err := slaveClient.replicaOf(masterClient); err != nil {
+		return err
+	}
+
+	slaveClient.removeDataLogFile()
+	if err := createDataLogFile(slaveClient.Address); err != nil {
+		return err
+	}
+
+	if err := masterClient.copyDataLogTo(slaveClient); err != nil {
+		return err
+	}
+
+	ensureReplicaMonitor(masterClient.Address)
+
+	return nil
+}
+
+// replicaOf : redisClient 인스턴스를 @masterClient 의 레디스 복제본으로 설정 (REPLICAOF host port)
+// 마스터의 노드 접속 설정 (AUTH / TLS) 을 먼저 슬레이브 레디스에 설정한다 (setMasterAuth)
+//
+func (redisClient *RedisClient) replicaOf(masterClient *RedisClient) error {
+
+	host, port, err := net.SplitHostPort(masterClient.Address)
+	if err != nil {
+		return err
+	}
+
+	if err := redisClient.setMasterAuth(masterClient.Address); err != nil {
+		return err
+	}
+
+	tools.InfoLogger.Printf(msg.ReplicaOfMaster, redisClient.Address, masterClient.Address)
+
+	_, err = redis.String(redisClient.Do("REPLICAOF", host, port))
+
+	return err
+}
+
+// setMasterAuth : @masterAddress 의 노드 접속 설정으로 슬레이브 레디스의 복제 연결 설정 (CONFIG SET)
+// masteruser / masterauth : 마스터의 AUTH 정보 (없으면 이전 마스터의 값을 지운다)
+// tls-replication : 마스터가 TLS 를 사용하면 yes, 인증서 / CA 는 슬레이브 레디스 자신의 tls-* 설정을 사용한다
+// (노드 설정의 파일 경로는 인터페이스 서버 기준이라 레디스에 전달하지 않는다)
+//
+func (redisClient *RedisClient) setMasterAuth(masterAddress string) error {
+
+	masterAuth := getNodeAuth(masterAddress)
+
+	replicationConfigs := [][]interface{}{
+		{"masterauth", masterAuth.Password},
+	}
+	// masteruser 는 Redis 6 부터 지원, ACL 사용자가 없으면 요청하지 않는다
+	if masterAuth.Username != "" {
+		replicationConfigs = append(replicationConfigs, []interface{}{"masteruser", masterAuth.Username})
+	}
+	// TLS 미지원 빌드는 tls-* 설정이 없으므로 TLS 사용 시에만 요청
+	if masterAuth.TLS.Enabled {
+		replicationConfigs = append(replicationConfigs, []interface{}{"tls-replication", "yes"})
+	}
+
+	for _, eachConfig := range replicationConfigs {
+		if _, err := redisClient.Do("CONFIG", append([]interface{}{"SET"}, eachConfig...)...); err != nil {
+			return fmt.Errorf(msg.ReplicaConfigFail, eachConfig[0], err.Error())
+		}
+	}
+
+	return nil
+}
+
+// replicaOfNoOne : 레디스 복제를 끊고 쓰기 가능한 마스터로 전환 (REPLICAOF NO ONE), native 모드에서만 실행
+//
+func (redisClient *RedisClient) replicaOfNoOne() error {
+
+	if replicationMode != ReplicationModeNative {
+		return nil
+	}
+
+	tools.InfoLogger.Printf(msg.ReplicaOfNoOne, redisClient.Address)
+
+	_, err := redis.String(redisClient.Do("REPLICAOF", "NO", "ONE"))
+
+	return err
+}
+
+// copyDataLogTo : masterClient 의 최신 데이터를 슬레이브 데이터 로그에만 기록 (데이터는 레디스 복제로 전달)
+// 슬레이브가 마스터로 승격된 뒤 죽으면 이 로그로 데이터를 재분배한다
+//
+func (masterClient *RedisClient) copyDataLogTo(slaveClient *RedisClient) error {
+
+	masterDataContainer := make(HashToDataMap)
+	if err := masterClient.getLatestDataFromLog(masterDataContainer); err != nil {
+		return err
+	}
+
+	for _, keyValueMap := range masterDataContainer {
+		for eachKey, eachValue := range keyValueMap {
+			if err := slaveClient.RecordModificationLog("SET", eachKey, eachValue); err != nil {
+				tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordNativeWrite : native 모드의 쓰기 순번 할당, 슬레이브 데이터 로그 기록
+//
+func (masterClient *RedisClient) recordNativeWrite(command string, key string, value string) uint64 {
+
+	sequence := atomic.AddUint64(&writeSequence, 1)
+	setReplicationState(masterClient.Address, replicationState{
+		sequence:  sequence,
+		writtenAt: time.Now(),
+	})
+
+	for _, eachSlave := range getSlavesOf(masterClient.Address) {
+		eachSlave.RecordModificationLog(command, key, value)
+	}
+
+	ensureReplicaMonitor(masterClient.Address)
+
+	return sequence
+}
+
+// ensureReplicaMonitor : @masterAddress 의 슬레이브 중 감시 고루틴이 없는 슬레이브의 고루틴 시작
+//
+func ensureReplicaMonitor(masterAddress string) {
+
+	slaveClients := getSlavesOf(masterAddress)
+	if len(slaveClients) == 0 {
+		return
+	}
+
+	replicaMonitorMutex.Lock()
+	defer replicaMonitorMutex.Unlock()
+
+	for _, eachSlave := range slaveClients {
+
+		if monitor, isRunning := replicaMonitors[eachSlave.Address]; isRunning && monitor.masterAddress == masterAddress {
+			continue
+		}
+
+		monitor := &replicaMonitor{
+			masterAddress: masterAddress,
+			slaveClient:   eachSlave,
+		}
+		replicaMonitors[eachSlave.Address] = monitor
+
+		go monitor.run()
+	}
+}
+
+// isCurrent : 아직 (마스터, 슬레이브) 쌍인지
+//
+func (monitor *replicaMonitor) isCurrent() bool {
+	return isSlaveOf(monitor.slaveClient.Address, monitor.masterAddress)
+}
+
+func (monitor *replicaMonitor) run() {
+
+	slaveAddress := monitor.slaveClient.Address
+
+	tools.InfoLogger.Printf(msg.ReplicaMonitorStart, monitor.masterAddress, slaveAddress)
+
+	defer func() {
+		monitor.closeConnections()
+
+		replicaMonitorMutex.Lock()
+		if replicaMonitors[slaveAddress] == monitor {
+			delete(replicaMonitors, slaveAddress)
+		}
+		replicaMonitorMutex.Unlock()
+
+		tools.InfoLogger.Printf(msg.ReplicaMonitorStop, monitor.masterAddress, slaveAddress)
+	}()
+
+	for monitor.isCurrent() {
+
+		if err := monitor.poll(); err != nil {
+			monitor.fail(err)
+			time.Sleep(replicationRetryInterval)
+			continue
+		}
+
+		time.Sleep(configs.ReplicationInfoPollMs * time.Millisecond)
+	}
+}
+
+// poll : 체크포인트 추가 후 슬레이브 Offset 까지의 체크포인트로 슬레이브 반영 순번 갱신
+//
+func (monitor *replicaMonitor) poll() error {
+
+	if monitor.masterConnection == nil {
+		connection, err := DialRedis(monitor.masterAddress)
+		if err != nil {
+			return err
+		}
+		monitor.masterConnection = connection
+	}
+	if monitor.slaveConnection == nil {
+		connection, err := DialRedis(monitor.slaveClient.Address)
+		if err != nil {
+			return err
+		}
+		monitor.slaveConnection = connection
+	}
+
+	// 마스터 Offset 보다 먼저 읽어야 체크포인트의 순번이 모두 Offset 안에 포함된다
+	masterState := getReplicationState(monitor.masterAddress)
+
+	masterInfo, err := replicationInfo(monitor.masterConnection)
+	if err != nil {
+		return err
+	}
+	masterReplOffset, _ := strconv.ParseInt(masterInfo["master_repl_offset"], 10, 64)
+
+	monitor.addCheckpoint(masterState, masterReplOffset)
+
+	slaveInfo, err := replicationInfo(monitor.slaveConnection)
+	if err != nil {
+		return err
+	}
+	linkStatus := slaveInfo["master_link_status"]
+	slaveReplOffset, _ := strconv.ParseInt(slaveInfo["slave_repl_offset"], 10, 64)
+
+	if linkStatus == "up" {
+		if state, isAdvanced := monitor.advance(slaveReplOffset); isAdvanced {
+			setReplicationState(monitor.slaveClient.Address, state)
+		}
+	}
+
+	monitor.mutex.Lock()
+	monitor.linkStatus = linkStatus
+	monitor.masterReplOffset = masterReplOffset
+	monitor.slaveReplOffset = slaveReplOffset
+	monitor.lastError = ""
+	monitor.mutex.Unlock()
+
+	return nil
+}
+
+// addCheckpoint : 새 쓰기가 있었으면 체크포인트 추가
+//
+func (monitor *replicaMonitor) addCheckpoint(state replicationState, offset int64) {
+
+	if count := len(monitor.checkpoints); count > 0 && monitor.checkpoints[count-1].state.sequence >= state.sequence {
+		return
+	}
+
+	monitor.checkpoints = append(monitor.checkpoints, replicationCheckpoint{
+		state:  state,
+		offset: offset,
+	})
+
+	if len(monitor.checkpoints) > replicationCheckpointLimit {
+		monitor.checkpoints = append(
+			[]replicationCheckpoint{},
+			monitor.checkpoints[len(monitor.checkpoints)-replicationCheckpointLimit:]...,
+		)
+	}
+}
+
+// advance : 슬레이브가 @slaveReplOffset 까지 반영했을 때 포함되는 마지막 체크포인트 순번, 사용한 체크포인트는 제거
+//
+func (monitor *replicaMonitor) advance(slaveReplOffset int64) (replicationState, bool) {
+
+	reachedCount := 0
+	for reachedCount < len(monitor.checkpoints) && monitor.checkpoints[reachedCount].offset <= slaveReplOffset {
+		reachedCount++
+	}
+
+	if reachedCount == 0 {
+		return replicationState{}, false
+	}
+
+	state := monitor.checkpoints[reachedCount-1].state
+	monitor.checkpoints = monitor.checkpoints[reachedCount:]
+
+	return state, true
+}
+
+// fail : 감시 에러 기록, 연결은 닫고 다음 시도에서 다시 연결
+//
+func (monitor *replicaMonitor) fail(err error) {
+
+	tools.ErrorLogger.Printf(msg.ReplicaInfoFail, monitor.slaveClient.Address, err.Error())
+
+	monitor.closeConnections()
+
+	monitor.mutex.Lock()
+	monitor.linkStatus = ""
+	monitor.lastError = err.Error()
+	monitor.mutex.Unlock()
+}
+
+func (monitor *replicaMonitor) closeConnections() {
+
+	if monitor.masterConnection != nil {
+		monitor.masterConnection.Close()
+		monitor.masterConnection = nil
+	}
+	if monitor.slaveConnection != nil {
+		monitor.slaveConnection.Close()
+		monitor.slaveConnection = nil
+	}
+}
+
+// fillNative : 감시 고루틴이 확인한 레디스 링크 상태 / Offset 추가, 링크가 연결되어 있으면 IsStreaming
+//
+func (status *ReplicaStatus) fillNative(masterAddress string) {
+
+	replicaMonitorMutex.Lock()
+	monitor, isRunning := replicaMonitors[status.SlaveAddress]
+	replicaMonitorMutex.Unlock()
+
+	if !isRunning || monitor.masterAddress != masterAddress {
+		return
+	}
+
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	status.LinkStatus = monitor.linkStatus
+	status.MasterReplOffset = monitor.masterReplOffset
+	status.SlaveReplOffset = monitor.slaveReplOffset
+	status.IsStreaming = monitor.linkStatus == "up"
+	status.LastError = monitor.lastError
+}
+
+// replicationInfo : INFO replication 결과를 필드 이름 -> 값 으로 반환
+//
+func replicationInfo(connection redis.Conn) (map[string]string, error) {
+
+	info, err := redis.String(connection.Do("INFO", "replication"))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInfoFields(info), nil
+}
+
+// parseInfoFields : "field:value" 줄 목록 파싱, 섹션 제목 (#) 과 빈 줄은 무시
+//
+func parseInfoFields(info string) map[string]string {
+
+	fields := make(map[string]string)
+
+	for _, eachLine := range strings.Split(info, "\n") {
+
+		eachLine = strings.TrimSpace(eachLine)
+		if eachLine == "" || strings.HasPrefix(eachLine, "#") {
+			continue
+		}
+
+		separatorIndex := strings.Index(eachLine, ":")
+		if separatorIndex <= 0 {
+			continue
+		}
+
+		fields[eachLine[:separatorIndex]] = eachLine[separatorIndex+1:]
+	}
+
+	return fields
+}
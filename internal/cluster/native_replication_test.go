@@ -0,0 +1,110 @@
+package cluster
+
+import (
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+
+	"hash_interface/internal/redistest"
+	"hash_interface/tools"
+)
+
+func TestParseInfoFields(t *testing.T) {
+
+	info := "# Replication\r\nrole:slave\r\nmaster_host:172.29.0.4\r\nmaster_link_status:up\r\nslave_repl_offset:1520\r\n\r\n"
+
+	fields := parseInfoFields(info)
+
+	if fields["master_link_status"] != "up" || fields["slave_repl_offset"] != "1520" || fields["master_host"] != "172.29.0.4" {
+		t.Fatalf("unexpected fields : %v", fields)
+	}
+	if _, isExist := fields["# Replication"]; isExist {
+		t.Fatal("section header must be skipped")
+	}
+}
+
+func TestReplicaMonitorCheckpoints(t *testing.T) {
+
+	monitor := &replicaMonitor{}
+
+	monitor.addCheckpoint(replicationState{sequence: 3}, 100)
+	// 새 쓰기가 없으면 추가하지 않는다
+	monitor.addCheckpoint(replicationState{sequence: 3}, 120)
+	monitor.addCheckpoint(replicationState{sequence: 7}, 200)
+
+	if len(monitor.checkpoints) != 2 {
+		t.Fatalf("unexpected checkpoints : %v", monitor.checkpoints)
+	}
+
+	if _, isAdvanced := monitor.advance(99); isAdvanced {
+		t.Fatal("slave has not reached any checkpoint")
+	}
+
+	state, isAdvanced := monitor.advance(150)
+	if !isAdvanced || state.sequence != 3 {
+		t.Fatalf("expected sequence 3, got %d (%v)", state.sequence, isAdvanced)
+	}
+
+	state, isAdvanced = monitor.advance(250)
+	if !isAdvanced || state.sequence != 7 || len(monitor.checkpoints) != 0 {
+		t.Fatalf("expected sequence 7, got %d (%v)", state.sequence, isAdvanced)
+	}
+
+	for i := 1; i <= replicationCheckpointLimit+10; i++ {
+		monitor.addCheckpoint(replicationState{sequence: uint64(10 + i)}, int64(300+i))
+	}
+	if len(monitor.checkpoints) != replicationCheckpointLimit {
+		t.Fatalf("checkpoints must be bounded : %d", len(monitor.checkpoints))
+	}
+}
+
+func TestReplicaOfSetsMasterAuth(t *testing.T) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	slaveServer := redistest.NewServer()
+	defer slaveServer.Close()
+
+	slavePool, err := NewNodePool(slaveServer.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slavePool.Close()
+
+	slaveClient := &RedisClient{Address: slaveServer.Address, Role: SlaveRole, Pool: slavePool}
+
+	nodeConfigMutex.Lock()
+	previousConfigs := nodeConfigs
+	nodeConfigs = NodeConfigFile{
+		Nodes: map[string]NodeAuth{
+			"10.0.7.1:8000": {Username: "replica", Password: "secret", TLS: NodeTLS{Enabled: true}},
+		},
+	}
+	nodeConfigMutex.Unlock()
+
+	defer func() {
+		nodeConfigMutex.Lock()
+		nodeConfigs = previousConfigs
+		nodeConfigMutex.Unlock()
+	}()
+
+	if err := slaveClient.replicaOf(&RedisClient{Address: "10.0.7.1:8000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// REPLICAOF 전에 마스터의 AUTH / TLS 설정이 되어 있어야 한다
+	expected := []string{"10.0.7.1:8000", "masteruser=replica", "masterauth=secret", "tls-replication=yes"}
+	if replicaOf := slaveServer.ReplicaOf(); !reflect.DeepEqual(replicaOf, expected) {
+		t.Fatalf("expected %v, got %v", expected, replicaOf)
+	}
+
+	// AUTH 가 없는 마스터로 옮기면 이전 마스터의 비밀번호는 지운다
+	if err := slaveClient.replicaOf(&RedisClient{Address: "10.0.7.2:8000"}); err != nil {
+		t.Fatal(err)
+	}
+	if replicaOf := slaveServer.ReplicaOf(); replicaOf[0] != "10.0.7.2:8000" || replicaOf[2] != "masterauth=" {
+		t.Fatalf("unexpected replica config %v", replicaOf)
+	}
+}
@@ -0,0 +1,214 @@
+package cluster
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 레디스 노드 접속 설정 (AUTH / ACL / TLS / 타임아웃)
+ * 설정 파일 형식 (JSON) :
+ * {
+ *   "default": { "username": "app", "password": "...", "tls": { "enabled": true, "ca_file": "..." }, "timeouts": { "read_ms": 500 } },
+ *   "nodes": {
+ *     "172.29.0.4:8000": { "username": "app", "password": "...", "tls": { ... } }
+ *   }
+ * }
+ * "nodes" 에 주소가 있으면 해당 설정을, 없으면 "default" 를 사용한다.
+ * 타임아웃은 설정하지 않은 (0) 항목만 configs 기본값을 사용한다.
+ */
+
+// NodeAuth : 레디스 노드 한 대의 접속 설정
+type NodeAuth struct {
+	// Username : 설정 시 "AUTH <username> <password>" (Redis 6 ACL)
+	Username string `json:"username"`
+	// Password : Username 없이 설정 시 "AUTH <password>"
+	Password string       `json:"password"`
+	TLS      NodeTLS      `json:"tls"`
+	Timeouts NodeTimeouts `json:"timeouts"`
+}
+
+type NodeTLS struct {
+	Enabled bool `json:"enabled"`
+	// CAFile : 레디스 서버 인증서 검증용 CA, 미설정 시 시스템 CA
+	CAFile string `json:"ca_file"`
+	// CertFile, KeyFile : 레디스가 클라이언트 인증서를 요구하는 경우 (tls-auth-clients)
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// ServerName : 미설정 시 노드 주소의 Host
+	ServerName string `json:"server_name"`
+}
+
+// NodeTimeouts : 노드 별 타임아웃 (ms), 0 이면 configs 기본값
+type NodeTimeouts struct {
+	ConnectMs int `json:"connect_ms"`
+	// ReadMs, WriteMs : 연결 단위 읽기 / 쓰기 타임아웃 (복제 전송 등 전용 연결 포함)
+	ReadMs  int `json:"read_ms"`
+	WriteMs int `json:"write_ms"`
+	// CommandMs : 요청에 Deadline 이 없을 때 명령 하나 (연결 대기 포함) 에 허용하는 시간
+	CommandMs int `json:"command_ms"`
+}
+
+type NodeConfigFile struct {
+	Default NodeAuth            `json:"default"`
+	Nodes   map[string]NodeAuth `json:"nodes"`
+}
+
+var nodeConfigs NodeConfigFile
+
+// nodeTLSConfigs : 노드 주소 -> 로드된 tls.Config (재연결 시 파일을 다시 읽지 않도록 캐시)
+var nodeTLSConfigs map[string]*tls.Config
+var nodeConfigMutex *sync.RWMutex
+
+func init() {
+	if nodeTLSConfigs == nil {
+		nodeTLSConfigs = make(map[string]*tls.Config)
+	}
+	if nodeConfigMutex == nil {
+		nodeConfigMutex = &sync.RWMutex{}
+	}
+}
+
+// LoadNodeConfigFile : 레디스 노드 접속 설정 파일 로드
+// 이후 생성되는 모든 연결 (최초 연결, 재연결, 모니터 서버 등록) 에 적용된다.
+//
+func LoadNodeConfigFile(filePath string) error {
+
+	encodedConfig, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var newNodeConfigs NodeConfigFile
+	if err := json.Unmarshal(encodedConfig, &newNodeConfigs); err != nil {
+		return fmt.Errorf(msg.NodeConfigParseError, filePath, err.Error())
+	}
+
+	nodeConfigMutex.Lock()
+	defer nodeConfigMutex.Unlock()
+
+	nodeConfigs = newNodeConfigs
+	nodeTLSConfigs = make(map[string]*tls.Config)
+
+	return nil
+}
+
+// getNodeAuth : @address 노드의 접속 설정
+func getNodeAuth(address string) NodeAuth {
+
+	nodeConfigMutex.RLock()
+	defer nodeConfigMutex.RUnlock()
+
+	if nodeAuth, isSet := nodeConfigs.Nodes[address]; isSet {
+		return nodeAuth
+	}
+
+	return nodeConfigs.Default
+}
+
+// getNodeTimeouts : @address 노드의 타임아웃, 설정하지 않은 항목은 기본값
+//
+func getNodeTimeouts(address string) NodeTimeouts {
+
+	timeouts := getNodeAuth(address).Timeouts
+
+	if timeouts.ConnectMs <= 0 {
+		timeouts.ConnectMs = int(ConnTimeoutDuration / time.Millisecond)
+	}
+	if timeouts.ReadMs <= 0 {
+		timeouts.ReadMs = configs.RedisReadTimeoutMs
+	}
+	if timeouts.WriteMs <= 0 {
+		timeouts.WriteMs = configs.RedisWriteTimeoutMs
+	}
+	if timeouts.CommandMs <= 0 {
+		timeouts.CommandMs = configs.RedisCommandTimeoutMs
+	}
+
+	return timeouts
+}
+
+// DialRedis : 노드 설정 (TLS, AUTH, 타임아웃) 을 적용하여 @address 레디스에 연결
+//
+func DialRedis(address string) (redis.Conn, error) {
+
+	nodeAuth := getNodeAuth(address)
+	timeouts := getNodeTimeouts(address)
+
+	dialOptions := []redis.DialOption{
+		redis.DialConnectTimeout(time.Duration(timeouts.ConnectMs) * time.Millisecond),
+		redis.DialReadTimeout(time.Duration(timeouts.ReadMs) * time.Millisecond),
+		redis.DialWriteTimeout(time.Duration(timeouts.WriteMs) * time.Millisecond),
+	}
+
+	if nodeAuth.TLS.Enabled {
+		tlsConfig, err := getNodeTLSConfig(address, nodeAuth.TLS)
+		if err != nil {
+			tools.ErrorLogger.Printf(msg.NodeTLSConfigError, address, err.Error())
+			return nil, err
+		}
+
+		dialOptions = append(
+			dialOptions,
+			redis.DialUseTLS(true),
+			redis.DialTLSConfig(tlsConfig),
+		)
+	}
+
+	// redigo 는 AUTH <username> <password> 옵션이 없으므로 직접 요청
+	if nodeAuth.Username == "" && nodeAuth.Password != "" {
+		dialOptions = append(dialOptions, redis.DialPassword(nodeAuth.Password))
+	}
+
+	connection, err := redis.Dial("tcp", address, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeAuth.Username != "" {
+		if _, err := connection.Do("AUTH", nodeAuth.Username, nodeAuth.Password); err != nil {
+			connection.Close()
+			tools.ErrorLogger.Printf(msg.NodeAuthFail, address, nodeAuth.Username, err.Error())
+			return nil, err
+		}
+	}
+
+	return connection, nil
+}
+
+func getNodeTLSConfig(address string, nodeTLS NodeTLS) (*tls.Config, error) {
+
+	nodeConfigMutex.RLock()
+	tlsConfig, isCached := nodeTLSConfigs[address]
+	nodeConfigMutex.RUnlock()
+
+	if isCached {
+		return tlsConfig, nil
+	}
+
+	tlsConfig, err := tools.TLSFiles{
+		CertFile: nodeTLS.CertFile,
+		KeyFile:  nodeTLS.KeyFile,
+		CAFile:   nodeTLS.CAFile,
+	}.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig.ServerName = nodeTLS.ServerName
+
+	nodeConfigMutex.Lock()
+	nodeTLSConfigs[address] = tlsConfig
+	nodeConfigMutex.Unlock()
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,185 @@
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+)
+
+/* Phi Accrual 장애 감지 (Hayashibara et al., Cassandra / Akka 와 유사)
+ * Health Detector 의 PING 성공을 heartbeat 로 보고, heartbeat 간격의 분포 (평균, 표준편차) 로
+ * "마지막 heartbeat 이후 지금까지 응답이 없을 확률" 을 phi = -log10(P) 로 계산한다.
+ *   - phi 는 응답이 없는 시간에 따라 연속적으로 커지므로 PING 한 번 실패 (GC, 네트워크 순간 끊김) 로는 down 이 되지 않는다
+ *   - PhiAcceptablePauseMs 만큼의 멈춤은 평균 간격에 더해 허용
+ *   - 간격이 일정해 표준편차가 너무 작으면 작은 지연에도 phi 가 급격히 커지므로 PhiMinStdDevMs 이상으로 본다
+ */
+
+// phiSuspectThreshold, phiDownThreshold : phi 가 이 값 이상이면 suspect / down
+var phiSuspectThreshold float64
+var phiDownThreshold float64
+
+// failoverMinDown : down 상태가 이 시간 이상 계속되어야 Failover 시작
+var failoverMinDown time.Duration
+
+// failoverCooldown : Failover 로 승격된 마스터는 이 시간 동안 다시 Failover 하지 않는다 (마스터 / 슬레이브 반복 전환 방지)
+var failoverCooldown time.Duration
+
+func init() {
+	phiSuspectThreshold = configs.DefaultPhiSuspectThreshold
+	phiDownThreshold = configs.DefaultPhiDownThreshold
+	failoverMinDown = configs.DefaultFailoverMinDownMs * time.Millisecond
+	failoverCooldown = configs.DefaultFailoverCooldownMs * time.Millisecond
+}
+
+// SetUpFailureDetector : 빈 문자열이면 기본값, @suspectThreshold <= @downThreshold
+//
+func SetUpFailureDetector(suspectThreshold string, downThreshold string, minDownMs string, cooldownMs string) error {
+
+	suspect := configs.DefaultPhiSuspectThreshold
+	if suspectThreshold != "" {
+		parsed, err := strconv.ParseFloat(suspectThreshold, 64)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf(msg.InvalidPhiThreshold, configs.PhiSuspectThresholdEnv, suspectThreshold)
+		}
+		suspect = parsed
+	}
+
+	down := configs.DefaultPhiDownThreshold
+	if downThreshold != "" {
+		parsed, err := strconv.ParseFloat(downThreshold, 64)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf(msg.InvalidPhiThreshold, configs.PhiDownThresholdEnv, downThreshold)
+		}
+		down = parsed
+	}
+
+	if suspect > down {
+		return fmt.Errorf(msg.PhiThresholdOrder, suspect, down)
+	}
+
+	minDown, err := parseDurationMs(configs.FailoverMinDownMsEnv, minDownMs, configs.DefaultFailoverMinDownMs)
+	if err != nil {
+		return err
+	}
+
+	cooldown, err := parseDurationMs(configs.FailoverCooldownMsEnv, cooldownMs, configs.DefaultFailoverCooldownMs)
+	if err != nil {
+		return err
+	}
+
+	phiSuspectThreshold = suspect
+	phiDownThreshold = down
+	failoverMinDown = minDown
+	failoverCooldown = cooldown
+
+	return nil
+}
+
+// parseDurationMs : @value (ms, 0 이상 정수), 빈 문자열이면 @defaultMs
+//
+func parseDurationMs(envName string, value string, defaultMs int) (time.Duration, error) {
+
+	if value == "" {
+		return time.Duration(defaultMs) * time.Millisecond, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf(msg.InvalidDetectorDuration, envName, value)
+	}
+
+	return time.Duration(parsed) * time.Millisecond, nil
+}
+
+// heartbeatHistory : 최근 PhiWindowSize 개의 heartbeat 간격 (ms)
+type heartbeatHistory struct {
+	intervals     []float64
+	nextIndex     int
+	lastHeartbeat time.Time
+}
+
+// newHeartbeatHistory : @now 에 heartbeat 를 받은 것으로 시작
+// 간격 기록이 없을 때는 HealthCheckIntervalMs 간격으로 받았다고 가정 (표준편차는 간격의 1/4)
+//
+func newHeartbeatHistory(now time.Time) *heartbeatHistory {
+
+	expectedInterval := float64(configs.HealthCheckIntervalMs)
+
+	return &heartbeatHistory{
+		intervals:     []float64{expectedInterval - expectedInterval/4, expectedInterval + expectedInterval/4},
+		lastHeartbeat: now,
+	}
+}
+
+// heartbeat : @now 의 heartbeat 기록, @isConsecutive 가 false 면 (이전 PING 실패) 간격은 기록하지 않는다
+// 장애 동안의 긴 간격이 평균을 늘려 다음 장애 감지가 늦어지지 않도록
+//
+func (history *heartbeatHistory) heartbeat(now time.Time, isConsecutive bool) {
+
+	if isConsecutive {
+
+		interval := float64(now.Sub(history.lastHeartbeat)) / float64(time.Millisecond)
+
+		if len(history.intervals) < configs.PhiWindowSize {
+			history.intervals = append(history.intervals, interval)
+		} else {
+			history.intervals[history.nextIndex] = interval
+			history.nextIndex = (history.nextIndex + 1) % configs.PhiWindowSize
+		}
+	}
+
+	history.lastHeartbeat = now
+}
+
+// phi : @now 까지 heartbeat 가 없을 때의 phi
+//
+func (history heartbeatHistory) phi(now time.Time) float64 {
+
+	elapsed := float64(now.Sub(history.lastHeartbeat)) / float64(time.Millisecond)
+
+	mean, stdDev := meanAndStdDev(history.intervals)
+	mean += configs.PhiAcceptablePauseMs
+	stdDev = math.Max(stdDev, configs.PhiMinStdDevMs)
+
+	return phiOf(elapsed, mean, stdDev)
+}
+
+// phiOf : 정규분포 N(@mean, @stdDev) 에서 간격이 @elapsed 보다 클 확률의 -log10
+// 누적분포는 로지스틱 근사 사용 (Akka PhiAccrualFailureDetector 와 같은 식)
+//
+func phiOf(elapsed float64, mean float64, stdDev float64) float64 {
+
+	y := (elapsed - mean) / stdDev
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+
+	// 확률이 float64 범위보다 작아지면 +Inf
+	if elapsed > mean {
+		return -math.Log10(e / (1.0 + e))
+	}
+
+	return -math.Log10(1.0 - 1.0/(1.0+e))
+}
+
+func meanAndStdDev(values []float64) (float64, float64) {
+
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, eachValue := range values {
+		sum += eachValue
+	}
+	mean := sum / float64(len(values))
+
+	squaredSum := 0.0
+	for _, eachValue := range values {
+		squaredSum += (eachValue - mean) * (eachValue - mean)
+	}
+
+	return mean, math.Sqrt(squaredSum / float64(len(values)))
+}
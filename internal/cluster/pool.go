@@ -0,0 +1,157 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 레디스 노드 별 Connection Pool
+ * 노드마다 redis.Pool 을 두고 명령마다 연결을 빌려 쓴 뒤 반납한다 (같은 마스터에 대한 요청도 병렬로 처리)
+ *   - MaxIdle / MaxActive : REDIS_POOL_MAX_IDLE / REDIS_POOL_MAX_ACTIVE, MaxActive 에 도달하면 반납을 기다린다
+ *   - 빌릴 때 PoolTestOnBorrowMs 이상 쉬었던 연결은 PING 으로 확인 (TestOnBorrow), 실패한 연결은 버리고 새로 연결
+ *   - 에러가 난 연결은 반납 시 버려지고, 다음에 빌릴 때 DialRedis 로 다시 연결 (노드 설정 AUTH / TLS 동일 적용)
+ */
+
+var poolMaxIdle int
+var poolMaxActive int
+
+func init() {
+	poolMaxIdle = configs.DefaultPoolMaxIdle
+	poolMaxActive = configs.DefaultPoolMaxActive
+}
+
+// SetUpConnectionPool : 빈 문자열이면 기본값, 노드 연결 설정 (NodeConnectionSetup) 전에 호출
+//
+func SetUpConnectionPool(maxIdle string, maxActive string) error {
+
+	if maxIdle != "" {
+		parsed, err := strconv.Atoi(maxIdle)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf(msg.InvalidPoolSize, configs.RedisPoolMaxIdleEnv, maxIdle)
+		}
+		poolMaxIdle = parsed
+	}
+
+	if maxActive != "" {
+		parsed, err := strconv.Atoi(maxActive)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf(msg.InvalidPoolSize, configs.RedisPoolMaxActiveEnv, maxActive)
+		}
+		poolMaxActive = parsed
+	}
+
+	return nil
+}
+
+// NewNodePool : @address 노드의 Connection Pool 생성 후 연결 확인 (PING), 연결할 수 없으면 에러
+//
+func NewNodePool(address string) (*redis.Pool, error) {
+
+	pool := &redis.Pool{
+		MaxIdle:     poolMaxIdle,
+		MaxActive:   poolMaxActive,
+		IdleTimeout: configs.PoolIdleTimeoutSec * time.Second,
+		Wait:        true,
+		Dial: func() (redis.Conn, error) {
+			return DialRedis(address)
+		},
+		TestOnBorrow: func(connection redis.Conn, lastUsed time.Time) error {
+			if time.Since(lastUsed) < configs.PoolTestOnBorrowMs*time.Millisecond {
+				return nil
+			}
+			_, err := connection.Do("PING")
+			return err
+		},
+	}
+
+	connection := pool.Get()
+	defer connection.Close()
+
+	if _, err := connection.Do("PING"); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// Do : DoContext (요청 Deadline 없음, 노드의 명령 타임아웃 적용)
+//
+func (redisClient *RedisClient) Do(command string, args ...interface{}) (interface{}, error) {
+	return redisClient.DoContext(context.Background(), command, args...)
+}
+
+// DoContext : 노드 Pool 에서 연결을 빌려 @ctx 의 Deadline 안에 명령 실행 후 반납
+// Circuit Breaker 가 열려 있으면 명령을 보내지 않고 ErrCircuitOpen
+//
+func (redisClient *RedisClient) DoContext(ctx context.Context, command string, args ...interface{}) (interface{}, error) {
+
+	connection, err := redisClient.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	return connection.Do(command, args...)
+}
+
+// getConnection : 연결 하나로 여러 명령을 실행할 때 (Script, SCAN 등) 사용, 사용 후 Close 로 반납
+// 연결의 명령은 @ctx (Deadline 이 없으면 노드의 명령 타임아웃) 안에서 실행되고 Circuit Breaker 에 기록된다
+//
+func (redisClient *RedisClient) getConnection(ctx context.Context) (redis.Conn, error) {
+
+	if redisClient.Pool == nil {
+		return nil, fmt.Errorf(msg.NodePoolNotSetUp, redisClient.Address)
+	}
+
+	breaker := breakerOf(redisClient.Address)
+	if err := breaker.allow(); err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, redisClient.Address)
+	}
+
+	commandCtx, cancel := withCommandDeadline(ctx, redisClient.Address)
+
+	connection, err := redisClient.Pool.GetContext(commandCtx)
+	if err != nil {
+		cancel()
+		breaker.record(redisClient.Address, err)
+		return nil, err
+	}
+
+	return contextConn{
+		Conn:    connection,
+		ctx:     commandCtx,
+		cancel:  cancel,
+		address: redisClient.Address,
+		breaker: breaker,
+	}, nil
+}
+
+// Ping : Circuit Breaker 를 거치지 않는 생존 확인, 노드의 연결 타임아웃 적용
+//
+func (redisClient *RedisClient) Ping() (string, error) {
+
+	if redisClient.Pool == nil {
+		return "", fmt.Errorf(msg.NodePoolNotSetUp, redisClient.Address)
+	}
+
+	timeout := time.Duration(getNodeTimeouts(redisClient.Address).ConnectMs) * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	connection, err := redisClient.Pool.GetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer connection.Close()
+
+	return redis.String(redis.DoWithTimeout(connection, timeout, "PING"))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	msg "hash_interface/internal/cluster/message"
 	"hash_interface/tools"
+	"sort"
 	"strings"
 	"sync"
 
@@ -16,27 +17,37 @@ const (
 )
 
 type RedisClient struct {
-	Connection redis.Conn `json:"-"`
-	Address    string     `json:"address"`
-	Role       string     `json:"role"`
+	// Pool : 노드 별 Connection Pool (pool.go), 명령은 Do 로 실행
+	Pool    *redis.Pool `json:"-"`
+	Address string      `json:"address"`
+	Role    string      `json:"role"`
+	// CircuitBreaker : 조회 시점의 Circuit Breaker 상태 (GetMasterClients / GetSlaveClients 결과에만 채운다)
+	CircuitBreaker CircuitState `json:"circuit_breaker,omitempty"`
+	// Health : 조회 시점의 Health Detector 상태 (GetMasterClients / GetSlaveClients 결과에만 채운다)
+	Health NodeState `json:"health,omitempty"`
 }
 
 var redisMasterClients []*RedisClient
 var redisSlaveClients []*RedisClient
 
-// masterSlaveMap : 마스터 주소 -> 슬레이브 노드
-var masterSlaveMap map[string]*RedisClient
+// masterSlaveMap : 마스터 주소 -> 슬레이브 노드 목록
+var masterSlaveMap map[string][]*RedisClient
 
 // slaveMasterMap : 슬레이브 주소 -> 마스터 노드
 var slaveMasterMap map[string]*RedisClient
 
-// redisMutexMap : IP주소 -> 뮤텍스, 각 마스터-슬레이브 그룹 별 요청 동기화용
-// used for sync of request to each Redis <Master-Slave> Set With key of "Address"
+// redisMutexMap : IP주소 -> 뮤텍스, 각 마스터-슬레이브 그룹 별 Failover (handleIfDead, startFailover) 동기화용
+// 명령은 Connection Pool 의 연결로 실행하므로 데이터 조회 / 변경은 뮤텍스를 잡지 않는다, mutexOf 로 조회
 var redisMutexMap map[string]*sync.Mutex
 
 // addClientMutex : 레디스 클라이언트 추가 동기화용
 var addClientMutex *sync.Mutex
 
+// topologyMutex : 마스터 / 슬레이브 목록, masterSlaveMap, slaveMasterMap, redisMutexMap, RedisClient.Role 동기화
+// Failover / 슬레이브 이동 / 노드 추가는 Lock 을 잡고 변경하고, 요청 / 복제 / Health Detector 는 RLock 으로 조회한다 (Topology Accessor)
+// 네트워크 요청 중에는 잡지 않는다
+var topologyMutex *sync.RWMutex
+
 /****************************************
  *
  *
@@ -48,25 +59,25 @@ var addClientMutex *sync.Mutex
 // handleIfDead : 인스턴스의 생존여부를 확인하고, failover 발생 시 처리
 //  1. masterClient 인스턴스가 살아있는지 확인
 //  2. 죽었을 경우
-//   1) 매핑된 Slave를 새로운 마스터로 승격
-//   2) 죽은 masterClient는 재시작
+//  1. 매핑된 Slave를 새로운 마스터로 승격
+//  2. 죽은 masterClient는 재시작
 //  3. 새로운 마스터 승격이 실패할 경우 (Slave 죽은 것으로 판단) 남은 Master Client들에게 해쉬슬롯 재분배
 //
 func (masterClient *RedisClient) handleIfDead() error {
 
-	if masterClient.Role != MasterRole {
+	if masterClient.currentRole() != MasterRole {
 		return fmt.Errorf(msg.NotAllowedIfNotMaster)
 	}
 
 	// 모니터 서버에게 생존 확인/투표 요청
 	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*masterClient, IsAlive)
+	votes, err := monitorClient.ask(masterClient, IsAlive)
 	if err != nil {
 		return err
 	}
 
 	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, hostPingErr := redis.String(masterClient.Connection.Do("PING"))
+	hostPingResult, hostPingErr := masterClient.Ping()
 	if strings.Contains(hostPingResult, "PONG") {
 		votes++
 	}
@@ -103,15 +114,27 @@ func (masterClient *RedisClient) handleIfDead() error {
 
 	tools.InfoLogger.Printf(msg.PromotinSlaveStart, masterClient.Address)
 
-	slaveClient, isSet := masterSlaveMap[masterClient.Address]
-	if isSet == false {
+	slaveClients := getSlavesOf(masterClient.Address)
+	if len(slaveClients) == 0 {
 		tools.ErrorLogger.Println(msg.MasterSlaveMapNotInit)
 
 		return fmt.Errorf(msg.MasterSlaveMapNotInit)
 	}
 
 	// 슬레이브를 새로운 마스터로 승격
-	if err := slaveClient.promoteToMaster(); err != nil {
+	// 가장 최근 쓰기까지 반영한 슬레이브부터 시도, 죽은 슬레이브는 건너뛴다
+	var promoteErr error
+	for _, slaveClient := range rankSlavesForPromotion(slaveClients) {
+
+		promoteErr = slaveClient.promoteToMaster()
+		if promoteErr == nil || promoteErr.Error() != msg.BothMasterSlaveDead {
+			break
+		}
+
+		tools.ErrorLogger.Printf(msg.VoteResultSlaveDead, slaveClient.Address)
+	}
+
+	if err := promoteErr; err != nil {
 
 		if err.Error() == msg.BothMasterSlaveDead {
 
@@ -131,6 +154,9 @@ func (masterClient *RedisClient) handleIfDead() error {
 
 	tools.InfoLogger.Printf(msg.PromotionSuccess, masterClient.Address)
 
+	// 승격된 마스터는 슬레이브가 없을 수 있다 (replica_migration.go)
+	requestReplicaMigration()
+
 	// 새로운 마스터로 승격 성공
 	// 죽은 기존 마스터는 재시작 (using docker API)
 	// err = docker.restartRedisContainer(masterClient.Address)
@@ -152,55 +178,17 @@ func (masterClient *RedisClient) handleIfDead() error {
 	return nil
 }
 
-// getSlave : 자신의 슬레이브가 살아있을 경우 반환, 죽어있을 경우 에러 반환
-// To-Do : 1-1 매핑이 아닌, 슬레이브가 여러 대일 경우 처리
-//
-func (masterClient RedisClient) getSlave() (*RedisClient, error) {
-
-	slaveClient, isSet := masterSlaveMap[masterClient.Address]
-	if isSet == false {
-		return nil, fmt.Errorf("")
-	}
-
-	// 모니터 서버에 슬레이브 생존 여부 확인
-	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*slaveClient, IsAlive)
-	if err != nil {
-		return nil, fmt.Errorf("")
-	}
-
-	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
-	if strings.Contains(hostPingResult, "PONG") {
-		votes++
-	}
-
-	tools.InfoLogger.Printf(
-		msg.FailOverVoteResult,
-		slaveClient.Address,
-		votes,
-		numberOfTotalVotes,
-	)
-
-	// 과반수 이상이 죽었다고 판단
-	if votes < (numberOfTotalVotes / 2) {
-		err := fmt.Errorf(msg.VoteResultSlaveDead, slaveClient.Address)
-		return nil, err
-	}
-
-	return slaveClient, nil
-}
-
 // handleIfDeadWithLock : Monitor 루틴에 사용되는 메소드
 // handleIfDead() 의 확장으로 Lock을 이용한다
 //
 func (masterClient *RedisClient) handleIfDeadWithLock(errorChannel chan error) {
 
-	redisMutexMap[masterClient.Address].Lock()
-	defer redisMutexMap[masterClient.Address].Unlock()
+	groupMutex := mutexOf(masterClient.Address)
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
 
 	// Redis Node can be discarded from Master nodes if redistribute happens
-	if masterClient.Role != MasterRole {
+	if masterClient.currentRole() != MasterRole {
 		return
 	}
 
@@ -214,21 +202,25 @@ func (masterClient *RedisClient) handleIfDeadWithLock(errorChannel chan error) {
 //
 func (masterClient *RedisClient) checkSlaveAlive() {
 
-	slaveClient, isSet := masterSlaveMap[masterClient.Address]
-	if isSet == false {
-		return
+	tools.InfoLogger.Printf(msg.StartSlaveAliveCheck, masterClient.Address)
+
+	for _, slaveClient := range getSlavesOf(masterClient.Address) {
+		masterClient.checkEachSlaveAlive(slaveClient)
 	}
+}
 
-	tools.InfoLogger.Printf(msg.StartSlaveAliveCheck, masterClient.Address)
+// checkEachSlaveAlive : @slaveClient 생존 투표, 죽었을 시 재시작 후 masterClient 인스턴스에 다시 연결
+//
+func (masterClient *RedisClient) checkEachSlaveAlive(slaveClient *RedisClient) {
 
 	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*slaveClient, IsAlive)
+	votes, err := monitorClient.ask(slaveClient, IsAlive)
 	if err != nil {
 		return
 	}
 
 	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
+	hostPingResult, _ := slaveClient.Ping()
 	if strings.Contains(hostPingResult, "PONG") {
 		votes++
 	}
@@ -248,8 +240,7 @@ func (masterClient *RedisClient) checkSlaveAlive() {
 
 	tools.InfoLogger.Printf(msg.VoteResultSlaveDead, slaveClient.Address)
 
-	// 죽었을 경우
-	slaveClient.removeDataLogFile()
+	// 죽었을 경우 (데이터 로그는 남겨두고 재연결 시 부분 재동기화)
 	slaveClient.RemoveFromList()
 
 	err = docker.restartRedisContainer(slaveClient.Address)
@@ -260,31 +251,39 @@ func (masterClient *RedisClient) checkSlaveAlive() {
 	}
 }
 
-// cleanUpMemory : masterClient 인스턴스와 이에 매핑된 Slave Client 의 메모리 해제
+// cleanUpMemory : masterClient 인스턴스와 이에 매핑된 Slave Client 들의 메모리 해제
 // Garbace Collect
-// To-Do : 여러 Slave Client들에 대한 처리
 //
 func (masterClient *RedisClient) cleanUpMemory() error {
 
-	clientHashRangeMap[masterClient.Address] = nil
-	delete(clientHashRangeMap, masterClient.Address)
+	removeHashRanges(masterClient.Address)
 
-	slaveClient := masterSlaveMap[masterClient.Address]
+	slaveClients := getSlavesOf(masterClient.Address)
 
 	masterClient.RemoveFromList()
-	slaveClient.RemoveFromList()
+	for _, eachSlave := range slaveClients {
+		eachSlave.RemoveFromList()
+	}
 
-	if _, err := monitorClient.ask(*masterClient, EndConnect); err != nil {
+	if _, err := monitorClient.ask(masterClient, EndConnect); err != nil {
 		return err
 	}
 
-	if _, err := monitorClient.ask(*slaveClient, EndConnect); err != nil {
-		return err
+	for _, eachSlave := range slaveClients {
+		if _, err := monitorClient.ask(eachSlave, EndConnect); err != nil {
+			return err
+		}
 	}
 
 	delete(MasterSlaveChannelMap, masterClient.Address)
+	removeReplicationBacklog(masterClient.Address)
+
+	topologyMutex.Lock()
 	delete(masterSlaveMap, masterClient.Address)
-	delete(slaveMasterMap, slaveClient.Address)
+	for _, eachSlave := range slaveClients {
+		delete(slaveMasterMap, eachSlave.Address)
+	}
+	topologyMutex.Unlock()
 
 	return nil
 }
@@ -305,13 +304,13 @@ func (slaveClient *RedisClient) promoteToMaster() error {
 
 	// 슬레이브가 살아있는지 확인
 	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*slaveClient, IsAlive)
+	votes, err := monitorClient.ask(slaveClient, IsAlive)
 	if err != nil {
 		return err
 	}
 
 	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
+	hostPingResult, _ := slaveClient.Ping()
 	if strings.Contains(hostPingResult, "PONG") {
 		votes++
 	}
@@ -330,7 +329,7 @@ func (slaveClient *RedisClient) promoteToMaster() error {
 
 	tools.InfoLogger.Printf(msg.PromotingSlaveNode, slaveClient.Address)
 
-	masterClient, isSet := slaveMasterMap[slaveClient.Address]
+	masterClient, isSet := getMasterOf(slaveClient.Address)
 	if isSet == false {
 		return fmt.Errorf(msg.MasterSlaveMapNotInit)
 	}
@@ -342,49 +341,70 @@ func (slaveClient *RedisClient) promoteToMaster() error {
 	}
 
 	// 2. 기존 마스터의 해쉬 슬롯을 이어 받음
-	for _, eachHashRangeOfClient := range clientHashRangeMap[masterClient.Address] {
+	slaveClient.takeOverHashSlotsOf(masterClient)
+
+	bumpTopologyEpoch()
+
+	return nil
+}
+
+// takeOverHashSlotsOf : @masterClient 가 담당하던 해쉬 슬롯을 slaveClient 인스턴스에 할당
+//
+func (slaveClient *RedisClient) takeOverHashSlotsOf(masterClient *RedisClient) {
+
+	for _, eachHashRangeOfClient := range hashRangesOf(masterClient.Address) {
 		hashSlotStart := eachHashRangeOfClient.startIndex
 		hashSlotEnd := eachHashRangeOfClient.endIndex
 
-		// 2-1. 해쉬 맵 업데이트
+		// 해쉬 맵 업데이트
 		hashSlot.assign(slaveClient, hashSlotStart, hashSlotEnd)
-
-		newHashRange := HashRange{
-			startIndex: hashSlotStart,
-			endIndex:   hashSlotEnd,
-		}
-
-		// 2-2. 담당하는 해쉬 슬롯 범위에 추가
-		clientHashRangeMap[slaveClient.Address] = append(
-			clientHashRangeMap[slaveClient.Address],
-			newHashRange,
-		)
 	}
 
-	// 기존 마스터의 해쉬 슬롯 범위 제거 (Garbage Collect)
-	clientHashRangeMap[masterClient.Address] = nil
-	delete(clientHashRangeMap, masterClient.Address)
-
-	return nil
+	// 담당하는 해쉬 슬롯 범위를 옮기고 기존 마스터의 범위 제거 (Garbage Collect)
+	moveHashRanges(masterClient.Address, slaveClient.Address)
 }
 
 // setUpMasterConfig : slaveClient 인스턴스를 마스터 Client의 설정 추가, 기존 마스터 Client의 설정 삭제
+// 기존 마스터의 나머지 슬레이브는 새로운 마스터의 슬레이브가 된다
 //
 func (slaveClient *RedisClient) setUpMasterConfig() error {
 
-	masterClient, isSet := slaveMasterMap[slaveClient.Address]
+	masterClient, isSet := getMasterOf(slaveClient.Address)
 	if isSet == false {
 		return fmt.Errorf(msg.MasterSlaveMapNotInit)
 	}
 
-	masterSlaveMap[slaveClient.Address] = masterClient
+	// native 복제 모드는 레디스 복제를 끊어야 쓰기를 받는다
+	if err := slaveClient.replicaOfNoOne(); err != nil {
+		return err
+	}
+
+	// 마스터는 슬레이브 데이터 로그 위치가 필요 없다
+	if err := slaveClient.clearResyncPosition(); err != nil {
+		tools.ErrorLogger.Printf(msg.ClearResyncPositionFail, slaveClient.Address, err.Error())
+	}
+
+	// 새로운 마스터가 반영한 마지막 쓰기 순번, 이보다 뒤처진 슬레이브는 전체 동기화
+	promotedSequence := getReplicationState(slaveClient.Address).sequence
 
 	if err := masterClient.removeDataLogFile(); err != nil {
 		return err
 	}
 
-	slaveClient.RemoveFromList()
-	masterClient.RemoveFromList()
+	topologyMutex.Lock()
+
+	otherSlaves := []*RedisClient{}
+	for _, eachSlave := range masterSlaveMap[masterClient.Address] {
+		if eachSlave.Address == slaveClient.Address {
+			continue
+		}
+		otherSlaves = append(otherSlaves, eachSlave)
+		slaveMasterMap[eachSlave.Address] = slaveClient
+	}
+	masterSlaveMap[slaveClient.Address] = otherSlaves
+
+	slaveClient.removeFromListLocked()
+	masterClient.removeFromListLocked()
 
 	slaveClient.Role = MasterRole
 
@@ -393,9 +413,32 @@ func (slaveClient *RedisClient) setUpMasterConfig() error {
 	delete(masterSlaveMap, masterClient.Address)
 	delete(slaveMasterMap, slaveClient.Address)
 
+	topologyMutex.Unlock()
+
+	removeReplicationBacklog(masterClient.Address)
+
+	slaveClient.attachSlaves(otherSlaves, promotedSequence)
+
 	return nil
 }
 
+// rankSlavesForPromotion : 승격 후보 순서, 가장 최근 쓰기까지 반영한 슬레이브가 먼저
+//
+func rankSlavesForPromotion(slaveClients []*RedisClient) []*RedisClient {
+
+	progress := make(map[string]int64, len(slaveClients))
+	for _, eachSlave := range slaveClients {
+		progress[eachSlave.Address] = replicationProgress(eachSlave)
+	}
+
+	rankedSlaves := append([]*RedisClient{}, slaveClients...)
+	sort.SliceStable(rankedSlaves, func(i, j int) bool {
+		return progress[rankedSlaves[i].Address] > progress[rankedSlaves[j].Address]
+	})
+
+	return rankedSlaves
+}
+
 /****************************************
  *
  *
@@ -408,47 +451,50 @@ func (slaveClient *RedisClient) setUpMasterConfig() error {
 //
 func (redisClient *RedisClient) RemoveFromList() {
 
-	tools.InfoLogger.Printf(msg.SlaveNodeInfo, redisClient.Role, redisClient.Address)
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
 
-	isFound := false
-
-	for i, eachClient := range redisMasterClients {
-		if eachClient.Address == redisClient.Address {
+	redisClient.removeFromListLocked()
+}
 
-			redisMasterClients[i] = redisMasterClients[len(redisMasterClients)-1]
-			redisMasterClients[len(redisMasterClients)-1] = nil
+// removeFromListLocked : RemoveFromList, topologyMutex 를 잡고 호출
+// 목록은 새 슬라이스로 만들어, 이전에 복사한 목록 (getMasterClientList) 을 사용하는 고루틴에 영향을 주지 않는다
+//
+func (redisClient *RedisClient) removeFromListLocked() {
 
-			isFound = true
-			break
-		}
-	}
+	tools.InfoLogger.Printf(msg.SlaveNodeInfo, redisClient.Role, redisClient.Address)
 
-	if isFound {
-		redisMasterClients = redisMasterClients[:len(redisMasterClients)-1]
+	if remainingClients, isFound := removeClient(redisMasterClients, redisClient.Address); isFound {
+		redisMasterClients = remainingClients
+		bumpTopologyEpoch()
 		return
 	}
 
-	for i, eachClient := range redisSlaveClients {
-		if eachClient.Address == redisClient.Address {
+	if remainingClients, isFound := removeClient(redisSlaveClients, redisClient.Address); isFound {
+		redisSlaveClients = remainingClients
+	}
+}
 
-			redisSlaveClients[i] = redisSlaveClients[len(redisSlaveClients)-1]
-			redisSlaveClients[len(redisSlaveClients)-1] = nil
+// removeClient : @redisClients 에서 @address 를 제외한 새 슬라이스
+//
+func removeClient(redisClients []*RedisClient, address string) ([]*RedisClient, bool) {
 
-			isFound = true
-			break
+	remainingClients := make([]*RedisClient, 0, len(redisClients))
+	for _, eachClient := range redisClients {
+		if eachClient.Address != address {
+			remainingClients = append(remainingClients, eachClient)
 		}
 	}
 
-	if isFound {
-		redisSlaveClients = redisSlaveClients[:len(redisSlaveClients)-1]
-	}
-
-	return
+	return remainingClients, len(remainingClients) != len(redisClients)
 }
 
 // isAlreadyExist : redisClient 인스턴스의 주소를 바탕으로 등록된 마스터, 슬레이브 리스트에 존재하는 지 확인
 //
-func (redisClient RedisClient) isAlreadyExist() bool {
+func (redisClient *RedisClient) isAlreadyExist() bool {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
 
 	for _, eachClient := range redisMasterClients {
 		if eachClient.Address == redisClient.Address {
@@ -469,8 +515,11 @@ func (redisClient RedisClient) isAlreadyExist() bool {
 func GetMasterClients() []RedisClient {
 	masters := []RedisClient{}
 
-	for _, eachMaster := range redisMasterClients {
-		masters = append(masters, *eachMaster)
+	for _, eachMaster := range getMasterClientList() {
+		master := eachMaster.snapshot()
+		master.CircuitBreaker = GetCircuitState(master.Address)
+		master.Health = GetNodeState(master.Address)
+		masters = append(masters, master)
 	}
 
 	return masters
@@ -480,9 +529,107 @@ func GetMasterClients() []RedisClient {
 func GetSlaveClients() []RedisClient {
 	slaves := []RedisClient{}
 
-	for _, eachSlave := range redisSlaveClients {
-		slaves = append(slaves, *eachSlave)
+	for _, eachSlave := range getSlaveClientList() {
+		slave := eachSlave.snapshot()
+		slave.CircuitBreaker = GetCircuitState(slave.Address)
+		slave.Health = GetNodeState(slave.Address)
+		slaves = append(slaves, slave)
 	}
 
 	return slaves
 }
+
+/****************************************
+ *
+ *
+ *        Topology Accessor
+ *
+ *
+ ****************************************/
+
+// getMasterClientList : 마스터 목록의 복사본
+//
+func getMasterClientList() []*RedisClient {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return append([]*RedisClient{}, redisMasterClients...)
+}
+
+// getSlaveClientList : 슬레이브 (대기 노드 포함) 목록의 복사본
+//
+func getSlaveClientList() []*RedisClient {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return append([]*RedisClient{}, redisSlaveClients...)
+}
+
+// getSlavesOf : @masterAddress 의 슬레이브 목록
+// 슬레이브 목록은 바뀔 때마다 새 슬라이스로 교체하므로 (initMasterSlaveMaps) 반환된 목록은 Lock 없이 읽어도 된다
+//
+func getSlavesOf(masterAddress string) []*RedisClient {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return masterSlaveMap[masterAddress]
+}
+
+// getMasterOf : @slaveAddress 의 마스터
+//
+func getMasterOf(slaveAddress string) (*RedisClient, bool) {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	masterClient, isSet := slaveMasterMap[slaveAddress]
+
+	return masterClient, isSet
+}
+
+// mutexOf : @address 가 속한 마스터-슬레이브 그룹의 Failover 뮤텍스, 없으면 새로 만든다
+// 슬레이브 없이 추가된 마스터 (AddNewMaster) 도 Failover 할 수 있도록 한다
+//
+func mutexOf(address string) *sync.Mutex {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	return mutexOfLocked(address)
+}
+
+// mutexOfLocked : mutexOf, topologyMutex 를 잡고 호출
+//
+func mutexOfLocked(address string) *sync.Mutex {
+
+	groupMutex, isExist := redisMutexMap[address]
+	if !isExist {
+		groupMutex = &sync.Mutex{}
+		redisMutexMap[address] = groupMutex
+	}
+
+	return groupMutex
+}
+
+// currentRole : 인스턴스의 현재 Role (Failover 중 바뀔 수 있다)
+//
+func (redisClient *RedisClient) currentRole() string {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return redisClient.Role
+}
+
+// snapshot : 인스턴스의 복사본 (조회 응답용)
+//
+func (redisClient *RedisClient) snapshot() RedisClient {
+
+	topologyMutex.RLock()
+	defer topologyMutex.RUnlock()
+
+	return *redisClient
+}
@@ -0,0 +1,133 @@
+package cluster
+
+import (
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+
+	"hash_interface/tools"
+)
+
+func TestTopologyFailoverRace(t *testing.T) {
+
+	// 목록 변경 로그
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+
+	masterClient := &RedisClient{Address: "10.0.5.1:8000", Role: MasterRole}
+	slaveClient := &RedisClient{Address: "10.0.5.2:8000", Role: SlaveRole}
+	standaloneMaster := &RedisClient{Address: "10.0.5.3:8000", Role: MasterRole}
+
+	AppendMaster(masterClient)
+	AppendMaster(standaloneMaster)
+	AppendSlave(slaveClient)
+	initMasterSlaveMaps(masterClient, slaveClient)
+
+	// 테스트용 해쉬 슬롯 구간 (요청 경로는 Failover 중에도 슬롯을 조회한다)
+	testSlot := uint16(100)
+	hashSlot.assign(masterClient, testSlot, testSlot+10)
+	appendHashRange(masterClient.Address, HashRange{startIndex: testSlot, endIndex: testSlot + 10})
+
+	defer func() {
+		hashSlot.slotsMutex.Lock()
+		for slot := testSlot; slot < testSlot+10; slot++ {
+			delete(hashSlot.slots, slot)
+		}
+		hashSlot.slotsMutex.Unlock()
+
+		for _, eachClient := range []*RedisClient{masterClient, slaveClient, standaloneMaster} {
+			eachClient.RemoveFromList()
+			removeReplicationBacklog(eachClient.Address)
+
+			topologyMutex.Lock()
+			delete(masterSlaveMap, eachClient.Address)
+			delete(slaveMasterMap, eachClient.Address)
+			delete(redisMutexMap, eachClient.Address)
+			delete(clientHashRangeMap, eachClient.Address)
+			topologyMutex.Unlock()
+		}
+	}()
+
+	// 슬레이브 없이 추가된 마스터도 Failover 뮤텍스가 있다
+	topologyMutex.RLock()
+	standaloneMutex := redisMutexMap[standaloneMaster.Address]
+	topologyMutex.RUnlock()
+	if standaloneMutex == nil || mutexOf(standaloneMaster.Address) != standaloneMutex {
+		t.Fatal("expected failover mutex for a master without slaves")
+	}
+
+	// 요청 / 복제 / 조회 경로는 Failover 중에도 계속 토폴로지를 읽는다
+	readers := []func(){
+		func() {
+			GetMasterClients()
+			GetSlaveClients()
+		},
+		func() {
+			selectReplica(masterClient.Address, ReadOptions{})
+			selectReplica(slaveClient.Address, ReadOptions{})
+		},
+		func() {
+			GetReplicationStatus()
+		},
+		func() {
+			GetRedisClient(testSlot)
+			GetSlotRanges()
+			hashRangesOf(masterClient.Address)
+			hashRangesOf(slaveClient.Address)
+		},
+		func() {
+			for _, eachMaster := range getMasterClientList() {
+				eachMaster.currentRole()
+				isSlaveOf(slaveClient.Address, eachMaster.Address)
+			}
+		},
+	}
+
+	done := make(chan struct{})
+	waitGroup := sync.WaitGroup{}
+
+	for _, eachReader := range readers {
+
+		waitGroup.Add(1)
+
+		go func(read func()) {
+			defer waitGroup.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					read()
+				}
+			}
+		}(eachReader)
+	}
+
+	// 마스터 <-> 슬레이브 전환을 반복 (짝수 번이면 처음 구성으로 돌아온다)
+	for i := 0; i < 200; i++ {
+		if i%2 == 0 {
+			swapMasterSlaveConfigs(masterClient, slaveClient)
+			slaveClient.takeOverHashSlotsOf(masterClient)
+		} else {
+			swapMasterSlaveConfigs(slaveClient, masterClient)
+			masterClient.takeOverHashSlotsOf(slaveClient)
+		}
+	}
+
+	close(done)
+	waitGroup.Wait()
+
+	if masterClient.currentRole() != MasterRole || !isSlaveOf(slaveClient.Address, masterClient.Address) {
+		t.Fatalf("unexpected topology after failover : %s is %s", masterClient.Address, masterClient.currentRole())
+	}
+	if owner, _ := GetRedisClient(testSlot); owner != masterClient {
+		t.Fatalf("expected slot %d to be owned by %s", testSlot, masterClient.Address)
+	}
+	if ranges := hashRangesOf(masterClient.Address); len(ranges) != 1 || len(hashRangesOf(slaveClient.Address)) != 0 {
+		t.Fatalf("unexpected hash ranges after failover : %v", ranges)
+	}
+	if masterCount := len(getMasterClientList()); masterCount != 2 {
+		t.Fatalf("expected 2 masters, got %d", masterCount)
+	}
+}
@@ -0,0 +1,348 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+)
+
+/* 슬레이브 이동 (Replica Migration, Redis Cluster 와 유사)
+ * 슬레이브가 최소 슬레이브 수 (MIN_REPLICAS_PER_MASTER) 보다 적은 마스터에 슬레이브를 옮긴다.
+ *   1. 대기 노드 (standby, 마스터 없이 등록된 노드) 가 있으면 먼저 사용
+ *   2. 없으면 슬레이브가 가장 많은 마스터에서 하나를 옮긴다 (옮긴 뒤에도 최소 슬레이브 수 이상 남는 경우만)
+ *      옮기는 슬레이브는 승격 순서 (rankSlavesForPromotion) 가 가장 늦은 슬레이브
+ * 승격 (promoteToMaster) 후 새로운 마스터는 슬레이브가 없을 수 있으므로 바로 확인하고, 그 외에는 주기적으로 확인한다.
+ * 옮긴 슬레이브는 이전 마스터의 데이터를 지우고 새로운 마스터와 전체 동기화 (native 모드는 REPLICAOF 가 데이터를 교체)
+ * down 이거나 Failover 중인 마스터는 옮기는 대상 / 옮겨오는 대상에서 제외하고,
+ * 이동 중에는 두 마스터의 Failover 뮤텍스 (redisMutexMap) 를 잡아 Failover 와 겹치지 않게 한다
+ */
+
+// StandbyRole : 마스터에 연결되지 않고 슬레이브가 부족한 마스터를 기다리는 노드
+const StandbyRole = "standby"
+
+// minReplicasPerMaster : 마스터 별 최소 슬레이브 수, 0 이면 슬레이브를 옮기지 않는다
+var minReplicasPerMaster int
+
+// replicaMigrationRequests : 다음 주기를 기다리지 않고 바로 확인 요청 (요청이 쌓이지 않도록 크기 1)
+var replicaMigrationRequests chan struct{}
+
+// replicaMove : @slave 를 @from 마스터 (대기 노드면 nil) 에서 @to 마스터로 이동
+type replicaMove struct {
+	slave *RedisClient
+	from  *RedisClient
+	to    *RedisClient
+}
+
+func init() {
+	if replicaMigrationRequests == nil {
+		replicaMigrationRequests = make(chan struct{}, 1)
+	}
+	minReplicasPerMaster = configs.DefaultMinReplicasPerMaster
+}
+
+// SetUpReplicaMigration : 빈 문자열이면 DefaultMinReplicasPerMaster
+//
+func SetUpReplicaMigration(minReplicas string) error {
+
+	if minReplicas == "" {
+		minReplicasPerMaster = configs.DefaultMinReplicasPerMaster
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(minReplicas)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf(msg.InvalidMinReplicas, minReplicas)
+	}
+
+	minReplicasPerMaster = parsed
+
+	return nil
+}
+
+// GetMinReplicasPerMaster : 현재 마스터 별 최소 슬레이브 수
+func GetMinReplicasPerMaster() int {
+	return minReplicasPerMaster
+}
+
+// StartReplicaMigration : 슬레이브가 부족한 마스터 확인 고루틴 시작 (최소 슬레이브 수가 0 이면 시작하지 않음)
+//
+func StartReplicaMigration() {
+
+	if minReplicasPerMaster == 0 {
+		return
+	}
+
+	go func() {
+
+		ticker := time.NewTicker(time.Duration(configs.ReplicaMigrationIntervalMs) * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-replicaMigrationRequests:
+			}
+
+			rebalanceReplicas()
+		}
+	}()
+}
+
+// requestReplicaMigration : 슬레이브 구성이 바뀐 후 바로 확인 요청, 이미 요청이 있으면 무시
+//
+func requestReplicaMigration() {
+
+	select {
+	case replicaMigrationRequests <- struct{}{}:
+	default:
+	}
+}
+
+// AddNewStandby : 마스터 없이 대기하는 노드 추가, 슬레이브가 부족한 마스터가 있으면 바로 슬레이브로 연결
+//
+func AddNewStandby(newStandbyAddress string) error {
+
+	addClientMutex.Lock()
+	defer addClientMutex.Unlock()
+
+	if err := NodeConnectionSetup([]string{newStandbyAddress}, StandbySetup); err != nil {
+		return err
+	}
+
+	newStandby, err := GetSlaveClientWithAddress(newStandbyAddress)
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.StandbyNotFound)
+		return err
+	}
+
+	// 모니터 서버에도 등록 요청
+	if _, err := monitorClient.ask(newStandby, NewConnect); err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorRegisterFail)
+		return err
+	}
+
+	requestReplicaMigration()
+
+	return nil
+}
+
+// rebalanceReplicas : 슬레이브가 부족한 마스터에 대기 노드 / 여유 슬레이브 이동
+//
+func rebalanceReplicas() {
+
+	addClientMutex.Lock()
+	defer addClientMutex.Unlock()
+
+	topologyMutex.RLock()
+	standbys := []*RedisClient{}
+	for _, eachClient := range redisSlaveClients {
+		if eachClient.Role == StandbyRole {
+			standbys = append(standbys, eachClient)
+		}
+	}
+	masterClients := append([]*RedisClient{}, redisMasterClients...)
+	topologyMutex.RUnlock()
+
+	// 옮길 슬레이브를 고르기 위해 승격 순서로 정렬 (가장 늦은 슬레이브가 마지막)
+	rankedSlaves := make(map[string][]*RedisClient, len(masterClients))
+	for _, eachMaster := range masterClients {
+		rankedSlaves[eachMaster.Address] = rankSlavesForPromotion(getSlavesOf(eachMaster.Address))
+	}
+
+	moves := planReplicaMigration(masterClients, rankedSlaves, standbys, minReplicasPerMaster)
+
+	for _, eachMove := range moves {
+		if err := eachMove.run(); err != nil {
+			tools.ErrorLogger.Printf(msg.ReplicaMigrationFail, eachMove.slave.Address, eachMove.to.Address, err.Error())
+		}
+	}
+}
+
+// planReplicaMigration : 슬레이브 수가 @minReplicas 미만인 마스터를 채우는 이동 목록
+// @slavesOf 는 마스터 별 슬레이브 (승격 순서), 슬레이브가 적은 마스터부터 채운다
+// down 이거나 Failover 중인 마스터는 제외 (isMigratable)
+//
+func planReplicaMigration(allMasters []*RedisClient, slavesOf map[string][]*RedisClient, standbys []*RedisClient, minReplicas int) []replicaMove {
+
+	moves := []replicaMove{}
+	if minReplicas <= 0 {
+		return moves
+	}
+
+	masters := []*RedisClient{}
+	for _, eachMaster := range allMasters {
+		if isMigratable(eachMaster) {
+			masters = append(masters, eachMaster)
+		}
+	}
+
+	remaining := make(map[string][]*RedisClient, len(masters))
+	for _, eachMaster := range masters {
+		remaining[eachMaster.Address] = append([]*RedisClient{}, slavesOf[eachMaster.Address]...)
+	}
+
+	orphans := []*RedisClient{}
+	for _, eachMaster := range masters {
+		if len(remaining[eachMaster.Address]) < minReplicas {
+			orphans = append(orphans, eachMaster)
+		}
+	}
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return len(remaining[orphans[i].Address]) < len(remaining[orphans[j].Address])
+	})
+
+	standbyIndex := 0
+
+	for _, orphan := range orphans {
+
+		for len(remaining[orphan.Address]) < minReplicas {
+
+			if standbyIndex < len(standbys) {
+				moves = append(moves, replicaMove{slave: standbys[standbyIndex], to: orphan})
+				remaining[orphan.Address] = append(remaining[orphan.Address], standbys[standbyIndex])
+				standbyIndex++
+				continue
+			}
+
+			// 옮긴 뒤에도 최소 슬레이브 수 이상 남는 마스터 중 슬레이브가 가장 많은 마스터
+			var donor *RedisClient
+			for _, eachMaster := range masters {
+				if eachMaster.Address == orphan.Address || len(remaining[eachMaster.Address]) <= minReplicas {
+					continue
+				}
+				if donor == nil || len(remaining[eachMaster.Address]) > len(remaining[donor.Address]) {
+					donor = eachMaster
+				}
+			}
+			if donor == nil {
+				return moves
+			}
+
+			donorSlaves := remaining[donor.Address]
+			movedSlave := donorSlaves[len(donorSlaves)-1]
+
+			moves = append(moves, replicaMove{slave: movedSlave, from: donor, to: orphan})
+			remaining[donor.Address] = donorSlaves[:len(donorSlaves)-1]
+			remaining[orphan.Address] = append(remaining[orphan.Address], movedSlave)
+		}
+	}
+
+	return moves
+}
+
+// isMigratable : 슬레이브를 옮겨오거나 옮겨갈 수 있는 마스터인지 (up 이고 Failover 중이 아님)
+//
+func isMigratable(masterClient *RedisClient) bool {
+	return GetNodeState(masterClient.Address) == NodeUp && !isFailingOver(masterClient.Address)
+}
+
+// isCurrent : 계획한 이동이 아직 유효한지, 두 마스터의 Failover 뮤텍스를 잡고 호출
+//
+func (move replicaMove) isCurrent() bool {
+
+	if move.to.currentRole() != MasterRole || !isMigratable(move.to) {
+		return false
+	}
+
+	if move.from == nil {
+		return move.slave.currentRole() == StandbyRole
+	}
+
+	return move.from.currentRole() == MasterRole && isMigratable(move.from) && isSlaveOf(move.slave.Address, move.from.Address)
+}
+
+// lockMasterGroups : @masterClients 의 Failover 뮤텍스를 주소 순서로 잡고, 푸는 함수 반환
+// 여러 그룹을 잡는 경로끼리 서로 기다리지 않도록 항상 같은 순서로 잡는다 (같은 뮤텍스를 공유하면 한 번만)
+//
+func lockMasterGroups(masterClients ...*RedisClient) func() {
+
+	addresses := []string{}
+	for _, eachMaster := range masterClients {
+		if eachMaster != nil {
+			addresses = append(addresses, eachMaster.Address)
+		}
+	}
+	sort.Strings(addresses)
+
+	lockedMutexes := []*sync.Mutex{}
+
+	for _, eachAddress := range addresses {
+
+		groupMutex := mutexOf(eachAddress)
+
+		isLocked := false
+		for _, eachMutex := range lockedMutexes {
+			if eachMutex == groupMutex {
+				isLocked = true
+				break
+			}
+		}
+		if isLocked {
+			continue
+		}
+
+		groupMutex.Lock()
+		lockedMutexes = append(lockedMutexes, groupMutex)
+	}
+
+	return func() {
+		for i := len(lockedMutexes) - 1; i >= 0; i-- {
+			lockedMutexes[i].Unlock()
+		}
+	}
+}
+
+// run : 슬레이브를 이전 마스터에서 떼어내고 새로운 마스터의 데이터와 전체 동기화
+// 이전 / 새로운 마스터의 Failover 는 이동이 끝날 때까지 기다린다
+//
+func (move replicaMove) run() error {
+
+	unlock := lockMasterGroups(move.from, move.to)
+	defer unlock()
+
+	// 계획 이후 Failover 가 시작되었거나 구성이 바뀐 경우
+	if !move.isCurrent() {
+		return fmt.Errorf(msg.ReplicaMigrationStale)
+	}
+
+	from := StandbyRole
+	if move.from != nil {
+		from = move.from.Address
+	}
+	tools.InfoLogger.Printf(msg.ReplicaMigrationStart, move.slave.Address, from, move.to.Address, minReplicasPerMaster)
+
+	// 이전 마스터의 복제 고루틴은 isSlaveOf 로 확인하고 종료된다
+	if move.from != nil {
+		detachSlave(move.from, move.slave)
+	}
+
+	// stream 모드는 이전 마스터의 데이터 (데이터 로그 위치 포함) 를 지워 전체 복사하도록 한다
+	if replicationMode != ReplicationModeNative {
+		if _, err := move.slave.Do("FLUSHALL"); err != nil {
+			return err
+		}
+	}
+
+	// 이전 마스터 기준의 반영 순번은 새로운 마스터에 의미가 없다
+	resetReplicationState(move.slave.Address)
+
+	topologyMutex.Lock()
+	move.slave.Role = SlaveRole
+	topologyMutex.Unlock()
+
+	initMasterSlaveMaps(move.to, move.slave)
+
+	if err := move.to.syncReplica(move.slave); err != nil {
+		return err
+	}
+
+	tools.InfoLogger.Printf(msg.ReplicaMigrationSuccess, move.slave.Address, move.to.Address)
+
+	return nil
+}
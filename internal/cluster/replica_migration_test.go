@@ -0,0 +1,91 @@
+package cluster
+
+import "testing"
+
+func TestPlanReplicaMigration(t *testing.T) {
+
+	firstMaster := &RedisClient{Address: "10.0.3.1:8000", Role: MasterRole}
+	secondMaster := &RedisClient{Address: "10.0.3.2:8000", Role: MasterRole}
+	orphanMaster := &RedisClient{Address: "10.0.3.3:8000", Role: MasterRole}
+
+	upToDateSlave := &RedisClient{Address: "10.0.3.11:8000", Role: SlaveRole}
+	laggingSlave := &RedisClient{Address: "10.0.3.12:8000", Role: SlaveRole}
+	secondSlave := &RedisClient{Address: "10.0.3.21:8000", Role: SlaveRole}
+
+	masters := []*RedisClient{firstMaster, secondMaster, orphanMaster}
+	slavesOf := map[string][]*RedisClient{
+		firstMaster.Address:  {upToDateSlave, laggingSlave},
+		secondMaster.Address: {secondSlave},
+	}
+
+	// 슬레이브가 2 개인 마스터에서 승격 순서가 가장 늦은 슬레이브를 옮긴다
+	moves := planReplicaMigration(masters, slavesOf, nil, 1)
+	if len(moves) != 1 {
+		t.Fatalf("expected 1 move, got %d", len(moves))
+	}
+	if moves[0].slave != laggingSlave || moves[0].from != firstMaster || moves[0].to != orphanMaster {
+		t.Fatalf("unexpected move : %s (%v) => %s", moves[0].slave.Address, moves[0].from, moves[0].to.Address)
+	}
+
+	// 대기 노드가 있으면 먼저 사용
+	standby := &RedisClient{Address: "10.0.3.31:8000", Role: StandbyRole}
+	moves = planReplicaMigration(masters, slavesOf, []*RedisClient{standby}, 1)
+	if len(moves) != 1 || moves[0].slave != standby || moves[0].from != nil {
+		t.Fatalf("expected standby move, got %+v", moves)
+	}
+
+	// 옮기면 최소 슬레이브 수 미만이 되는 마스터에서는 옮기지 않는다
+	if moves := planReplicaMigration(masters, slavesOf, nil, 2); len(moves) != 0 {
+		t.Fatalf("expected no moves, got %d", len(moves))
+	}
+
+	if moves := planReplicaMigration(masters, slavesOf, []*RedisClient{standby}, 0); len(moves) != 0 {
+		t.Fatalf("expected replica migration disabled, got %d moves", len(moves))
+	}
+
+	// Failover 중인 마스터로는 옮기지 않는다
+	orphanHealth := healthOf(orphanMaster.Address)
+	donorHealth := healthOf(firstMaster.Address)
+	defer func() {
+		nodeHealthMutex.Lock()
+		delete(nodeHealthMap, orphanMaster.Address)
+		delete(nodeHealthMap, firstMaster.Address)
+		nodeHealthMutex.Unlock()
+	}()
+
+	nodeHealthMutex.Lock()
+	orphanHealth.isFailingOver = true
+	nodeHealthMutex.Unlock()
+
+	if moves := planReplicaMigration(masters, slavesOf, []*RedisClient{standby}, 1); len(moves) != 0 {
+		t.Fatalf("expected no moves to a failing over master, got %d", len(moves))
+	}
+
+	// down 인 마스터에서는 옮겨오지 않는다
+	nodeHealthMutex.Lock()
+	orphanHealth.isFailingOver = false
+	donorHealth.state = NodeDown
+	nodeHealthMutex.Unlock()
+
+	if moves := planReplicaMigration(masters, slavesOf, nil, 1); len(moves) != 0 {
+		t.Fatalf("expected no moves from a down master, got %d", len(moves))
+	}
+
+	// 같은 Failover 뮤텍스를 공유하는 그룹은 한 번만 잡는다
+	topologyMutex.Lock()
+	redisMutexMap[secondMaster.Address] = mutexOfLocked(firstMaster.Address)
+	topologyMutex.Unlock()
+	defer func() {
+		topologyMutex.Lock()
+		delete(redisMutexMap, firstMaster.Address)
+		delete(redisMutexMap, secondMaster.Address)
+		topologyMutex.Unlock()
+	}()
+
+	unlock := lockMasterGroups(secondMaster, nil, firstMaster)
+	unlock()
+
+	if err := SetUpReplicaMigration("-1"); err == nil {
+		t.Fatal("expected error for negative minimum replicas")
+	}
+}
@@ -0,0 +1,258 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/internal/hash"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 슬레이브 조회 (Read Preference)
+ * 조회 요청마다 읽을 노드를 선택한다.
+ *   - master         : 항상 마스터 (기본값, GetData 와 동일)
+ *   - prefer-replica : 슬레이브가 있고 복제 지연이 허용 범위 이내이면 슬레이브, 아니면 마스터
+ *   - replica-only   : 슬레이브에서만 조회, 조건에 맞는 슬레이브가 없으면 ErrReplicaUnavailable
+ *
+ * 복제 지연 : 노드 별로 마지막으로 반영한 쓰기의 순번 / 시각을 기록하고
+ * (마스터의 마지막 쓰기 시각 - 슬레이브가 마지막으로 반영한 쓰기 시각) 으로 계산한다.
+ * 슬레이브가 마스터의 마지막 쓰기까지 반영했으면 0
+ * 슬레이브 조회는 마스터의 Mutex 를 잡지 않는다 (슬레이브 에러 시 prefer-replica 는 마스터로 다시 조회)
+ * 세션 토큰 (session.go) 이 있으면 토큰의 쓰기까지 반영한 슬레이브만 사용한다.
+ */
+
+// ReadPreference : 조회할 노드 선택 방식
+type ReadPreference string
+
+const (
+	ReadFromMaster        ReadPreference = "master"
+	ReadPreferReplica     ReadPreference = "prefer-replica"
+	ReadFromReplicaOnly   ReadPreference = "replica-only"
+	defaultReadPreference                = ReadFromMaster
+)
+
+// ReadOptions : 조회 옵션
+type ReadOptions struct {
+	Preference ReadPreference
+	// MaxStaleness : 허용하는 슬레이브의 최대 복제 지연 (0 = 제한 없음)
+	MaxStaleness time.Duration
+	// Session : 슬레이브가 반영해야 하는 쓰기 (Read-your-writes), SessionWait 동안 기다린 후 마스터로 조회
+	Session     SessionToken
+	SessionWait time.Duration
+}
+
+// ReadResult : 조회 결과와 값을 읽은 노드
+type ReadResult struct {
+	Value  string
+	Client *RedisClient
+	// IsReplica : 슬레이브에서 읽은 경우
+	IsReplica bool
+	// ReplicationLag : 슬레이브에서 읽은 경우 조회 시점의 복제 지연
+	ReplicationLag time.Duration
+}
+
+var (
+	// ErrInvalidReadPreference : 지원하지 않는 Read Preference
+	ErrInvalidReadPreference = errors.New(msg.InvalidReadPreference)
+	// ErrReplicaUnavailable : replica-only 조회에 사용할 수 있는 슬레이브가 없음
+	ErrReplicaUnavailable = errors.New(msg.ReplicaUnavailable)
+)
+
+// replicationState : 노드가 마지막으로 반영한 쓰기
+// 마스터는 마지막으로 받은 쓰기, 슬레이브는 마지막으로 전파받은 쓰기
+type replicationState struct {
+	sequence  uint64
+	writtenAt time.Time
+}
+
+// writeSequence : 클러스터 전체 쓰기 순번 (복제 Offset), 슬레이브가 마스터로 승격되어도 같은 순번을 이어 쓴다
+var writeSequence uint64
+
+// replicaReadCounter : 슬레이브가 여러 개일 때 조회를 나누는 Round-robin 카운터
+var replicaReadCounter uint64
+
+var replicationStates map[string]replicationState
+var replicationStateMutex *sync.RWMutex
+
+func init() {
+	if replicationStates == nil {
+		replicationStates = make(map[string]replicationState)
+	}
+	if replicationStateMutex == nil {
+		replicationStateMutex = &sync.RWMutex{}
+	}
+}
+
+// ParseReadPreference : 빈 문자열이면 master
+//
+func ParseReadPreference(value string) (ReadPreference, error) {
+
+	switch preference := ReadPreference(value); preference {
+	case "":
+		return defaultReadPreference, nil
+	case ReadFromMaster, ReadPreferReplica, ReadFromReplicaOnly:
+		return preference, nil
+	default:
+		return "", ErrInvalidReadPreference
+	}
+}
+
+// setReplicationState : @address 노드의 상태를 @state 로 올린다 (이미 더 최신이면 유지)
+// 마스터는 Backlog 추가 시, 슬레이브는 전송 확인 / 데이터 복사 (복사 시작 시점의 마스터 상태) 후
+//
+func setReplicationState(address string, state replicationState) {
+
+	replicationStateMutex.Lock()
+	defer replicationStateMutex.Unlock()
+
+	if state.sequence >= replicationStates[address].sequence {
+		replicationStates[address] = state
+	}
+}
+
+// resetReplicationState : 데이터를 다시 복사할 슬레이브의 반영 순번 초기화 (setReplicationState 는 순번을 낮추지 않는다)
+//
+func resetReplicationState(address string) {
+
+	replicationStateMutex.Lock()
+	defer replicationStateMutex.Unlock()
+
+	delete(replicationStates, address)
+}
+
+func getReplicationState(address string) replicationState {
+
+	replicationStateMutex.RLock()
+	defer replicationStateMutex.RUnlock()
+
+	return replicationStates[address]
+}
+
+// ReplicationLag : @masterAddress 기준 @slaveAddress 의 복제 지연
+//
+func ReplicationLag(masterAddress string, slaveAddress string) time.Duration {
+
+	replicationStateMutex.RLock()
+	masterState := replicationStates[masterAddress]
+	slaveState := replicationStates[slaveAddress]
+	replicationStateMutex.RUnlock()
+
+	if slaveState.sequence >= masterState.sequence {
+		return 0
+	}
+
+	// 슬레이브가 한 번도 전파받지 못한 경우 (복사 전 / 재시작 직후 쓰기 유실) 마스터 첫 쓰기부터 지연
+	if slaveState.writtenAt.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return masterState.writtenAt.Sub(slaveState.writtenAt)
+}
+
+// selectReplica : 복제 지연 / 세션 조건을 만족하는 @masterAddress 의 슬레이브, 없으면 nil
+// 슬레이브가 여러 개면 Round-robin 으로 나누고, 세션의 쓰기를 반영한 슬레이브가 없으면
+// 가장 앞선 슬레이브를 최대 SessionWait 동안 기다린다
+//
+func selectReplica(masterAddress string, options ReadOptions) *RedisClient {
+
+	slaveClients := getSlavesOf(masterAddress)
+	if len(slaveClients) == 0 {
+		return nil
+	}
+
+	startIndex := int(atomic.AddUint64(&replicaReadCounter, 1) % uint64(len(slaveClients)))
+	sessionSequence := options.Session[masterAddress]
+
+	var waitTarget *RedisClient
+
+	for i := range slaveClients {
+
+		eachSlave := slaveClients[(startIndex+i)%len(slaveClients)]
+		if eachSlave.Pool == nil || GetNodeState(eachSlave.Address) == NodeDown {
+			continue
+		}
+
+		if options.MaxStaleness > 0 && ReplicationLag(masterAddress, eachSlave.Address) > options.MaxStaleness {
+			continue
+		}
+
+		appliedSequence := getReplicationState(eachSlave.Address).sequence
+		if appliedSequence >= sessionSequence {
+			return eachSlave
+		}
+
+		if waitTarget == nil || appliedSequence > getReplicationState(waitTarget.Address).sequence {
+			waitTarget = eachSlave
+		}
+	}
+
+	// 세션의 쓰기를 아직 반영하지 못한 슬레이브
+	if waitTarget != nil && waitForSequence(waitTarget.Address, sessionSequence, options.SessionWait) {
+		return waitTarget
+	}
+
+	return nil
+}
+
+// GetDataWithOptions : @options 의 Read Preference 에 따라 마스터 또는 슬레이브에서 @key 조회
+// 저장되어 있지 않으면 ErrKeyNotFound (슬레이브 조회는 아직 전파되지 않은 Key 일 수 있다)
+//
+func GetDataWithOptions(ctx context.Context, key string, options ReadOptions) (ReadResult, error) {
+
+	if options.Preference == "" || options.Preference == ReadFromMaster {
+		value, redisClient, err := GetData(ctx, key)
+		return ReadResult{Value: value, Client: redisClient}, err
+	}
+
+	result, err := getDataFromReplica(ctx, key, options)
+	if err == nil || err == ErrKeyNotFound {
+		return result, err
+	}
+
+	if options.Preference == ReadFromReplicaOnly {
+		tools.ErrorLogger.Printf(msg.ReplicaReadFail, key, err.Error())
+		return ReadResult{}, ErrReplicaUnavailable
+	}
+
+	// prefer-replica : 마스터에서 다시 조회
+	value, redisClient, err := GetData(ctx, key)
+	return ReadResult{Value: value, Client: redisClient}, err
+}
+
+// getDataFromReplica : @key 의 해쉬 슬롯을 담당하는 마스터의 슬레이브에서 조회
+//
+func getDataFromReplica(ctx context.Context, key string, options ReadOptions) (ReadResult, error) {
+
+	masterClient := hashSlot.get(hash.GetHashSlotIndex(key))
+	if masterClient == nil {
+		return ReadResult{}, errors.New(msg.NotAnyRedisSetUpYet)
+	}
+
+	slaveClient := selectReplica(masterClient.Address, options)
+	if slaveClient == nil {
+		return ReadResult{}, ErrReplicaUnavailable
+	}
+
+	lag := ReplicationLag(masterClient.Address, slaveClient.Address)
+
+	result := ReadResult{Client: slaveClient, IsReplica: true, ReplicationLag: lag}
+
+	value, err := redis.String(slaveClient.DoContext(ctx, "GET", key))
+	if err == redis.ErrNil {
+		return result, ErrKeyNotFound
+
+	} else if err != nil {
+		return ReadResult{}, err
+	}
+
+	result.Value = value
+
+	return result, nil
+}
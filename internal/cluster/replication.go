@@ -1,29 +1,210 @@
 package cluster
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"hash_interface/configs"
 	msg "hash_interface/internal/cluster/message"
+	"hash_interface/internal/hash"
 	"hash_interface/tools"
+)
+
+/* 쓰기 복제 수준 (Write Ack)
+ * 클라이언트에 성공을 응답하기 전에 쓰기를 반영해야 하는 노드
+ *   - master  : 마스터 반영 + 데이터 로그 기록 (기본값, 슬레이브 전파 실패는 복제 지연으로 남는다)
+ *   - replica : 슬레이브 하나 이상이 반영
+ *   - all     : 마스터의 모든 슬레이브가 반영
+ * 요청한 수준을 만족하지 못하면 ErrReplicationAck, 쓰기는 이미 마스터에 반영되어 있으므로 되돌리지 않는다 (WAIT 와 동일)
+ */
+
+// WriteAck : 쓰기 복제 수준
+type WriteAck string
 
-	"github.com/gomodule/redigo/redis"
+const (
+	AckMaster  WriteAck = "master"
+	AckReplica WriteAck = "replica"
+	AckAll     WriteAck = "all"
 )
 
-// ReplicateToSlave : masterClient 인스턴스의 슬레이브에게 명령 전파
-// 슬레이브가 죽어있으면 처리하지 않는다 (살아날 때 마스터의 데이터를 복사)
+var (
+	// ErrInvalidWriteAck : 지원하지 않는 복제 수준
+	ErrInvalidWriteAck = errors.New(msg.InvalidWriteAck)
+	// ErrReplicationAck : 요청한 복제 수준을 만족하지 못함 (마스터에는 반영됨)
+	ErrReplicationAck = errors.New(msg.ReplicationAckFail)
+)
+
+// keyWriteStripes : 같은 Key 의 쓰기 순서를 맞추는 Lock 수 (해쉬 슬롯 기준으로 나눈다)
+const keyWriteStripes = 256
+
+// keyWriteMutexes : 마스터 반영 ~ recordWrite 를 한 단계로 묶어, 같은 Key 의 쓰기가 레디스에 반영된 순서대로 로그 / Backlog 에 남도록 한다
+var keyWriteMutexes []*sync.Mutex
+
+func init() {
+	if keyWriteMutexes == nil {
+		keyWriteMutexes = make([]*sync.Mutex, keyWriteStripes)
+		for i := range keyWriteMutexes {
+			keyWriteMutexes[i] = &sync.Mutex{}
+		}
+	}
+}
+
+// lockKeyWrite : @key 의 쓰기 Lock, 반환된 함수로 해제 (여러 번 호출해도 한 번만 해제)
+// 마스터에 반영하기 전에 잡고 recordWrite 후 (슬레이브 반영을 기다리기 전) 해제한다
 //
-func (masterClient RedisClient) ReplicateToSlave(command string, key string, value string) {
+func lockKeyWrite(key string) func() {
 
-	tools.InfoLogger.Println(msg.StartReplicaiton)
+	mutex := keyWriteMutexes[hash.GetHashSlotIndex(key)%keyWriteStripes]
+	mutex.Lock()
 
-	// 슬레이브가 죽은 경우, 에러
-	slaveClient, err := masterClient.getSlave()
-	if err != nil {
+	isUnlocked := false
+
+	return func() {
+		if !isUnlocked {
+			isUnlocked = true
+			mutex.Unlock()
+		}
+	}
+}
+
+// ParseWriteAck : 빈 문자열이면 master
+//
+func ParseWriteAck(value string) (WriteAck, error) {
+
+	switch ack := WriteAck(value); ack {
+	case "":
+		return AckMaster, nil
+	case AckMaster, AckReplica, AckAll:
+		return ack, nil
+	default:
+		return "", ErrInvalidWriteAck
+	}
+}
+
+// isSatisfied : 슬레이브 @ackedCount / @slaveCount 개가 반영했을 때 복제 수준을 만족하는지
+//
+func (ack WriteAck) isSatisfied(ackedCount int, slaveCount int) bool {
+
+	switch ack {
+	case AckReplica:
+		return ackedCount >= 1
+	case AckAll:
+		return ackedCount >= 1 && ackedCount == slaveCount
+	default:
+		return true
+	}
+}
+
+// recordWrite : masterClient 인스턴스의 데이터 로그에 명령 기록 후 복제 Backlog 에 추가, 할당된 순번 (Offset) 반환
+// 데이터 로그 기록과 Backlog 추가를 같은 Lock 안에서 처리해, Backlog 항목의 로그 위치가 로그 순서와 같도록 한다
+// 슬레이브 전송은 전송 고루틴이 비동기로 처리한다 (replication_stream.go)
+// native 모드는 순번만 할당하고 전송은 레디스 복제에 맡긴다 (native_replication.go)
+//
+func (masterClient *RedisClient) recordWrite(command string, key string, value string) (uint64, error) {
+
+	if replicationMode == ReplicationModeNative {
+		if err := masterClient.RecordModificationLog(command, key, value); err != nil {
+			return 0, err
+		}
+		return masterClient.recordNativeWrite(command, key, value), nil
+	}
+
+	backlog := backlogOf(masterClient.Address)
+
+	backlog.recordMutex.Lock()
+	if err := masterClient.RecordModificationLog(command, key, value); err != nil {
+		backlog.recordMutex.Unlock()
+		return 0, err
+	}
+	entry := backlog.append(masterClient.Address, command, key, value, currentDataLogPosition(masterClient.Address))
+	backlog.recordMutex.Unlock()
+
+	ensureReplicaSender(masterClient.Address)
+
+	return entry.sequence, nil
+}
+
+// waitForAck : @sequence 번째 쓰기가 @ack 수준을 만족할 때까지 최대 ReplicationAckTimeoutMs 대기
+//
+func (masterClient *RedisClient) waitForAck(sequence uint64, ack WriteAck) error {
+
+	if ack == AckMaster {
+		return nil
+	}
+
+	slaveClients := getSlavesOf(masterClient.Address)
+	slaveCount := len(slaveClients)
+	deadline := time.Now().Add(configs.ReplicationAckTimeoutMs * time.Millisecond)
+
+	for {
+		ackedCount := 0
+		for _, eachSlave := range slaveClients {
+			if getReplicationState(eachSlave.Address).sequence >= sequence {
+				ackedCount++
+			}
+		}
+
+		if ack.isSatisfied(ackedCount, slaveCount) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			tools.ErrorLogger.Printf(msg.ReplicationAckResult, masterClient.Address, ack, ackedCount, slaveCount)
+
+			return fmt.Errorf("%w (ack=%s, %d/%d)", ErrReplicationAck, ack, ackedCount, slaveCount)
+		}
+
+		time.Sleep(sessionWaitInterval)
+	}
+}
+
+// attachSlaves : 승격된 masterClient 인스턴스에 기존 마스터의 나머지 슬레이브 연결
+// stream 모드는 @syncedSequence 까지의 쓰기가 Backlog 에 없으므로, 이보다 뒤처진 슬레이브는 전송 고루틴이 전체 동기화
+//
+func (masterClient *RedisClient) attachSlaves(slaveClients []*RedisClient, syncedSequence uint64) {
+
+	if replicationMode == ReplicationModeNative {
+		for _, eachSlave := range slaveClients {
+			if err := eachSlave.replicaOf(masterClient); err != nil {
+				tools.ErrorLogger.Printf(msg.ReplicaOfFail, eachSlave.Address, masterClient.Address, err.Error())
+			}
+		}
+		ensureReplicaMonitor(masterClient.Address)
 		return
 	}
 
-	// 슬레이브가 살아있는 경우
-	redis.String(slaveClient.Connection.Do(command, key, value))
+	backlogOf(masterClient.Address).markSyncedUntil(syncedSequence)
+	ensureReplicaSender(masterClient.Address)
+}
+
+// replicationProgress : 슬레이브가 반영한 정도 (승격 후보 비교용)
+// native 모드는 슬레이브의 slave_repl_offset (마스터가 죽어도 확인 가능), 확인 실패 시 -1
+//
+func replicationProgress(slaveClient *RedisClient) int64 {
+
+	if replicationMode != ReplicationModeNative {
+		return int64(getReplicationState(slaveClient.Address).sequence)
+	}
 
-	slaveClient.RecordModificationLog(command, key, value)
+	connection, err := slaveClient.getConnection(context.Background())
+	if err != nil {
+		return -1
+	}
+	defer connection.Close()
+
+	info, err := replicationInfo(connection)
+	if err != nil {
+		return -1
+	}
+
+	offset, err := strconv.ParseInt(info["slave_repl_offset"], 10, 64)
+	if err != nil {
+		return -1
+	}
 
-	tools.InfoLogger.Println(msg.EndReplication)
+	return offset
 }
@@ -0,0 +1,479 @@
+package cluster
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hash_interface/configs"
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 비동기 복제 스트림
+ * 마스터에 반영된 쓰기는 마스터 별 Backlog (메모리) 에 순번 (Offset) 과 함께 추가되고,
+ * 슬레이브 별 전송 고루틴이 Backlog 를 읽어 슬레이브에 묶어서 (Pipeline) 전송한다.
+ *   - Offset : 클러스터 전체 쓰기 순번 (writeSequence), 슬레이브의 Acked Offset = 마지막으로 반영을 확인한 순번
+ *   - Backlog 는 항목 수 / 바이트 제한을 넘으면 오래된 항목부터 버린다.
+ *     슬레이브가 버려진 항목을 필요로 하면 마스터 레디스를 SCAN 하여 전체 동기화 (copyDataTo) 후 이어서 전송
+ *   - 전송 고루틴은 슬레이브 별로 하나, (마스터, 슬레이브) 쌍이 바뀌면 종료하고 쓰기가 들어올 때 새 쌍의 고루틴을 시작한다.
+ *   - 슬레이브 연결 에러 시 전용 연결을 닫고 재시도 (복제 지연으로 남는다)
+ * 슬레이브 생존 확인 (모니터 투표) 은 쓰기마다 하지 않는다.
+ */
+
+const (
+	// replicationRetryInterval : 전송 / 전체 동기화 실패 후 재시도 간격
+	replicationRetryInterval = time.Second
+	// replicationIdleCheck : 쓰기가 없을 때 (마스터, 슬레이브) 쌍 변경 확인 주기
+	replicationIdleCheck = time.Second
+)
+
+// replicationEntry : Backlog 의 쓰기 하나
+type replicationEntry struct {
+	sequence  uint64
+	writtenAt time.Time
+	command   string
+	key       string
+	value     string
+	// logPosition : 이 쓰기까지 기록한 마스터 데이터 로그 위치 (슬레이브 부분 재동기화용)
+	logPosition dataLogPosition
+}
+
+func (entry replicationEntry) size() int {
+	return len(entry.command) + len(entry.key) + len(entry.value)
+}
+
+// replicationBacklog : 마스터 하나의 최근 쓰기 (순번 오름차순)
+type replicationBacklog struct {
+	// recordMutex : 마스터 데이터 로그 기록과 Backlog 추가의 순서를 맞춘다 (recordWrite)
+	recordMutex sync.Mutex
+
+	mutex   sync.Mutex
+	entries []replicationEntry
+	bytes   int
+	// evictedSequence : 버려진 마지막 순번, 이 순번 미만까지만 반영한 슬레이브는 전체 동기화
+	evictedSequence uint64
+	// notify : 새 항목이 추가되면 닫고 새로 만든다
+	notify chan struct{}
+}
+
+// replicaSender : (마스터, 슬레이브) 쌍의 전송 고루틴 상태
+type replicaSender struct {
+	masterAddress string
+	slaveClient   *RedisClient
+
+	// connection : 전송 전용 연결 (Connection Pool 과 별개로 유지해 Pool 의 MaxActive 를 차지하지 않는다)
+	connection redis.Conn
+
+	mutex     sync.Mutex
+	lastError string
+}
+
+var replicationBacklogs map[string]*replicationBacklog
+var replicationBacklogMutex *sync.Mutex
+
+// replicaSenders : 슬레이브 주소 -> 전송 고루틴
+var replicaSenders map[string]*replicaSender
+var replicaSenderMutex *sync.Mutex
+
+func init() {
+	if replicationBacklogs == nil {
+		replicationBacklogs = make(map[string]*replicationBacklog)
+	}
+	if replicationBacklogMutex == nil {
+		replicationBacklogMutex = &sync.Mutex{}
+	}
+	if replicaSenders == nil {
+		replicaSenders = make(map[string]*replicaSender)
+	}
+	if replicaSenderMutex == nil {
+		replicaSenderMutex = &sync.Mutex{}
+	}
+}
+
+// backlogOf : @masterAddress 의 Backlog, 없으면 생성
+//
+func backlogOf(masterAddress string) *replicationBacklog {
+
+	replicationBacklogMutex.Lock()
+	defer replicationBacklogMutex.Unlock()
+
+	backlog, isExist := replicationBacklogs[masterAddress]
+	if !isExist {
+		backlog = &replicationBacklog{notify: make(chan struct{})}
+		replicationBacklogs[masterAddress] = backlog
+	}
+
+	return backlog
+}
+
+// removeReplicationBacklog : 제거된 마스터의 Backlog 메모리 해제 (전송 고루틴은 쌍이 바뀌면 스스로 종료)
+//
+func removeReplicationBacklog(masterAddress string) {
+
+	replicationBacklogMutex.Lock()
+	defer replicationBacklogMutex.Unlock()
+
+	delete(replicationBacklogs, masterAddress)
+}
+
+// append : 쓰기 추가, 순번 할당과 마스터 상태 갱신을 Backlog Lock 안에서 처리해 순번 순서를 보장한다
+//
+func (backlog *replicationBacklog) append(masterAddress string, command string, key string, value string, logPosition dataLogPosition) replicationEntry {
+
+	backlog.mutex.Lock()
+	defer backlog.mutex.Unlock()
+
+	entry := replicationEntry{
+		sequence:    atomic.AddUint64(&writeSequence, 1),
+		writtenAt:   time.Now(),
+		command:     command,
+		key:         key,
+		value:       value,
+		logPosition: logPosition,
+	}
+
+	backlog.entries = append(backlog.entries, entry)
+	backlog.bytes += entry.size()
+	backlog.trim()
+
+	setReplicationState(masterAddress, replicationState{
+		sequence:  entry.sequence,
+		writtenAt: entry.writtenAt,
+	})
+
+	close(backlog.notify)
+	backlog.notify = make(chan struct{})
+
+	return entry
+}
+
+// trim : 제한을 넘으면 오래된 항목을 1/10 씩 버린다 (새 슬라이스로 복사해 메모리 반환)
+//
+func (backlog *replicationBacklog) trim() {
+
+	for len(backlog.entries) > configs.ReplicationBacklogEntries || backlog.bytes > configs.ReplicationBacklogBytes {
+
+		dropCount := len(backlog.entries)/10 + 1
+		for _, eachEntry := range backlog.entries[:dropCount] {
+			backlog.bytes -= eachEntry.size()
+		}
+		backlog.evictedSequence = backlog.entries[dropCount-1].sequence
+
+		remainEntries := make([]replicationEntry, len(backlog.entries)-dropCount, configs.ReplicationBacklogEntries+1)
+		copy(remainEntries, backlog.entries[dropCount:])
+		backlog.entries = remainEntries
+	}
+}
+
+// markSyncedUntil : @sequence 까지의 쓰기는 이 Backlog 에 없음을 표시 (승격된 마스터의 Backlog)
+// 이보다 뒤처진 슬레이브는 전체 동기화
+//
+func (backlog *replicationBacklog) markSyncedUntil(sequence uint64) {
+
+	backlog.mutex.Lock()
+	defer backlog.mutex.Unlock()
+
+	if sequence > backlog.evictedSequence {
+		backlog.evictedSequence = sequence
+	}
+}
+
+// since : @ackedSequence 이후 항목 최대 @limit 개
+// 필요한 항목이 이미 버려졌으면 isEvicted = true
+//
+func (backlog *replicationBacklog) since(ackedSequence uint64, limit int) (entries []replicationEntry, notify chan struct{}, isEvicted bool) {
+
+	backlog.mutex.Lock()
+	defer backlog.mutex.Unlock()
+
+	if ackedSequence < backlog.evictedSequence {
+		return nil, backlog.notify, true
+	}
+
+	start := sort.Search(len(backlog.entries), func(i int) bool {
+		return backlog.entries[i].sequence > ackedSequence
+	})
+
+	end := start + limit
+	if end > len(backlog.entries) {
+		end = len(backlog.entries)
+	}
+
+	entries = make([]replicationEntry, end-start)
+	copy(entries, backlog.entries[start:end])
+
+	return entries, backlog.notify, false
+}
+
+// pendingCount : @ackedSequence 이후 항목 수
+//
+func (backlog *replicationBacklog) pendingCount(ackedSequence uint64) int {
+
+	backlog.mutex.Lock()
+	defer backlog.mutex.Unlock()
+
+	start := sort.Search(len(backlog.entries), func(i int) bool {
+		return backlog.entries[i].sequence > ackedSequence
+	})
+
+	return len(backlog.entries) - start
+}
+
+// ensureReplicaSender : @masterAddress 의 슬레이브 중 전송 고루틴이 없는 슬레이브의 고루틴 시작
+//
+func ensureReplicaSender(masterAddress string) {
+
+	slaveClients := getSlavesOf(masterAddress)
+	if len(slaveClients) == 0 {
+		return
+	}
+
+	replicaSenderMutex.Lock()
+	defer replicaSenderMutex.Unlock()
+
+	for _, eachSlave := range slaveClients {
+
+		if sender, isRunning := replicaSenders[eachSlave.Address]; isRunning && sender.masterAddress == masterAddress {
+			continue
+		}
+
+		sender := &replicaSender{
+			masterAddress: masterAddress,
+			slaveClient:   eachSlave,
+		}
+		replicaSenders[eachSlave.Address] = sender
+
+		go sender.run()
+	}
+}
+
+// isCurrent : 아직 (마스터, 슬레이브) 쌍인지
+//
+func (sender *replicaSender) isCurrent() bool {
+	return isSlaveOf(sender.slaveClient.Address, sender.masterAddress)
+}
+
+func (sender *replicaSender) run() {
+
+	slaveAddress := sender.slaveClient.Address
+	backlog := backlogOf(sender.masterAddress)
+
+	tools.InfoLogger.Printf(msg.ReplicaSenderStart, sender.masterAddress, slaveAddress)
+
+	defer func() {
+		if sender.connection != nil {
+			sender.connection.Close()
+		}
+
+		tools.InfoLogger.Printf(msg.ReplicaSenderStop, sender.masterAddress, slaveAddress)
+
+		replicaSenderMutex.Lock()
+		if replicaSenders[slaveAddress] == sender {
+			delete(replicaSenders, slaveAddress)
+		}
+		replicaSenderMutex.Unlock()
+	}()
+
+	for sender.isCurrent() {
+
+		// 전체 동기화 (copyDataTo) 로 상태가 바뀔 수 있으므로 매번 다시 읽는다
+		ackedSequence := getReplicationState(slaveAddress).sequence
+
+		entries, notify, isEvicted := backlog.since(ackedSequence, configs.ReplicationBatchSize)
+
+		if isEvicted {
+			if err := sender.fullSync(); err != nil {
+				sender.fail(err)
+				time.Sleep(replicationRetryInterval)
+			}
+			continue
+		}
+
+		if len(entries) == 0 {
+			select {
+			case <-notify:
+			case <-time.After(replicationIdleCheck):
+			}
+			continue
+		}
+
+		if err := sender.send(entries); err != nil {
+			sender.fail(err)
+			time.Sleep(replicationRetryInterval)
+			continue
+		}
+
+		lastEntry := entries[len(entries)-1]
+		setReplicationState(slaveAddress, replicationState{
+			sequence:  lastEntry.sequence,
+			writtenAt: lastEntry.writtenAt,
+		})
+
+		sender.mutex.Lock()
+		sender.lastError = ""
+		sender.mutex.Unlock()
+	}
+}
+
+// send : @entries 를 한 번에 전송 (Pipeline) 후 응답 확인, 슬레이브 데이터 로그에도 기록
+// 명령 에러 (redis.Error) 는 기록만 하고 다음 항목으로 넘어간다 (연결 에러만 재시도)
+//
+func (sender *replicaSender) send(entries []replicationEntry) error {
+
+	if sender.connection == nil {
+		connection, err := DialRedis(sender.slaveClient.Address)
+		if err != nil {
+			return err
+		}
+		sender.connection = connection
+	}
+
+	for _, eachEntry := range entries {
+		var err error
+		switch eachEntry.command {
+		case "DEL":
+			err = sender.connection.Send(eachEntry.command, eachEntry.key)
+		default:
+			err = sender.connection.Send(eachEntry.command, eachEntry.key, eachEntry.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// 슬레이브가 반영한 마스터 데이터 로그 위치도 같은 Pipeline 으로 기록 (resync.go)
+	lastPosition := entries[len(entries)-1].logPosition
+	if err := sendResyncPosition(sender.connection, lastPosition); err != nil {
+		return err
+	}
+
+	if err := sender.connection.Flush(); err != nil {
+		return err
+	}
+
+	timeout := configs.ReplicationAckTimeoutMs * time.Millisecond
+
+	for _, eachEntry := range entries {
+		_, err := redis.ReceiveWithTimeout(sender.connection, timeout)
+		if _, isCommandError := err.(redis.Error); isCommandError {
+			tools.ErrorLogger.Printf(msg.ReplicateCommandFail, sender.slaveClient.Address, err.Error())
+
+		} else if err != nil {
+			return err
+		}
+
+		sender.slaveClient.RecordModificationLog(eachEntry.command, eachEntry.key, eachEntry.value)
+	}
+
+	return receiveResyncPosition(sender.connection, lastPosition, timeout)
+}
+
+// fullSync : Backlog 에 없는 쓰기가 필요한 슬레이브에 마스터 데이터 복사
+//
+func (sender *replicaSender) fullSync() error {
+
+	masterClient, err := GetMasterWithAddress(sender.masterAddress)
+	if err != nil {
+		return err
+	}
+
+	tools.InfoLogger.Printf(msg.ReplicaFullSync, sender.slaveClient.Address, sender.masterAddress)
+
+	return masterClient.copyDataTo(sender.slaveClient)
+}
+
+// fail : 전송 에러 기록, 연결은 닫고 다음 시도에서 다시 연결
+//
+func (sender *replicaSender) fail(err error) {
+
+	tools.ErrorLogger.Printf(msg.ReplicateCommandFail, sender.slaveClient.Address, err.Error())
+
+	if sender.connection != nil {
+		sender.connection.Close()
+		sender.connection = nil
+	}
+
+	sender.mutex.Lock()
+	sender.lastError = err.Error()
+	sender.mutex.Unlock()
+}
+
+// ReplicaStatus : 슬레이브 복제 현황
+type ReplicaStatus struct {
+	MasterAddress string
+	SlaveAddress  string
+	// MasterOffset : 마스터의 마지막 쓰기 순번, AckedOffset : 슬레이브가 반영을 확인한 마지막 순번
+	MasterOffset uint64
+	AckedOffset  uint64
+	// PendingEntries : Backlog 에서 아직 전송하지 못한 쓰기 수
+	PendingEntries int
+	Lag            time.Duration
+	IsStreaming    bool
+	LastError      string
+
+	// Mode : 복제 모드, native 모드에서만 레디스 INFO replication 의 링크 상태 / Offset 을 채운다
+	Mode             ReplicationMode
+	LinkStatus       string
+	MasterReplOffset int64
+	SlaveReplOffset  int64
+}
+
+// GetReplicationStatus : 모든 (마스터, 슬레이브) 쌍의 복제 현황
+//
+func GetReplicationStatus() []ReplicaStatus {
+
+	statuses := []ReplicaStatus{}
+
+	for _, eachMaster := range getMasterClientList() {
+		for _, slaveClient := range getSlavesOf(eachMaster.Address) {
+			statuses = append(statuses, replicaStatusOf(eachMaster, slaveClient))
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].MasterAddress != statuses[j].MasterAddress {
+			return statuses[i].MasterAddress < statuses[j].MasterAddress
+		}
+		return statuses[i].SlaveAddress < statuses[j].SlaveAddress
+	})
+
+	return statuses
+}
+
+// replicaStatusOf : (@masterClient, @slaveClient) 쌍의 복제 현황
+//
+func replicaStatusOf(masterClient *RedisClient, slaveClient *RedisClient) ReplicaStatus {
+
+	status := ReplicaStatus{
+		MasterAddress: masterClient.Address,
+		SlaveAddress:  slaveClient.Address,
+		MasterOffset:  getReplicationState(masterClient.Address).sequence,
+		AckedOffset:   getReplicationState(slaveClient.Address).sequence,
+		Lag:           ReplicationLag(masterClient.Address, slaveClient.Address),
+		Mode:          replicationMode,
+	}
+
+	if replicationMode == ReplicationModeNative {
+		status.fillNative(masterClient.Address)
+		return status
+	}
+
+	status.PendingEntries = backlogOf(masterClient.Address).pendingCount(status.AckedOffset)
+
+	replicaSenderMutex.Lock()
+	sender, isRunning := replicaSenders[slaveClient.Address]
+	replicaSenderMutex.Unlock()
+
+	if isRunning && sender.masterAddress == masterClient.Address {
+		status.IsStreaming = true
+		sender.mutex.Lock()
+		status.LastError = sender.lastError
+		sender.mutex.Unlock()
+	}
+
+	return status
+}
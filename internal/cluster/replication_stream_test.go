@@ -0,0 +1,36 @@
+package cluster
+
+import (
+	"testing"
+
+	"hash_interface/configs"
+)
+
+func TestBacklogEviction(t *testing.T) {
+
+	masterAddress := "10.0.1.1:8000"
+	backlog := backlogOf(masterAddress)
+
+	firstEntry := backlog.append(masterAddress, "SET", "k", "v", dataLogPosition{})
+	for i := 0; i < configs.ReplicationBacklogEntries; i++ {
+		backlog.append(masterAddress, "SET", "k", "v", dataLogPosition{})
+	}
+
+	if len(backlog.entries) > configs.ReplicationBacklogEntries {
+		t.Fatalf("backlog must be bounded : %d entries", len(backlog.entries))
+	}
+
+	// 버려진 항목이 필요한 슬레이브는 전체 동기화
+	if _, _, isEvicted := backlog.since(firstEntry.sequence-1, 1); !isEvicted {
+		t.Fatal("expected eviction")
+	}
+
+	entries, _, isEvicted := backlog.since(backlog.evictedSequence, configs.ReplicationBatchSize)
+	if isEvicted || len(entries) != configs.ReplicationBatchSize || entries[0].sequence <= backlog.evictedSequence {
+		t.Fatalf("unexpected batch : %d entries, evicted %v", len(entries), isEvicted)
+	}
+
+	if pending := backlog.pendingCount(entries[len(entries)-1].sequence); pending != len(backlog.entries)-len(entries) {
+		t.Fatalf("unexpected pending count : %d", pending)
+	}
+}
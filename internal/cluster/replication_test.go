@@ -0,0 +1,71 @@
+package cluster
+
+import "testing"
+
+func TestWriteAckSatisfied(t *testing.T) {
+
+	testCases := []struct {
+		ack         WriteAck
+		ackedCount  int
+		slaveCount  int
+		isSatisfied bool
+	}{
+		{AckMaster, 0, 1, true},
+		{AckMaster, 0, 0, true},
+		{AckReplica, 1, 1, true},
+		{AckReplica, 0, 1, false},
+		{AckReplica, 0, 0, false},
+		{AckAll, 1, 1, true},
+		{AckAll, 1, 2, false},
+		{AckAll, 0, 0, false},
+	}
+
+	for _, eachCase := range testCases {
+		if isSatisfied := eachCase.ack.isSatisfied(eachCase.ackedCount, eachCase.slaveCount); isSatisfied != eachCase.isSatisfied {
+			t.Errorf("ack=%s %d/%d : expected %v", eachCase.ack, eachCase.ackedCount, eachCase.slaveCount, eachCase.isSatisfied)
+		}
+	}
+
+	if _, err := ParseWriteAck("quorum"); err != ErrInvalidWriteAck {
+		t.Fatalf("expected ErrInvalidWriteAck, got %v", err)
+	}
+}
+
+func TestMultipleSlaves(t *testing.T) {
+
+	masterClient := &RedisClient{Address: "10.0.2.1:8000", Role: MasterRole}
+	firstSlave := &RedisClient{Address: "10.0.2.2:8000", Role: SlaveRole}
+	secondSlave := &RedisClient{Address: "10.0.2.3:8000", Role: SlaveRole}
+
+	initMasterSlaveMaps(masterClient, firstSlave)
+	initMasterSlaveMaps(masterClient, secondSlave)
+	// 같은 슬레이브를 다시 연결하면 교체
+	initMasterSlaveMaps(masterClient, firstSlave)
+
+	defer func() {
+		topologyMutex.Lock()
+		delete(masterSlaveMap, masterClient.Address)
+		delete(slaveMasterMap, firstSlave.Address)
+		delete(slaveMasterMap, secondSlave.Address)
+		delete(redisMutexMap, masterClient.Address)
+		delete(redisMutexMap, firstSlave.Address)
+		delete(redisMutexMap, secondSlave.Address)
+		topologyMutex.Unlock()
+	}()
+
+	if slaveCount := len(getSlavesOf(masterClient.Address)); slaveCount != 2 {
+		t.Fatalf("expected 2 slaves, got %d", slaveCount)
+	}
+	if !isSlaveOf(secondSlave.Address, masterClient.Address) || isSlaveOf(masterClient.Address, secondSlave.Address) {
+		t.Fatal("unexpected slave mapping")
+	}
+
+	// 가장 최근 쓰기까지 반영한 슬레이브가 먼저 승격 후보
+	setReplicationState(firstSlave.Address, replicationState{sequence: 3})
+	setReplicationState(secondSlave.Address, replicationState{sequence: 9})
+
+	rankedSlaves := rankSlavesForPromotion(getSlavesOf(masterClient.Address))
+	if rankedSlaves[0].Address != secondSlave.Address || rankedSlaves[1].Address != firstSlave.Address {
+		t.Fatalf("unexpected promotion order : %s, %s", rankedSlaves[0].Address, rankedSlaves[1].Address)
+	}
+}
@@ -0,0 +1,203 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	msg "hash_interface/internal/cluster/message"
+	"hash_interface/internal/namespace"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 슬레이브 부분 재동기화 (stream 모드)
+ * 슬레이브에 반영한 마스터 데이터 로그 위치 (로그 ID, 줄 수) 를 슬레이브 레디스의 resyncPositionKey 에 함께 기록한다.
+ *   - 복제 스트림 : 쓰기 묶음과 같은 Pipeline 으로 마지막 항목의 로그 위치 기록
+ *   - 전체 복사 (copyDataTo) : 복사를 시작한 시점의 로그 위치 기록
+ * 슬레이브가 돌아오면 (connectToMaster) 기록된 위치 이후의 마스터 데이터 로그만 다시 적용한다.
+ * 위치가 없거나 (슬레이브 데이터 유실), 마스터 로그가 새로 만들어졌거나 (재분배, 승격), 로그가 더 짧으면 전체 복사
+ * 위치는 데이터보다 나중에 기록되므로, 슬레이브가 중간까지만 저장했어도 실제 반영보다 앞선 위치가 남지 않는다.
+ * native 모드는 레디스 복제 (PSYNC) 가 부분 재동기화를 처리한다.
+ */
+
+// resyncPositionKey : 슬레이브 레디스에 기록하는 마스터 데이터 로그 위치 Key, 승격 시 삭제
+// 예약된 Prefix 를 사용하므로 사용자 Key 와 겹치지 않는다 (namespace.InternalName)
+const resyncPositionKey = namespace.InternalName + namespace.Separator + "resync_position"
+
+// errResyncPositionNotFound : 슬레이브에 로그 위치가 없음
+var errResyncPositionNotFound = errors.New(msg.ResyncPositionNotFound)
+
+// String : "<로그 ID>:<줄 수>"
+//
+func (position dataLogPosition) String() string {
+	return strconv.FormatUint(position.logID, 10) + ":" + strconv.FormatInt(position.line, 10)
+}
+
+// parseDataLogPosition : String() 형식 파싱
+//
+func parseDataLogPosition(value string) (dataLogPosition, error) {
+
+	separatorIndex := strings.Index(value, ":")
+	if separatorIndex <= 0 {
+		return dataLogPosition{}, fmt.Errorf(msg.InvalidResyncPosition, value)
+	}
+
+	logID, err := strconv.ParseUint(value[:separatorIndex], 10, 64)
+	if err != nil {
+		return dataLogPosition{}, fmt.Errorf(msg.InvalidResyncPosition, value)
+	}
+
+	line, err := strconv.ParseInt(value[separatorIndex+1:], 10, 64)
+	if err != nil || line < 0 {
+		return dataLogPosition{}, fmt.Errorf(msg.InvalidResyncPosition, value)
+	}
+
+	return dataLogPosition{logID: logID, line: line}, nil
+}
+
+// isTailOf : @current 로그에서 position 이후 부분을 다시 읽을 수 있는지
+//
+func (position dataLogPosition) isTailOf(current dataLogPosition) bool {
+	return position.logID != 0 && position.logID == current.logID && position.line <= current.line
+}
+
+// sendResyncPosition : Pipeline 에 로그 위치 기록 명령 추가 (이 서버가 만든 로그가 아니면 생략)
+//
+func sendResyncPosition(connection redis.Conn, position dataLogPosition) error {
+
+	if position.logID == 0 {
+		return nil
+	}
+
+	return connection.Send("SET", resyncPositionKey, position.String())
+}
+
+// receiveResyncPosition : sendResyncPosition 의 응답 확인
+//
+func receiveResyncPosition(connection redis.Conn, position dataLogPosition, timeout time.Duration) error {
+
+	if position.logID == 0 {
+		return nil
+	}
+
+	_, err := redis.ReceiveWithTimeout(connection, timeout)
+
+	return err
+}
+
+// getResyncPosition : redisClient 인스턴스 (슬레이브) 에 기록된 마스터 데이터 로그 위치
+//
+func (redisClient *RedisClient) getResyncPosition() (dataLogPosition, error) {
+
+	value, err := redis.String(redisClient.Do("GET", resyncPositionKey))
+	if err == redis.ErrNil {
+		return dataLogPosition{}, errResyncPositionNotFound
+
+	} else if err != nil {
+		return dataLogPosition{}, err
+	}
+
+	return parseDataLogPosition(value)
+}
+
+// setResyncPosition : redisClient 인스턴스 (슬레이브) 에 마스터 데이터 로그 위치 기록
+//
+func (redisClient *RedisClient) setResyncPosition(position dataLogPosition) error {
+
+	if position.logID == 0 {
+		return nil
+	}
+
+	_, err := redisClient.Do("SET", resyncPositionKey, position.String())
+
+	return err
+}
+
+// clearResyncPosition : 마스터로 승격된 노드의 로그 위치 삭제
+//
+func (redisClient *RedisClient) clearResyncPosition() error {
+
+	_, err := redisClient.Do("DEL", resyncPositionKey)
+
+	return err
+}
+
+// replicationSnapshot : masterClient 인스턴스의 마지막 쓰기 순번과 데이터 로그 위치
+// recordWrite 와 같은 Lock 으로 읽어 두 값이 같은 쓰기를 가리킨다
+//
+func (masterClient *RedisClient) replicationSnapshot() (replicationState, dataLogPosition) {
+
+	backlog := backlogOf(masterClient.Address)
+
+	backlog.recordMutex.Lock()
+	defer backlog.recordMutex.Unlock()
+
+	return getReplicationState(masterClient.Address), currentDataLogPosition(masterClient.Address)
+}
+
+// resyncReplica : 돌아온 @slaveClient 에 마스터 데이터 로그에서 빠진 부분만 다시 적용
+// 빠진 부분을 읽을 수 없으면 슬레이브 데이터 로그를 새로 만들고 전체 복사 (copyDataTo)
+//
+func (masterClient *RedisClient) resyncReplica(slaveClient *RedisClient) error {
+
+	appliedPosition, err := slaveClient.getResyncPosition()
+	snapshotState, snapshotPosition := masterClient.replicationSnapshot()
+
+	if err == nil && appliedPosition.isTailOf(snapshotPosition) {
+
+		err = masterClient.replayDataLogTo(slaveClient, appliedPosition, snapshotPosition)
+		if err == nil {
+			setReplicationState(slaveClient.Address, snapshotState)
+			return nil
+		}
+	}
+
+	if err == nil {
+		err = fmt.Errorf(msg.ResyncTailUnavailable, appliedPosition, snapshotPosition)
+	}
+	tools.InfoLogger.Printf(msg.FullResyncStart, slaveClient.Address, masterClient.Address, err.Error())
+
+	// 슬레이브 데이터 로그와 반영 순번을 처음부터 다시 만든다
+	slaveClient.removeDataLogFile()
+	if err := createDataLogFile(slaveClient.Address); err != nil {
+		return err
+	}
+	resetReplicationState(slaveClient.Address)
+
+	return masterClient.copyDataTo(slaveClient)
+}
+
+// replayDataLogTo : masterClient 데이터 로그의 (@from, @to] 부분을 @slaveClient 에 적용, 슬레이브 데이터 로그에도 기록
+//
+func (masterClient *RedisClient) replayDataLogTo(slaveClient *RedisClient, from dataLogPosition, to dataLogPosition) error {
+
+	tailLogs, err := readDataLogLines(masterClient.Address, from.line, to.line)
+	if err != nil {
+		return err
+	}
+
+	tools.InfoLogger.Printf(msg.PartialResyncStart, slaveClient.Address, masterClient.Address, len(tailLogs), from.line)
+
+	for _, eachLog := range tailLogs {
+
+		switch eachLog.Command {
+		case "DEL":
+			_, err = slaveClient.Do(eachLog.Command, eachLog.Key)
+		default:
+			_, err = slaveClient.Do(eachLog.Command, eachLog.Key, eachLog.Value)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := slaveClient.RecordModificationLog(eachLog.Command, eachLog.Key, eachLog.Value); err != nil {
+			tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
+		}
+	}
+
+	return slaveClient.setResyncPosition(to)
+}
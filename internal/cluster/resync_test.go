@@ -0,0 +1,86 @@
+package cluster
+
+import "testing"
+
+func TestResyncPosition(t *testing.T) {
+
+	position := dataLogPosition{logID: 1760000000123456789, line: 42}
+
+	parsedPosition, err := parseDataLogPosition(position.String())
+	if err != nil || parsedPosition != position {
+		t.Fatalf("unexpected position : %v (%v)", parsedPosition, err)
+	}
+
+	for _, invalidValue := range []string{"", "42", ":42", "abc:42", "1:-1", "1:abc"} {
+		if _, err := parseDataLogPosition(invalidValue); err == nil {
+			t.Errorf("%q must be invalid", invalidValue)
+		}
+	}
+
+	testCases := []struct {
+		current  dataLogPosition
+		isTailOf bool
+	}{
+		{dataLogPosition{logID: position.logID, line: 42}, true},
+		{dataLogPosition{logID: position.logID, line: 100}, true},
+		// 로그가 더 짧음
+		{dataLogPosition{logID: position.logID, line: 41}, false},
+		// 로그가 새로 만들어짐 (재분배, 승격)
+		{dataLogPosition{logID: position.logID + 1, line: 100}, false},
+	}
+
+	for _, eachCase := range testCases {
+		if isTailOf := position.isTailOf(eachCase.current); isTailOf != eachCase.isTailOf {
+			t.Errorf("%v in %v : expected %v", position, eachCase.current, eachCase.isTailOf)
+		}
+	}
+
+	if (dataLogPosition{}).isTailOf(dataLogPosition{}) {
+		t.Fatal("position of unknown log must not be resumable")
+	}
+}
+
+func TestResyncDataLogTail(t *testing.T) {
+
+	defer setUpTestDataLogDirectory(t)()
+
+	masterClient := &RedisClient{Address: "10.0.6.3:8000"}
+	if err := createDataLogFile(masterClient.Address); err != nil {
+		t.Fatal(err)
+	}
+	defer masterClient.removeDataLogFile()
+
+	records := []logFormat{
+		{KeyValuePair{"k1", "before"}, "SET"},
+		{KeyValuePair{"k2", "line1\nline2"}, "SET"},
+		{KeyValuePair{"k 3", "a   b  "}, "SET"},
+		{KeyValuePair{"k4", ""}, "SET"},
+		{KeyValuePair{"k1", deletedValue}, "DEL"},
+	}
+
+	for _, eachRecord := range records {
+		if err := masterClient.RecordModificationLog(eachRecord.Command, eachRecord.Key, eachRecord.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 기록 수와 로그의 줄 수가 같아야 위치로 이어서 읽을 수 있다
+	position := currentDataLogPosition(masterClient.Address)
+	if position.line != int64(len(records)) {
+		t.Fatalf("expected %d records, got %d", len(records), position.line)
+	}
+
+	tailLogs, err := readDataLogLines(masterClient.Address, 1, position.line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tailLogs) != len(records)-1 {
+		t.Fatalf("expected %d tail records, got %d", len(records)-1, len(tailLogs))
+	}
+	for i, eachLog := range tailLogs {
+		if eachLog != records[i+1] {
+			t.Errorf("record %d : expected %+v, got %+v", i+2, records[i+1], eachLog)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	msg "hash_interface/internal/cluster/message"
+)
+
+/* 세션 토큰 (Read-your-writes)
+ * 쓰기 응답으로 (마스터 주소, 쓰기 순번) 목록을 돌려주고, 조회 요청에 다시 받는다.
+ * 슬레이브 조회 시 Key 의 마스터에 대해 토큰의 순번 이상을 반영한 슬레이브만 사용하고,
+ * 잠시 기다려도 따라잡지 못하면 마스터에서 조회한다. (replica-only 는 ErrReplicaUnavailable)
+ *
+ * 형식 : base64url("<master address>=<sequence>;...") , 클라이언트는 내용을 해석하지 않고 그대로 전달한다.
+ * 쓰기 순번은 클러스터 전체 순번이므로 토큰의 순번은 해당 쓰기 이후의 다른 쓰기를 포함할 수 있다 (더 엄격한 조건).
+ */
+
+// sessionWaitInterval : 슬레이브가 토큰의 순번을 따라잡는지 확인하는 주기
+const sessionWaitInterval = 5 * time.Millisecond
+
+// ErrInvalidSessionToken : 세션 토큰 형식 오류
+var ErrInvalidSessionToken = errors.New(msg.InvalidSessionToken)
+
+// SessionToken : 마스터 주소 별 마지막 쓰기 순번
+type SessionToken map[string]uint64
+
+// ParseSessionToken : 빈 문자열이면 빈 토큰
+//
+func ParseSessionToken(value string) (SessionToken, error) {
+
+	token := SessionToken{}
+	if value == "" {
+		return token, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidSessionToken
+	}
+
+	for _, eachEntry := range strings.Split(string(decoded), ";") {
+
+		separatorIndex := strings.LastIndex(eachEntry, "=")
+		if separatorIndex <= 0 {
+			return nil, ErrInvalidSessionToken
+		}
+
+		sequence, err := strconv.ParseUint(eachEntry[separatorIndex+1:], 10, 64)
+		if err != nil {
+			return nil, ErrInvalidSessionToken
+		}
+
+		token.observe(eachEntry[:separatorIndex], sequence)
+	}
+
+	return token, nil
+}
+
+// String : 응답 헤더에 담을 토큰 문자열, 비어있으면 빈 문자열
+//
+func (token SessionToken) String() string {
+
+	if len(token) == 0 {
+		return ""
+	}
+
+	entries := make([]string, 0, len(token))
+	for address, sequence := range token {
+		entries = append(entries, address+"="+strconv.FormatUint(sequence, 10))
+	}
+	sort.Strings(entries)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(entries, ";")))
+}
+
+// ObserveWrite : @redisClient (마스터) 에 반영된 쓰기를 토큰에 추가
+//
+func (token SessionToken) ObserveWrite(redisClient *RedisClient) {
+
+	if redisClient == nil {
+		return
+	}
+
+	token.observe(redisClient.Address, getReplicationState(redisClient.Address).sequence)
+}
+
+func (token SessionToken) observe(address string, sequence uint64) {
+
+	if sequence > token[address] {
+		token[address] = sequence
+	}
+}
+
+// waitForSequence : @slaveAddress 가 @sequence 까지 반영할 때까지 최대 @timeout 대기
+//
+func waitForSequence(slaveAddress string, sequence uint64, timeout time.Duration) bool {
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if getReplicationState(slaveAddress).sequence >= sequence {
+			return true
+		}
+
+		if time.Now().After(deadline) {
+			return false
+		}
+
+		time.Sleep(sessionWaitInterval)
+	}
+}
@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionTokenRoundTrip(t *testing.T) {
+
+	token := SessionToken{}
+	token.observe("172.29.0.4:8000", 7)
+	token.observe("172.29.0.4:8000", 3)
+	token.observe("172.29.0.5:8001", 12)
+
+	parsedToken, err := ParseSessionToken(token.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsedToken) != 2 || parsedToken["172.29.0.4:8000"] != 7 || parsedToken["172.29.0.5:8001"] != 12 {
+		t.Fatalf("unexpected token : %v", parsedToken)
+	}
+
+	if _, err := ParseSessionToken("not a token"); err != ErrInvalidSessionToken {
+		t.Fatalf("expected ErrInvalidSessionToken, got %v", err)
+	}
+}
+
+func TestReplicaCatchUp(t *testing.T) {
+
+	masterAddress, slaveAddress := "10.0.0.1:8000", "10.0.0.2:8000"
+	backlog := backlogOf(masterAddress)
+
+	firstEntry := backlog.append(masterAddress, "SET", "a", "1", dataLogPosition{})
+	setReplicationState(slaveAddress, replicationState{sequence: firstEntry.sequence, writtenAt: firstEntry.writtenAt})
+	lastEntry := backlog.append(masterAddress, "SET", "b", "2", dataLogPosition{})
+
+	token := SessionToken{}
+	token.ObserveWrite(&RedisClient{Address: masterAddress})
+
+	if token[masterAddress] != lastEntry.sequence || waitForSequence(slaveAddress, token[masterAddress], time.Millisecond) {
+		t.Fatal("slave must not have applied the last write")
+	}
+
+	if entries, _, isEvicted := backlog.since(firstEntry.sequence, 10); isEvicted || len(entries) != 1 || entries[0].key != "b" {
+		t.Fatalf("unexpected pending entries : %v, evicted %v", entries, isEvicted)
+	}
+
+	setReplicationState(slaveAddress, replicationState{sequence: lastEntry.sequence, writtenAt: lastEntry.writtenAt})
+
+	if ReplicationLag(masterAddress, slaveAddress) != 0 || !waitForSequence(slaveAddress, token[masterAddress], 0) {
+		t.Fatal("slave must have caught up")
+	}
+}
@@ -0,0 +1,202 @@
+package cluster
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hash_interface/internal/hash"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 클러스터 토폴로지 조회 (RESP CLUSTER SLOTS / NODES / SHARDS 용)
+ * 해쉬 슬롯 맵을 처음부터 끝까지 훑어 같은 마스터가 연속으로 담당하는 구간을 하나의 SlotRange 로 묶는다.
+ * 마스터 추가 (distributeTo -> reshardData) 중 데이터가 아직 옮겨지지 않은 슬롯은
+ * 이동이 끝날 때까지 기존 마스터 (Source) 소유로 보고한다.
+ *
+ * 토폴로지 Epoch : 슬롯 할당 / 마스터 슬레이브 구성이 바뀔 때마다 증가,
+ * 클라이언트 / 모니터는 캐시한 슬롯 맵의 Epoch 와 비교해 오래된 라우팅 정보를 감지한다.
+ */
+
+// SlotRange : [Start, End] (End 포함) 구간을 담당하는 마스터와 슬레이브
+type SlotRange struct {
+	Start  uint16
+	End    uint16
+	Master RedisClient
+	Slaves []RedisClient
+}
+
+// SlotMigration : 슬롯 데이터 이동 중인 마스터 주소
+type SlotMigration struct {
+	SourceAddress string
+	TargetAddress string
+}
+
+type slotMigrationRange struct {
+	start uint16
+	// end : 포함하지 않음 (hashSlot.assign 과 동일)
+	end       uint16
+	migration SlotMigration
+}
+
+// slotMigrations : 현재 데이터 이동 중인 슬롯 구간
+var slotMigrations []slotMigrationRange
+var slotMigrationMutex *sync.RWMutex
+
+// topologyEpoch : 서버 시작 시각 (ms) 부터 시작, 재시작해도 이전 값보다 작아지지 않는다
+var topologyEpoch uint64
+
+func init() {
+	if slotMigrationMutex == nil {
+		slotMigrationMutex = &sync.RWMutex{}
+	}
+	if topologyEpoch == 0 {
+		topologyEpoch = uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	}
+}
+
+// TopologyEpoch : 현재 토폴로지 Epoch
+//
+func TopologyEpoch() uint64 {
+	return atomic.LoadUint64(&topologyEpoch)
+}
+
+// bumpTopologyEpoch : 슬롯 할당 / 마스터 슬레이브 구성 변경 후 호출
+//
+func bumpTopologyEpoch() {
+	atomic.AddUint64(&topologyEpoch, 1)
+}
+
+// GetSlotRangesWithEpoch : 같은 Epoch 에서 조회한 슬롯 구간 (조회 중 토폴로지가 바뀌면 다시 조회)
+//
+func GetSlotRangesWithEpoch() ([]SlotRange, uint64) {
+
+	for {
+		epoch := TopologyEpoch()
+		slotRanges := GetSlotRanges()
+
+		if epoch == TopologyEpoch() {
+			return slotRanges, epoch
+		}
+	}
+}
+
+// NodeID : 레디스 클러스터 노드 ID 형식 (40자 hex), 주소로부터 항상 같은 값 생성
+//
+func NodeID(address string) string {
+	sum := sha1.Sum([]byte(address))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetSlotRanges : 현재 해쉬 슬롯 맵의 구간별 담당 마스터 (슬롯 순)
+//
+func GetSlotRanges() []SlotRange {
+
+	slotRanges := []SlotRange{}
+
+	var currentAddress string
+
+	for slot := 0; slot < hash.HashSlotsNumber; slot++ {
+
+		owner, _ := GetSlotOwner(uint16(slot))
+		if owner == nil {
+			currentAddress = ""
+			continue
+		}
+
+		if owner.Address == currentAddress {
+			slotRanges[len(slotRanges)-1].End = uint16(slot)
+			continue
+		}
+
+		currentAddress = owner.Address
+
+		newSlotRange := SlotRange{
+			Start:  uint16(slot),
+			End:    uint16(slot),
+			Master: owner.snapshot(),
+			Slaves: []RedisClient{},
+		}
+
+		for _, eachSlave := range getSlavesOf(owner.Address) {
+			newSlotRange.Slaves = append(newSlotRange.Slaves, eachSlave.snapshot())
+		}
+
+		slotRanges = append(slotRanges, newSlotRange)
+	}
+
+	return slotRanges
+}
+
+// GetSlotOwner : @slot 을 담당하는 마스터, 데이터 이동 중이면 이동 정보도 함께 반환
+// 이동 중인 슬롯은 이동이 끝날 때까지 Source 마스터가 담당한다
+//
+func GetSlotOwner(slot uint16) (*RedisClient, *SlotMigration) {
+
+	slotMigrationMutex.RLock()
+	defer slotMigrationMutex.RUnlock()
+
+	for _, eachRange := range slotMigrations {
+		if eachRange.start <= slot && slot < eachRange.end {
+
+			migration := eachRange.migration
+			for _, eachMaster := range getMasterClientList() {
+				if eachMaster.Address == migration.SourceAddress {
+					return eachMaster, &migration
+				}
+			}
+		}
+	}
+
+	return hashSlot.get(slot), nil
+}
+
+// IsKeyMigrated : 이동 중인 슬롯의 @key 가 이미 Source 마스터에서 옮겨졌는지 확인
+// (레디스 클러스터의 ASK 판단과 동일 : Source 에 없으면 Target 에 요청)
+//
+func (migration SlotMigration) IsKeyMigrated(key string) (bool, error) {
+
+	sourceClient, err := GetMasterWithAddress(migration.SourceAddress)
+	if err != nil {
+		return true, nil
+	}
+
+	isExist, err := redis.Bool(sourceClient.Do("EXISTS", key))
+	if err != nil {
+		return false, err
+	}
+
+	return !isExist, nil
+}
+
+// startSlotMigration : [@start, @end) 슬롯의 데이터를 @source 에서 @target 으로 옮기기 시작
+//
+func startSlotMigration(start uint16, end uint16, source *RedisClient, target *RedisClient) {
+
+	slotMigrationMutex.Lock()
+	defer slotMigrationMutex.Unlock()
+
+	slotMigrations = append(slotMigrations, slotMigrationRange{
+		start: start,
+		end:   end,
+		migration: SlotMigration{
+			SourceAddress: source.Address,
+			TargetAddress: target.Address,
+		},
+	})
+}
+
+// finishSlotMigrations : 데이터 이동 완료, 이후 해쉬 슬롯 맵의 마스터가 담당
+//
+func finishSlotMigrations() {
+
+	slotMigrationMutex.Lock()
+	defer slotMigrationMutex.Unlock()
+
+	slotMigrations = nil
+
+	bumpTopologyEpoch()
+}
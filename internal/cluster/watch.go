@@ -0,0 +1,87 @@
+package cluster
+
+import (
+	"sync"
+)
+
+/* 데이터 변경 알림 (gRPC Watch 등)
+ * 이 인터페이스 서버를 거쳐 마스터에 반영되고 데이터 로그에 기록된 변경만 전달된다.
+ */
+
+// watchBufferSize : 구독자 별 대기 이벤트 수, 가득 차면 해당 구독을 끊는다 (느린 구독자가 저장을 막지 않도록)
+const watchBufferSize = 256
+
+// DataEvent : 마스터에 반영된 변경 사항
+type DataEvent struct {
+	// Command : "SET" / "DEL"
+	Command string
+	Key     string
+	// Value : DEL 은 빈 문자열
+	Value       string
+	NodeAddress string
+}
+
+// Subscription : Events 채널이 닫히면 구독이 끝난 것 (Unsubscribe 또는 처리 지연)
+type Subscription struct {
+	Events chan DataEvent
+}
+
+// subscriptions : 현재 구독 목록
+var subscriptions map[*Subscription]struct{}
+var subscriptionMutex *sync.Mutex
+
+func init() {
+	if subscriptions == nil {
+		subscriptions = make(map[*Subscription]struct{})
+	}
+	if subscriptionMutex == nil {
+		subscriptionMutex = &sync.Mutex{}
+	}
+}
+
+// Subscribe : 이후 발생하는 모든 데이터 변경 구독, 끝나면 반드시 Unsubscribe()
+//
+func Subscribe() *Subscription {
+
+	subscription := &Subscription{
+		Events: make(chan DataEvent, watchBufferSize),
+	}
+
+	subscriptionMutex.Lock()
+	subscriptions[subscription] = struct{}{}
+	subscriptionMutex.Unlock()
+
+	return subscription
+}
+
+// Unsubscribe : 구독 해제 & Events 채널 닫기, 여러 번 호출해도 된다
+//
+func (subscription *Subscription) Unsubscribe() {
+
+	subscriptionMutex.Lock()
+	defer subscriptionMutex.Unlock()
+
+	if _, isExist := subscriptions[subscription]; !isExist {
+		return
+	}
+
+	delete(subscriptions, subscription)
+	close(subscription.Events)
+}
+
+// publishDataEvent : 모든 구독자에게 전달, 대기 이벤트가 가득 찬 구독은 끊는다
+//
+func publishDataEvent(event DataEvent) {
+
+	subscriptionMutex.Lock()
+	defer subscriptionMutex.Unlock()
+
+	for eachSubscription := range subscriptions {
+		select {
+		case eachSubscription.Events <- event:
+		default:
+			delete(subscriptions, eachSubscription)
+			close(eachSubscription.Events)
+		}
+	}
+}
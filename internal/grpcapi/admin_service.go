@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/grpcapi/pb"
+	"hash_interface/internal/models/response"
+	"hash_interface/tools"
+
+	"google.golang.org/grpc/codes"
+)
+
+// adminService : pb.HashAdminServer, HTTP /clients 핸들러와 동일
+type adminService struct{}
+
+// AddClient : handlers.AddNewClient 와 같은 검증 / 에러 코드
+//
+func (service adminService) AddClient(ctx context.Context, req *pb.AddClientRequest) (*pb.ClientsResponse, error) {
+
+	if req.Address == "" || req.Role == "" {
+		err := fmt.Errorf("AddClient() : address, role 이 비어있습니다")
+		return nil, statusError(ctx, codes.InvalidArgument, response.CodeInvalidBody, err)
+	}
+
+	switch req.Role {
+	case cluster.MasterRole:
+		if err := cluster.AddNewMaster(req.Address); err != nil {
+			return nil, statusError(ctx, codes.Internal, response.CodeClientSetup, err)
+		}
+
+	case cluster.SlaveRole:
+		if req.MasterAddress == "" {
+			err := fmt.Errorf("마스터 주소 없음")
+			return nil, statusError(ctx, codes.InvalidArgument, response.CodeInvalidBody, err)
+		}
+
+		targetMaster, err := cluster.GetMasterWithAddress(req.MasterAddress)
+		if err != nil {
+			return nil, statusError(ctx, codes.InvalidArgument, response.CodeInvalidRequest, err)
+		}
+
+		if err := cluster.AddNewSlave(req.Address, targetMaster); err != nil {
+			tools.ErrorLogger.Printf("AddClient() : 슬레이브 추가 에러 - %s", err.Error())
+			return nil, statusError(ctx, codes.Internal, response.CodeClientSetup, err)
+		}
+
+	case cluster.StandbyRole:
+		if err := cluster.AddNewStandby(req.Address); err != nil {
+			tools.ErrorLogger.Printf("AddClient() : 대기 노드 추가 에러 - %s", err.Error())
+			return nil, statusError(ctx, codes.Internal, response.CodeClientSetup, err)
+		}
+
+	default:
+		err := fmt.Errorf("AddClient() : 지원하지 않는 %s role", req.Role)
+		return nil, statusError(ctx, codes.InvalidArgument, response.CodeInvalidBody, err)
+	}
+
+	return currentClients(), nil
+}
+
+func (service adminService) GetClients(ctx context.Context, req *pb.GetClientsRequest) (*pb.ClientsResponse, error) {
+	return currentClients(), nil
+}
+
+func currentClients() *pb.ClientsResponse {
+
+	clientsResponse := &pb.ClientsResponse{}
+
+	for _, eachMaster := range cluster.GetMasterClients() {
+		clientsResponse.Masters = append(clientsResponse.Masters, &pb.RedisNode{
+			Address: eachMaster.Address,
+			Role:    eachMaster.Role,
+		})
+	}
+
+	for _, eachSlave := range cluster.GetSlaveClients() {
+		clientsResponse.Slaves = append(clientsResponse.Slaves, &pb.RedisNode{
+			Address: eachSlave.Address,
+			Role:    eachSlave.Role,
+		})
+	}
+
+	return clientsResponse
+}
@@ -0,0 +1,277 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/grpcapi/pb"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/namespace"
+	"hash_interface/tools"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// dataService : pb.HashDataServer, HTTP 데이터 핸들러와 같은 cluster / namespace 경로 사용
+type dataService struct{}
+
+// WriteAckMetadata : 쓰기 복제 수준 (HTTP X-Write-Ack 와 동일), 없으면 네임스페이스 설정
+const WriteAckMetadata = "write-ack"
+
+var errEmptyKey = fmt.Errorf("Key 가 비어있습니다")
+
+// resolveStoredKey : @namespaceName 기준 레디스에 저장되는 Key
+//
+func resolveStoredKey(ctx context.Context, namespaceName string, key string) (*namespace.Namespace, string, error) {
+
+	if key == "" {
+		return nil, "", statusError(ctx, codes.InvalidArgument, response.CodeInvalidKey, errEmptyKey)
+	}
+
+	targetNamespace, err := namespace.Get(namespaceName)
+	if err != nil {
+		return nil, "", namespaceError(ctx, err)
+	}
+
+	storedKey, err := targetNamespace.PrefixKey(key)
+	if err != nil {
+		return nil, "", namespaceError(ctx, err)
+	}
+
+	return targetNamespace, storedKey, nil
+}
+
+// writeAckOf : 요청 Metadata 의 쓰기 복제 수준, 없으면 @targetNamespace 의 기본값
+//
+func writeAckOf(ctx context.Context, targetNamespace *namespace.Namespace) (cluster.WriteAck, error) {
+
+	writeAck := targetNamespace.DefaultWriteAck()
+
+	if md, isExist := metadata.FromIncomingContext(ctx); isExist {
+		if values := md.Get(WriteAckMetadata); len(values) > 0 {
+			writeAck = values[0]
+		}
+	}
+
+	ack, err := cluster.ParseWriteAck(writeAck)
+	if err != nil {
+		return "", statusError(ctx, codes.InvalidArgument, response.CodeInvalidRequest, err)
+	}
+
+	return ack, nil
+}
+
+// replicationAckError : 요청한 쓰기 복제 수준 미달 (마스터에는 반영된 상태)
+//
+func replicationAckError(ctx context.Context, err error) error {
+	return statusError(ctx, codes.Unavailable, response.CodeReplicationAck, err)
+}
+
+func (service dataService) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+
+	_, storedKey, err := resolveStoredKey(ctx, req.Namespace, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, redisClient, err := cluster.GetData(ctx, storedKey)
+	if err == cluster.ErrKeyNotFound {
+		return nil, statusError(ctx, codes.NotFound, response.CodeKeyNotFound, err)
+
+	} else if err != nil {
+		return nil, redisError(ctx, err)
+	}
+
+	return &pb.GetResponse{
+		Value:       value,
+		NodeAddress: redisClient.Address,
+	}, nil
+}
+
+// Set : HTTP PUT /hash/data/{key} 와 동일 (할당량 반영 후 저장, 생성 여부 반환)
+//
+func (service dataService) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetResponse, error) {
+
+	targetNamespace, storedKey, err := resolveStoredKey(ctx, req.Namespace, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	writeAck, err := writeAckOf(ctx, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := targetNamespace.Reserve(req.Key, req.Value)
+	if err != nil {
+		return nil, namespaceError(ctx, err)
+	}
+
+	isCreated, redisClient, err := cluster.ReplaceData(ctx, storedKey, req.Value, writeAck)
+	if errors.Is(err, cluster.ErrReplicationAck) {
+		return nil, replicationAckError(ctx, err)
+
+	} else if err != nil {
+		reservation.Cancel()
+		return nil, redisError(ctx, err)
+	}
+
+	return &pb.SetResponse{
+		Created:     isCreated,
+		NodeAddress: redisClient.Address,
+	}, nil
+}
+
+func (service dataService) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+
+	targetNamespace, storedKey, err := resolveStoredKey(ctx, req.Namespace, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	writeAck, err := writeAckOf(ctx, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	isDeleted, redisClient, err := cluster.DeleteData(ctx, storedKey, writeAck)
+	if errors.Is(err, cluster.ErrReplicationAck) {
+		targetNamespace.Release(req.Key)
+		return nil, replicationAckError(ctx, err)
+
+	} else if err != nil {
+		return nil, redisError(ctx, err)
+	}
+
+	if !isDeleted {
+		return nil, statusError(ctx, codes.NotFound, response.CodeKeyNotFound, cluster.ErrKeyNotFound)
+	}
+
+	targetNamespace.Release(req.Key)
+
+	return &pb.DeleteResponse{NodeAddress: redisClient.Address}, nil
+}
+
+func (service dataService) BatchGet(ctx context.Context, req *pb.BatchGetRequest) (*pb.BatchGetResponse, error) {
+
+	results := make([]*pb.KeyValueResult, 0, len(req.Keys))
+
+	for _, eachKey := range req.Keys {
+
+		_, storedKey, err := resolveStoredKey(ctx, req.Namespace, eachKey)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &pb.KeyValueResult{Key: eachKey}
+
+		value, redisClient, err := cluster.GetData(ctx, storedKey)
+		if err != nil && err != cluster.ErrKeyNotFound {
+			return nil, redisError(ctx, err)
+		}
+
+		result.Found = err == nil
+		result.Value = value
+		result.NodeAddress = redisClient.Address
+
+		results = append(results, result)
+	}
+
+	return &pb.BatchGetResponse{Results: results}, nil
+}
+
+// BatchSet : HTTP POST /hash/data 와 동일, 실패한 Key 이전까지는 저장된 상태
+//
+func (service dataService) BatchSet(ctx context.Context, req *pb.BatchSetRequest) (*pb.BatchSetResponse, error) {
+
+	results := make([]*pb.KeyValueResult, 0, len(req.Data))
+
+	for _, eachKeyValue := range req.Data {
+
+		targetNamespace, storedKey, err := resolveStoredKey(ctx, req.Namespace, eachKeyValue.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		writeAck, err := writeAckOf(ctx, targetNamespace)
+		if err != nil {
+			return nil, err
+		}
+
+		reservation, err := targetNamespace.Reserve(eachKeyValue.Key, eachKeyValue.Value)
+		if err != nil {
+			return nil, namespaceError(ctx, err)
+		}
+
+		redisClient, err := cluster.SetData(ctx, storedKey, eachKeyValue.Value, writeAck)
+		if errors.Is(err, cluster.ErrReplicationAck) {
+			return nil, replicationAckError(ctx, err)
+
+		} else if err != nil {
+			reservation.Cancel()
+			return nil, redisError(ctx, err)
+		}
+
+		results = append(results, &pb.KeyValueResult{
+			Key:         eachKeyValue.Key,
+			Value:       eachKeyValue.Value,
+			Found:       true,
+			NodeAddress: redisClient.Address,
+		})
+	}
+
+	return &pb.BatchSetResponse{Results: results}, nil
+}
+
+// Watch : 네임스페이스의 @key_prefix 로 시작하는 Key 변경 사항 전달
+// 구독자가 이벤트를 제때 받지 못하면 ABORTED (WATCH_LAGGED) 로 종료, 클라이언트는 다시 Watch
+//
+func (service dataService) Watch(req *pb.WatchRequest, stream pb.HashData_WatchServer) error {
+
+	ctx := stream.Context()
+
+	targetNamespace, err := namespace.Get(req.Namespace)
+	if err != nil {
+		return namespaceError(ctx, err)
+	}
+
+	subscription := cluster.Subscribe()
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, isOpen := <-subscription.Events:
+			if !isOpen {
+				err := fmt.Errorf("변경 사항 처리 지연으로 Watch 종료")
+				return statusError(ctx, codes.Aborted, response.CodeWatchLagged, err)
+			}
+
+			key, isOwned := targetNamespace.StripKey(event.Key)
+			if !isOwned || !strings.HasPrefix(key, req.KeyPrefix) {
+				continue
+			}
+
+			watchEvent := &pb.WatchEvent{
+				Type:        pb.WatchEvent_SET,
+				Key:         key,
+				Value:       event.Value,
+				NodeAddress: event.NodeAddress,
+			}
+			if event.Command == "DEL" {
+				watchEvent.Type = pb.WatchEvent_DELETE
+			}
+
+			if err := stream.Send(watchEvent); err != nil {
+				tools.ErrorLogger.Printf("Watch() : 이벤트 전송 실패 - %s", err.Error())
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/grpcapi/pb"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/namespace"
+	"hash_interface/internal/redistest"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testDataLogDirectory : cluster 데이터 로그 디렉토리 (테스트 작업 디렉토리 기준), testDataLogTopDirectory 는 정리할 첫 디렉토리
+const (
+	testDataLogDirectory    = "./internal/cluster/dump"
+	testDataLogTopDirectory = "./internal"
+)
+
+// setUpTestDataService : 테스트 레디스 서버 하나에 모든 해쉬 슬롯을 할당하고 bufconn 으로 gRPC 서버 시작
+// 반환된 함수로 서버 / 연결 / 데이터 로그 디렉토리 정리
+//
+func setUpTestDataService(t *testing.T) (pb.HashDataClient, *redistest.Server, func()) {
+
+	setUpTestTokens(t)
+
+	_, err := os.Stat(testDataLogTopDirectory)
+	isCreated := os.IsNotExist(err)
+
+	if err := os.MkdirAll(testDataLogDirectory, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	redisServer := redistest.NewServer()
+
+	if err := cluster.NodeConnectionSetup([]string{redisServer.Address}, cluster.Default); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.MakeHashMapToRedis(); err != nil {
+		t.Fatal(err)
+	}
+	cluster.SetUpModificationLogger([]string{redisServer.Address})
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := NewServer(nil)
+	go grpcServer.Serve(listener)
+
+	connection, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pb.NewHashDataClient(connection), redisServer, func() {
+		connection.Close()
+		grpcServer.Stop()
+		redisServer.Close()
+		if isCreated {
+			os.RemoveAll(testDataLogTopDirectory)
+		}
+	}
+}
+
+// withToken : "<role>-token" 토큰을 담은 요청 Context
+func withToken(role string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+role+"-token")
+}
+
+// expectStatus : @err 의 gRPC 상태 코드와 Trailer 의 에러 코드 확인
+//
+func expectStatus(t *testing.T, name string, err error, trailer metadata.MD, code codes.Code, errorCode string) {
+
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Errorf("%s : expected %s, got %v", name, code, err)
+		return
+	}
+
+	if errorCodes := trailer.Get(ErrorCodeTrailer); len(errorCodes) == 0 || errorCodes[0] != errorCode {
+		t.Errorf("%s : expected error code %s, got %v", name, errorCode, errorCodes)
+	}
+}
+
+func TestDataService(t *testing.T) {
+
+	client, redisServer, cleanUp := setUpTestDataService(t)
+	defer cleanUp()
+
+	if _, err := namespace.Register("team-a", 2, namespace.Unlimited); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := namespace.Register("team-b", namespace.Unlimited, namespace.Unlimited); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("role denial", func(t *testing.T) {
+
+		trailer := metadata.MD{}
+
+		_, err := client.Set(withToken("read"), &pb.SetRequest{Namespace: "team-b", Key: "denied", Value: "v"}, grpc.Trailer(&trailer))
+		expectStatus(t, "read token Set", err, trailer, codes.PermissionDenied, response.CodeForbidden)
+
+		_, err = client.BatchSet(withToken("read"), &pb.BatchSetRequest{
+			Namespace: "team-b",
+			Data:      []*pb.KeyValue{{Key: "denied", Value: "v"}},
+		}, grpc.Trailer(&trailer))
+		expectStatus(t, "read token BatchSet", err, trailer, codes.PermissionDenied, response.CodeForbidden)
+
+		if _, isStored := redisServer.Get("team-b:denied"); isStored {
+			t.Error("denied write must not be stored")
+		}
+
+		_, err = client.Get(context.Background(), &pb.GetRequest{Namespace: "team-b", Key: "denied"}, grpc.Trailer(&trailer))
+		expectStatus(t, "Get without token", err, trailer, codes.Unauthenticated, response.CodeUnauthorized)
+
+		// 권한은 있고 Key 가 없다
+		_, err = client.Get(withToken("read"), &pb.GetRequest{Namespace: "team-b", Key: "denied"}, grpc.Trailer(&trailer))
+		expectStatus(t, "read token Get", err, trailer, codes.NotFound, response.CodeKeyNotFound)
+
+		stream, err := client.Watch(context.Background(), &pb.WatchRequest{Namespace: "team-b"})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("Watch without token : expected Unauthenticated, got %v", err)
+		}
+	})
+
+	t.Run("quota rejection", func(t *testing.T) {
+
+		trailer := metadata.MD{}
+
+		// team-a 는 Key 2 개까지, 실패한 Key 이전까지는 저장된다
+		_, err := client.BatchSet(withToken("write"), &pb.BatchSetRequest{
+			Namespace: "team-a",
+			Data:      []*pb.KeyValue{{Key: "q1", Value: "1"}, {Key: "q2", Value: "2"}, {Key: "q3", Value: "3"}},
+		}, grpc.Trailer(&trailer))
+		expectStatus(t, "BatchSet over quota", err, trailer, codes.ResourceExhausted, response.CodeQuotaExceeded)
+
+		for key, isExpected := range map[string]bool{"team-a:q1": true, "team-a:q2": true, "team-a:q3": false} {
+			if _, isStored := redisServer.Get(key); isStored != isExpected {
+				t.Errorf("%s : expected stored %v", key, isExpected)
+			}
+		}
+
+		_, err = client.Set(withToken("write"), &pb.SetRequest{Namespace: "team-a", Key: "q4", Value: "4"}, grpc.Trailer(&trailer))
+		expectStatus(t, "Set over quota", err, trailer, codes.ResourceExhausted, response.CodeQuotaExceeded)
+
+		// 이미 있는 Key 는 개수가 늘지 않으므로 저장된다
+		if setResponse, err := client.Set(withToken("write"), &pb.SetRequest{Namespace: "team-a", Key: "q1", Value: "updated"}); err != nil || setResponse.Created {
+			t.Errorf("overwrite within quota : %+v (%v)", setResponse, err)
+		}
+
+		targetNamespace, _ := namespace.Get("team-a")
+		if snapshot := targetNamespace.Snapshot(); snapshot.KeyCount != 2 {
+			t.Errorf("rejected writes must not count : %d keys", snapshot.KeyCount)
+		}
+	})
+
+	t.Run("namespace isolation", func(t *testing.T) {
+
+		for _, eachNamespace := range []string{"team-a", "team-b"} {
+			if _, err := client.Set(withToken("write"), &pb.SetRequest{Namespace: eachNamespace, Key: "q1", Value: eachNamespace}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := client.BatchSet(withToken("write"), &pb.BatchSetRequest{
+			Namespace: "team-b",
+			Data:      []*pb.KeyValue{{Key: "only-b", Value: "b"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, eachNamespace := range []string{"team-a", "team-b"} {
+			getResponse, err := client.Get(withToken("read"), &pb.GetRequest{Namespace: eachNamespace, Key: "q1"})
+			if err != nil || getResponse.Value != eachNamespace {
+				t.Errorf("%s : expected own value, got %+v (%v)", eachNamespace, getResponse, err)
+			}
+			if storedValue, _ := redisServer.Get(eachNamespace + ":q1"); storedValue != eachNamespace {
+				t.Errorf("%s : expected prefixed key, got %q", eachNamespace, storedValue)
+			}
+		}
+
+		trailer := metadata.MD{}
+
+		_, err := client.Get(withToken("read"), &pb.GetRequest{Namespace: "team-a", Key: "only-b"}, grpc.Trailer(&trailer))
+		expectStatus(t, "other namespace key", err, trailer, codes.NotFound, response.CodeKeyNotFound)
+
+		_, err = client.Get(withToken("read"), &pb.GetRequest{Key: "q1"}, grpc.Trailer(&trailer))
+		expectStatus(t, "default namespace", err, trailer, codes.NotFound, response.CodeKeyNotFound)
+
+		// 기본 네임스페이스에서 다른 네임스페이스의 Key 형식은 사용할 수 없다
+		_, err = client.Get(withToken("read"), &pb.GetRequest{Key: "team-a:q1"}, grpc.Trailer(&trailer))
+		expectStatus(t, "reserved key Get", err, trailer, codes.InvalidArgument, response.CodeInvalidKey)
+
+		_, err = client.BatchSet(withToken("write"), &pb.BatchSetRequest{
+			Data: []*pb.KeyValue{{Key: "team-b:q1", Value: "overwritten"}},
+		}, grpc.Trailer(&trailer))
+		expectStatus(t, "reserved key BatchSet", err, trailer, codes.InvalidArgument, response.CodeInvalidKey)
+
+		if storedValue, _ := redisServer.Get("team-b:q1"); storedValue != "team-b" {
+			t.Errorf("other namespace value must not change, got %q", storedValue)
+		}
+
+		_, err = client.Get(withToken("read"), &pb.GetRequest{Namespace: "unknown", Key: "q1"}, grpc.Trailer(&trailer))
+		expectStatus(t, "unknown namespace", err, trailer, codes.NotFound, response.CodeNamespaceNotFound)
+	})
+
+	t.Run("watch isolation", func(t *testing.T) {
+
+		ctx, cancel := context.WithTimeout(withToken("read"), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &pb.WatchRequest{Namespace: "team-b", KeyPrefix: "w"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 구독 시작 시점을 알 수 없으므로 첫 이벤트를 받을 때까지 같은 쓰기를 반복
+		// 다른 네임스페이스 / Prefix 의 쓰기는 전달되지 않으므로 첫 이벤트는 항상 team-b 의 "w1"
+		isReceived := make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(50 * time.Millisecond)
+			defer ticker.Stop()
+
+			for {
+				client.Set(withToken("admin"), &pb.SetRequest{Namespace: "team-a", Key: "w1", Value: "a"})
+				client.Set(withToken("admin"), &pb.SetRequest{Namespace: "team-b", Key: "x1", Value: "b"})
+				client.Set(withToken("admin"), &pb.SetRequest{Namespace: "team-b", Key: "w1", Value: "b"})
+
+				select {
+				case <-isReceived:
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+
+		event, err := stream.Recv()
+		close(isReceived)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.Type != pb.WatchEvent_SET || event.Key != "w1" || event.Value != "b" || event.NodeAddress != redisServer.Address {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	})
+}
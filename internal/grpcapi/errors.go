@@ -0,0 +1,61 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/namespace"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCodeTrailer : HTTP 에러 응답의 error.code (response.Code*) 를 담는 Trailer
+const ErrorCodeTrailer = "error-code"
+
+// statusError : gRPC 상태 코드 + HTTP API 와 같은 에러 코드 (Trailer)
+//
+func statusError(ctx context.Context, code codes.Code, errorCode string, err error) error {
+
+	grpc.SetTrailer(ctx, metadata.Pairs(ErrorCodeTrailer, errorCode))
+
+	return status.Error(code, err.Error())
+}
+
+// namespaceError : handlers.responseNamespaceError 와 같은 에러 코드
+//
+func namespaceError(ctx context.Context, err error) error {
+
+	switch err {
+	case namespace.ErrNotFound:
+		return statusError(ctx, codes.NotFound, response.CodeNamespaceNotFound, err)
+	case namespace.ErrInvalidName:
+		return statusError(ctx, codes.InvalidArgument, response.CodeInvalidNamespace, err)
+	case namespace.ErrReservedKey:
+		return statusError(ctx, codes.InvalidArgument, response.CodeInvalidKey, err)
+	case namespace.ErrKeyCountQuota, namespace.ErrBytesQuota:
+		return statusError(ctx, codes.ResourceExhausted, response.CodeQuotaExceeded, err)
+	default:
+		return statusError(ctx, codes.InvalidArgument, response.CodeInvalidRequest, err)
+	}
+}
+
+// redisError : handlers.responseRedisError 와 같은 에러 코드
+//
+func redisError(ctx context.Context, err error) error {
+
+	switch {
+	case errors.Is(err, cluster.ErrNodeDown):
+		return statusError(ctx, codes.Unavailable, response.CodeNodeDown, err)
+	case errors.Is(err, cluster.ErrCircuitOpen):
+		return statusError(ctx, codes.Unavailable, response.CodeCircuitOpen, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return statusError(ctx, codes.DeadlineExceeded, response.CodeRedisTimeout, err)
+	default:
+		return statusError(ctx, codes.Internal, response.CodeRedisCommand, err)
+	}
+}
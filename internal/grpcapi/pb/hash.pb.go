@@ -0,0 +1,1401 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: hash.proto
+
+// 인터페이스 서버 gRPC API
+// HTTP API (/hash/data, /clients) 와 같은 클러스터 코드 경로 / 권한 / 에러 코드를 사용한다.
+//
+// 생성 : protoc --go_out=plugins=grpc,paths=source_relative:internal/grpcapi/pb -I api/proto api/proto/hash.proto
+
+package pb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_SET    WatchEvent_Type = 0
+	WatchEvent_DELETE WatchEvent_Type = 1
+)
+
+var WatchEvent_Type_name = map[int32]string{
+	0: "SET",
+	1: "DELETE",
+}
+
+var WatchEvent_Type_value = map[string]int32{
+	"SET":    0,
+	"DELETE": 1,
+}
+
+func (x WatchEvent_Type) String() string {
+	return proto.EnumName(WatchEvent_Type_name, int32(x))
+}
+
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{13, 0}
+}
+
+type GetRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{0}
+}
+
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type GetResponse struct {
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// node_address : 처리한 마스터 주소
+	NodeAddress          string   `protobuf:"bytes,2,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{1}
+}
+
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
+}
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+}
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
+}
+func (m *GetResponse) XXX_Size() int {
+	return xxx_messageInfo_GetResponse.Size(m)
+}
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+
+func (m *GetResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *GetResponse) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+type SetRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetRequest) Reset()         { *m = SetRequest{} }
+func (m *SetRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRequest) ProtoMessage()    {}
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{2}
+}
+
+func (m *SetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRequest.Unmarshal(m, b)
+}
+func (m *SetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRequest.Merge(m, src)
+}
+func (m *SetRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRequest.Size(m)
+}
+func (m *SetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRequest proto.InternalMessageInfo
+
+func (m *SetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *SetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetResponse struct {
+	Created              bool     `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	NodeAddress          string   `protobuf:"bytes,2,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetResponse) Reset()         { *m = SetResponse{} }
+func (m *SetResponse) String() string { return proto.CompactTextString(m) }
+func (*SetResponse) ProtoMessage()    {}
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{3}
+}
+
+func (m *SetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetResponse.Unmarshal(m, b)
+}
+func (m *SetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetResponse.Marshal(b, m, deterministic)
+}
+func (m *SetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetResponse.Merge(m, src)
+}
+func (m *SetResponse) XXX_Size() int {
+	return xxx_messageInfo_SetResponse.Size(m)
+}
+func (m *SetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetResponse proto.InternalMessageInfo
+
+func (m *SetResponse) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *SetResponse) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{4}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	NodeAddress          string   `protobuf:"bytes,1,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{5}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+func (m *DeleteResponse) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+type BatchGetRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Keys                 []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetRequest) Reset()         { *m = BatchGetRequest{} }
+func (m *BatchGetRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetRequest) ProtoMessage()    {}
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{6}
+}
+
+func (m *BatchGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetRequest.Unmarshal(m, b)
+}
+func (m *BatchGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetRequest.Merge(m, src)
+}
+func (m *BatchGetRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchGetRequest.Size(m)
+}
+func (m *BatchGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetRequest proto.InternalMessageInfo
+
+func (m *BatchGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *BatchGetRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type BatchGetResponse struct {
+	Results              []*KeyValueResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BatchGetResponse) Reset()         { *m = BatchGetResponse{} }
+func (m *BatchGetResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetResponse) ProtoMessage()    {}
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{7}
+}
+
+func (m *BatchGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchGetResponse.Unmarshal(m, b)
+}
+func (m *BatchGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchGetResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetResponse.Merge(m, src)
+}
+func (m *BatchGetResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchGetResponse.Size(m)
+}
+func (m *BatchGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetResponse proto.InternalMessageInfo
+
+func (m *BatchGetResponse) GetResults() []*KeyValueResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type KeyValue struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()    {}
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{8}
+}
+
+func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
+}
+func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+}
+func (m *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(m, src)
+}
+func (m *KeyValue) XXX_Size() int {
+	return xxx_messageInfo_KeyValue.Size(m)
+}
+func (m *KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValue proto.InternalMessageInfo
+
+func (m *KeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type BatchSetRequest struct {
+	Namespace            string      `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Data                 []*KeyValue `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BatchSetRequest) Reset()         { *m = BatchSetRequest{} }
+func (m *BatchSetRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchSetRequest) ProtoMessage()    {}
+func (*BatchSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{9}
+}
+
+func (m *BatchSetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchSetRequest.Unmarshal(m, b)
+}
+func (m *BatchSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchSetRequest.Marshal(b, m, deterministic)
+}
+func (m *BatchSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchSetRequest.Merge(m, src)
+}
+func (m *BatchSetRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchSetRequest.Size(m)
+}
+func (m *BatchSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchSetRequest proto.InternalMessageInfo
+
+func (m *BatchSetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *BatchSetRequest) GetData() []*KeyValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type BatchSetResponse struct {
+	Results              []*KeyValueResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BatchSetResponse) Reset()         { *m = BatchSetResponse{} }
+func (m *BatchSetResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchSetResponse) ProtoMessage()    {}
+func (*BatchSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{10}
+}
+
+func (m *BatchSetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchSetResponse.Unmarshal(m, b)
+}
+func (m *BatchSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchSetResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchSetResponse.Merge(m, src)
+}
+func (m *BatchSetResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchSetResponse.Size(m)
+}
+func (m *BatchSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchSetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchSetResponse proto.InternalMessageInfo
+
+func (m *BatchSetResponse) GetResults() []*KeyValueResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type KeyValueResult struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Found                bool     `protobuf:"varint,3,opt,name=found,proto3" json:"found,omitempty"`
+	NodeAddress          string   `protobuf:"bytes,4,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValueResult) Reset()         { *m = KeyValueResult{} }
+func (m *KeyValueResult) String() string { return proto.CompactTextString(m) }
+func (*KeyValueResult) ProtoMessage()    {}
+func (*KeyValueResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{11}
+}
+
+func (m *KeyValueResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValueResult.Unmarshal(m, b)
+}
+func (m *KeyValueResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValueResult.Marshal(b, m, deterministic)
+}
+func (m *KeyValueResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValueResult.Merge(m, src)
+}
+func (m *KeyValueResult) XXX_Size() int {
+	return xxx_messageInfo_KeyValueResult.Size(m)
+}
+func (m *KeyValueResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValueResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValueResult proto.InternalMessageInfo
+
+func (m *KeyValueResult) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValueResult) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *KeyValueResult) GetFound() bool {
+	if m != nil {
+		return m.Found
+	}
+	return false
+}
+
+func (m *KeyValueResult) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// key_prefix : 빈 문자열이면 네임스페이스의 모든 Key
+	KeyPrefix            string   `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{12}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+type WatchEvent struct {
+	Type WatchEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=hashinterface.v1.WatchEvent_Type" json:"type,omitempty"`
+	Key  string          `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// value : DELETE 는 빈 문자열
+	Value                string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	NodeAddress          string   `protobuf:"bytes,4,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{13}
+}
+
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEvent.Unmarshal(m, b)
+}
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+}
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
+}
+func (m *WatchEvent) XXX_Size() int {
+	return xxx_messageInfo_WatchEvent.Size(m)
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetType() WatchEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return WatchEvent_SET
+}
+
+func (m *WatchEvent) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+type AddClientRequest struct {
+	// address : 레디스 노드 주소 (IP:Port)
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// role : "master" / "slave"
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// master_address : role = "slave" 일 때 필수
+	MasterAddress        string   `protobuf:"bytes,3,opt,name=master_address,json=masterAddress,proto3" json:"master_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddClientRequest) Reset()         { *m = AddClientRequest{} }
+func (m *AddClientRequest) String() string { return proto.CompactTextString(m) }
+func (*AddClientRequest) ProtoMessage()    {}
+func (*AddClientRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{14}
+}
+
+func (m *AddClientRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddClientRequest.Unmarshal(m, b)
+}
+func (m *AddClientRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddClientRequest.Marshal(b, m, deterministic)
+}
+func (m *AddClientRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddClientRequest.Merge(m, src)
+}
+func (m *AddClientRequest) XXX_Size() int {
+	return xxx_messageInfo_AddClientRequest.Size(m)
+}
+func (m *AddClientRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddClientRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddClientRequest proto.InternalMessageInfo
+
+func (m *AddClientRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddClientRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *AddClientRequest) GetMasterAddress() string {
+	if m != nil {
+		return m.MasterAddress
+	}
+	return ""
+}
+
+type GetClientsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClientsRequest) Reset()         { *m = GetClientsRequest{} }
+func (m *GetClientsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetClientsRequest) ProtoMessage()    {}
+func (*GetClientsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{15}
+}
+
+func (m *GetClientsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClientsRequest.Unmarshal(m, b)
+}
+func (m *GetClientsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClientsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetClientsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClientsRequest.Merge(m, src)
+}
+func (m *GetClientsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetClientsRequest.Size(m)
+}
+func (m *GetClientsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClientsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClientsRequest proto.InternalMessageInfo
+
+type RedisNode struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Role                 string   `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedisNode) Reset()         { *m = RedisNode{} }
+func (m *RedisNode) String() string { return proto.CompactTextString(m) }
+func (*RedisNode) ProtoMessage()    {}
+func (*RedisNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{16}
+}
+
+func (m *RedisNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedisNode.Unmarshal(m, b)
+}
+func (m *RedisNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedisNode.Marshal(b, m, deterministic)
+}
+func (m *RedisNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedisNode.Merge(m, src)
+}
+func (m *RedisNode) XXX_Size() int {
+	return xxx_messageInfo_RedisNode.Size(m)
+}
+func (m *RedisNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedisNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedisNode proto.InternalMessageInfo
+
+func (m *RedisNode) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RedisNode) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+type ClientsResponse struct {
+	Masters              []*RedisNode `protobuf:"bytes,1,rep,name=masters,proto3" json:"masters,omitempty"`
+	Slaves               []*RedisNode `protobuf:"bytes,2,rep,name=slaves,proto3" json:"slaves,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ClientsResponse) Reset()         { *m = ClientsResponse{} }
+func (m *ClientsResponse) String() string { return proto.CompactTextString(m) }
+func (*ClientsResponse) ProtoMessage()    {}
+func (*ClientsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_844ef095867f6a46, []int{17}
+}
+
+func (m *ClientsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientsResponse.Unmarshal(m, b)
+}
+func (m *ClientsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientsResponse.Marshal(b, m, deterministic)
+}
+func (m *ClientsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientsResponse.Merge(m, src)
+}
+func (m *ClientsResponse) XXX_Size() int {
+	return xxx_messageInfo_ClientsResponse.Size(m)
+}
+func (m *ClientsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientsResponse proto.InternalMessageInfo
+
+func (m *ClientsResponse) GetMasters() []*RedisNode {
+	if m != nil {
+		return m.Masters
+	}
+	return nil
+}
+
+func (m *ClientsResponse) GetSlaves() []*RedisNode {
+	if m != nil {
+		return m.Slaves
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("hashinterface.v1.WatchEvent_Type", WatchEvent_Type_name, WatchEvent_Type_value)
+	proto.RegisterType((*GetRequest)(nil), "hashinterface.v1.GetRequest")
+	proto.RegisterType((*GetResponse)(nil), "hashinterface.v1.GetResponse")
+	proto.RegisterType((*SetRequest)(nil), "hashinterface.v1.SetRequest")
+	proto.RegisterType((*SetResponse)(nil), "hashinterface.v1.SetResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "hashinterface.v1.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "hashinterface.v1.DeleteResponse")
+	proto.RegisterType((*BatchGetRequest)(nil), "hashinterface.v1.BatchGetRequest")
+	proto.RegisterType((*BatchGetResponse)(nil), "hashinterface.v1.BatchGetResponse")
+	proto.RegisterType((*KeyValue)(nil), "hashinterface.v1.KeyValue")
+	proto.RegisterType((*BatchSetRequest)(nil), "hashinterface.v1.BatchSetRequest")
+	proto.RegisterType((*BatchSetResponse)(nil), "hashinterface.v1.BatchSetResponse")
+	proto.RegisterType((*KeyValueResult)(nil), "hashinterface.v1.KeyValueResult")
+	proto.RegisterType((*WatchRequest)(nil), "hashinterface.v1.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "hashinterface.v1.WatchEvent")
+	proto.RegisterType((*AddClientRequest)(nil), "hashinterface.v1.AddClientRequest")
+	proto.RegisterType((*GetClientsRequest)(nil), "hashinterface.v1.GetClientsRequest")
+	proto.RegisterType((*RedisNode)(nil), "hashinterface.v1.RedisNode")
+	proto.RegisterType((*ClientsResponse)(nil), "hashinterface.v1.ClientsResponse")
+}
+
+func init() {
+	proto.RegisterFile("hash.proto", fileDescriptor_844ef095867f6a46)
+}
+
+var fileDescriptor_844ef095867f6a46 = []byte{
+	// 697 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x75, 0x9a, 0xc4, 0x93, 0x36, 0x0d, 0x0b, 0x87, 0x28, 0x6d, 0xa1, 0x35, 0xaa, 0xe8,
+	0x29, 0x85, 0x54, 0x3d, 0xf0, 0x23, 0xa1, 0xa6, 0x0d, 0x41, 0x14, 0x55, 0x60, 0x47, 0x20, 0x71,
+	0x89, 0xb6, 0xf1, 0xb4, 0x89, 0xe2, 0xda, 0xae, 0x77, 0x13, 0xe1, 0x03, 0x4f, 0xc4, 0x99, 0xd7,
+	0xe0, 0x99, 0x90, 0xd7, 0x5e, 0xe7, 0xc7, 0x69, 0x6a, 0x2a, 0x6e, 0xbb, 0xe3, 0x99, 0x6f, 0xbe,
+	0x6f, 0x7e, 0x36, 0x01, 0xe8, 0x53, 0xd6, 0xaf, 0x7b, 0xbe, 0xcb, 0x5d, 0x52, 0x09, 0xcf, 0x03,
+	0x87, 0xa3, 0x7f, 0x49, 0x7b, 0x58, 0x1f, 0xbf, 0xd4, 0xdf, 0x02, 0xb4, 0x91, 0x1b, 0x78, 0x33,
+	0x42, 0xc6, 0xc9, 0x16, 0x68, 0x0e, 0xbd, 0x46, 0xe6, 0xd1, 0x1e, 0x56, 0x95, 0x1d, 0x65, 0x5f,
+	0x33, 0x26, 0x06, 0x52, 0x01, 0x75, 0x88, 0x41, 0x75, 0x45, 0xd8, 0xc3, 0xa3, 0xfe, 0x1e, 0x4a,
+	0x22, 0x9a, 0x79, 0xae, 0xc3, 0x90, 0x3c, 0x86, 0xd5, 0x31, 0xb5, 0x47, 0x32, 0x34, 0xba, 0x90,
+	0x5d, 0x58, 0x73, 0x5c, 0x0b, 0xbb, 0xd4, 0xb2, 0x7c, 0x64, 0x2c, 0x8e, 0x2f, 0x85, 0xb6, 0xe3,
+	0xc8, 0xa4, 0x1b, 0x00, 0xe6, 0xbd, 0x59, 0x4c, 0xd2, 0xaa, 0x53, 0x69, 0xf5, 0x8f, 0x50, 0x32,
+	0xa7, 0xb8, 0x55, 0xa1, 0xd0, 0xf3, 0x91, 0x72, 0xb4, 0x04, 0x64, 0xd1, 0x90, 0xd7, 0x2c, 0xfc,
+	0xde, 0xc1, 0xfa, 0x29, 0xda, 0xc8, 0xf1, 0xbe, 0x85, 0x3a, 0x84, 0xb2, 0x04, 0x88, 0xf9, 0xcc,
+	0x67, 0x55, 0xd2, 0x59, 0x4f, 0x60, 0xa3, 0x49, 0x79, 0xaf, 0x9f, 0xb9, 0x41, 0x04, 0x72, 0x43,
+	0x0c, 0x42, 0x05, 0xea, 0xbe, 0x66, 0x88, 0xb3, 0x7e, 0x0e, 0x95, 0x09, 0x48, 0x9c, 0xfb, 0x35,
+	0x14, 0x7c, 0x64, 0x23, 0x9b, 0x87, 0x69, 0xd5, 0xfd, 0x52, 0x63, 0xa7, 0x3e, 0x3f, 0x18, 0xf5,
+	0x33, 0x0c, 0xbe, 0x86, 0x75, 0x34, 0x84, 0xa3, 0x21, 0x03, 0xf4, 0x06, 0x14, 0xe5, 0x27, 0xa9,
+	0x53, 0x59, 0xd0, 0x8a, 0x95, 0xe9, 0x56, 0x74, 0x63, 0x21, 0x99, 0x7b, 0x5c, 0x87, 0x9c, 0x45,
+	0x39, 0x15, 0x42, 0x4a, 0x8d, 0xda, 0x12, 0x76, 0xc2, 0x2f, 0x11, 0x69, 0xfe, 0x27, 0x91, 0x37,
+	0x50, 0x9e, 0xfd, 0x94, 0x55, 0x6a, 0x68, 0xbd, 0x74, 0x47, 0x8e, 0x25, 0x66, 0xb1, 0x68, 0x44,
+	0x97, 0x54, 0xb3, 0x73, 0xe9, 0x66, 0x9f, 0xc1, 0xda, 0xb7, 0x50, 0x42, 0xb6, 0x02, 0x6d, 0x03,
+	0x0c, 0x31, 0xe8, 0x7a, 0x3e, 0x5e, 0x0e, 0x7e, 0xc4, 0x0c, 0xb4, 0x21, 0x06, 0x9f, 0x85, 0x41,
+	0xff, 0xa5, 0x00, 0x08, 0xb4, 0xd6, 0x18, 0x1d, 0x4e, 0x8e, 0x20, 0xc7, 0x03, 0x2f, 0x82, 0x29,
+	0x37, 0x76, 0xd3, 0x75, 0x98, 0xf8, 0xd6, 0x3b, 0x81, 0x87, 0x86, 0x70, 0xcf, 0xba, 0x69, 0x59,
+	0xd4, 0x6d, 0x42, 0x2e, 0x04, 0x26, 0x05, 0x50, 0xcd, 0x56, 0xa7, 0xf2, 0x80, 0x00, 0xe4, 0x4f,
+	0x5b, 0x9f, 0x5a, 0x9d, 0x56, 0x45, 0xd1, 0xaf, 0xa0, 0x72, 0x6c, 0x59, 0x27, 0xf6, 0x00, 0x9d,
+	0x64, 0x3e, 0xaa, 0x50, 0x98, 0xdd, 0x0c, 0x79, 0x0d, 0x87, 0xdc, 0x77, 0x6d, 0x59, 0x76, 0x71,
+	0x26, 0x7b, 0x50, 0xbe, 0xa6, 0x8c, 0xa3, 0x9f, 0x70, 0x88, 0x08, 0xae, 0x47, 0x56, 0xc9, 0xe2,
+	0x11, 0x3c, 0x6c, 0x23, 0x8f, 0x12, 0xb1, 0x38, 0x93, 0xfe, 0x0a, 0x34, 0x03, 0xad, 0x01, 0x3b,
+	0x77, 0x2d, 0xfc, 0xb7, 0xb4, 0xfa, 0x4f, 0xd8, 0x48, 0xc0, 0xe2, 0xa9, 0x3b, 0x82, 0x42, 0x94,
+	0x53, 0x4e, 0xdd, 0x66, 0xba, 0xda, 0x49, 0x3a, 0x43, 0xfa, 0x92, 0x43, 0xc8, 0x33, 0x9b, 0x8e,
+	0x91, 0x55, 0x57, 0xee, 0x8e, 0x8a, 0x5d, 0x1b, 0x7f, 0x54, 0x28, 0x7e, 0xa0, 0xac, 0x7f, 0x4a,
+	0x39, 0x25, 0x4d, 0x50, 0xdb, 0xc8, 0xc9, 0x56, 0x3a, 0x70, 0xf2, 0x7c, 0xd4, 0xb6, 0x6f, 0xf9,
+	0x1a, 0x93, 0x6f, 0x82, 0x6a, 0x2e, 0xc6, 0x30, 0x97, 0x62, 0x4c, 0xaf, 0xdd, 0x19, 0xe4, 0xa3,
+	0x97, 0x8e, 0x3c, 0x4d, 0x3b, 0xce, 0x3c, 0xa2, 0xb5, 0x9d, 0xdb, 0x1d, 0x62, 0xb0, 0x2f, 0x50,
+	0x94, 0x8f, 0x17, 0x59, 0x30, 0xb6, 0x73, 0xaf, 0x63, 0x4d, 0x5f, 0xe6, 0x32, 0x07, 0x69, 0x2e,
+	0x81, 0x34, 0xef, 0x86, 0x9c, 0x96, 0xdc, 0x86, 0x55, 0xb1, 0x40, 0xe4, 0xc9, 0x2d, 0x9b, 0x25,
+	0xc1, 0xb6, 0x96, 0x6d, 0xde, 0x0b, 0xa5, 0xf1, 0x5b, 0x01, 0x2d, 0x6c, 0xe8, 0xb1, 0x75, 0x3d,
+	0x70, 0x88, 0x01, 0x5a, 0xb2, 0x16, 0x64, 0x01, 0x8f, 0xf9, 0x9d, 0xa9, 0x2d, 0x90, 0x33, 0x3f,
+	0x9e, 0x1d, 0xf1, 0x73, 0x1f, 0x5b, 0xc9, 0xb3, 0x85, 0xe3, 0x30, 0xbb, 0x1f, 0x19, 0x50, 0x9b,
+	0xcf, 0xbf, 0xef, 0x85, 0x3e, 0xdd, 0xc4, 0xe9, 0x40, 0x9c, 0x1c, 0x6a, 0x1f, 0x5c, 0xf9, 0x5e,
+	0x8f, 0x7a, 0x83, 0x03, 0xef, 0xe2, 0x8d, 0x77, 0x71, 0x91, 0x17, 0x7f, 0x43, 0x0e, 0xff, 0x0e,
+	0x00, 0xcc, 0x7c, 0x74, 0x6b, 0x94, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// HashDataClient is the client API for HashData service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HashDataClient interface {
+	// Get : 없는 Key 는 NOT_FOUND (KEY_NOT_FOUND)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// Set : 새로 생성된 경우 created = true
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	// Delete : 없는 Key 는 NOT_FOUND (KEY_NOT_FOUND)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// BatchGet : Key 별 결과, 없는 Key 는 found = false
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// BatchSet : 순서대로 저장, 실패 시 이전 Key 는 저장된 상태로 에러 반환 (HTTP POST /hash/data 와 동일)
+	BatchSet(ctx context.Context, in *BatchSetRequest, opts ...grpc.CallOption) (*BatchSetResponse, error)
+	// Watch : 이 인터페이스 서버를 거친 변경 사항 스트림
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HashData_WatchClient, error)
+}
+
+type hashDataClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHashDataClient(cc grpc.ClientConnInterface) HashDataClient {
+	return &hashDataClient{cc}
+}
+
+func (c *hashDataClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashData/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashDataClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	out := new(SetResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashData/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashDataClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashData/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashDataClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashData/BatchGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashDataClient) BatchSet(ctx context.Context, in *BatchSetRequest, opts ...grpc.CallOption) (*BatchSetResponse, error) {
+	out := new(BatchSetResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashData/BatchSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashDataClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (HashData_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HashData_serviceDesc.Streams[0], "/hashinterface.v1.HashData/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hashDataWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HashData_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type hashDataWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *hashDataWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HashDataServer is the server API for HashData service.
+type HashDataServer interface {
+	// Get : 없는 Key 는 NOT_FOUND (KEY_NOT_FOUND)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// Set : 새로 생성된 경우 created = true
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	// Delete : 없는 Key 는 NOT_FOUND (KEY_NOT_FOUND)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// BatchGet : Key 별 결과, 없는 Key 는 found = false
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// BatchSet : 순서대로 저장, 실패 시 이전 Key 는 저장된 상태로 에러 반환 (HTTP POST /hash/data 와 동일)
+	BatchSet(context.Context, *BatchSetRequest) (*BatchSetResponse, error)
+	// Watch : 이 인터페이스 서버를 거친 변경 사항 스트림
+	Watch(*WatchRequest, HashData_WatchServer) error
+}
+
+// UnimplementedHashDataServer can be embedded to have forward compatible implementations.
+type UnimplementedHashDataServer struct {
+}
+
+func (*UnimplementedHashDataServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedHashDataServer) Set(ctx context.Context, req *SetRequest) (*SetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedHashDataServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedHashDataServer) BatchGet(ctx context.Context, req *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (*UnimplementedHashDataServer) BatchSet(ctx context.Context, req *BatchSetRequest) (*BatchSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSet not implemented")
+}
+func (*UnimplementedHashDataServer) Watch(req *WatchRequest, srv HashData_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+
+func RegisterHashDataServer(s *grpc.Server, srv HashDataServer) {
+	s.RegisterService(&_HashData_serviceDesc, srv)
+}
+
+func _HashData_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashDataServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashData/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashDataServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashData_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashDataServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashData/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashDataServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashData_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashDataServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashData/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashDataServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashData_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashDataServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashData/BatchGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashDataServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashData_BatchSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashDataServer).BatchSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashData/BatchSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashDataServer).BatchSet(ctx, req.(*BatchSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashData_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HashDataServer).Watch(m, &hashDataWatchServer{stream})
+}
+
+type HashData_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type hashDataWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *hashDataWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _HashData_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashinterface.v1.HashData",
+	HandlerType: (*HashDataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _HashData_Get_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _HashData_Set_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _HashData_Delete_Handler,
+		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _HashData_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchSet",
+			Handler:    _HashData_BatchSet_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _HashData_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "hash.proto",
+}
+
+// HashAdminClient is the client API for HashAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HashAdminClient interface {
+	// AddClient : HTTP POST /clients
+	AddClient(ctx context.Context, in *AddClientRequest, opts ...grpc.CallOption) (*ClientsResponse, error)
+	// GetClients : HTTP GET /clients
+	GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*ClientsResponse, error)
+}
+
+type hashAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHashAdminClient(cc grpc.ClientConnInterface) HashAdminClient {
+	return &hashAdminClient{cc}
+}
+
+func (c *hashAdminClient) AddClient(ctx context.Context, in *AddClientRequest, opts ...grpc.CallOption) (*ClientsResponse, error) {
+	out := new(ClientsResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashAdmin/AddClient", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashAdminClient) GetClients(ctx context.Context, in *GetClientsRequest, opts ...grpc.CallOption) (*ClientsResponse, error) {
+	out := new(ClientsResponse)
+	err := c.cc.Invoke(ctx, "/hashinterface.v1.HashAdmin/GetClients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HashAdminServer is the server API for HashAdmin service.
+type HashAdminServer interface {
+	// AddClient : HTTP POST /clients
+	AddClient(context.Context, *AddClientRequest) (*ClientsResponse, error)
+	// GetClients : HTTP GET /clients
+	GetClients(context.Context, *GetClientsRequest) (*ClientsResponse, error)
+}
+
+// UnimplementedHashAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedHashAdminServer struct {
+}
+
+func (*UnimplementedHashAdminServer) AddClient(ctx context.Context, req *AddClientRequest) (*ClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddClient not implemented")
+}
+func (*UnimplementedHashAdminServer) GetClients(ctx context.Context, req *GetClientsRequest) (*ClientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClients not implemented")
+}
+
+func RegisterHashAdminServer(s *grpc.Server, srv HashAdminServer) {
+	s.RegisterService(&_HashAdmin_serviceDesc, srv)
+}
+
+func _HashAdmin_AddClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashAdminServer).AddClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashAdmin/AddClient",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashAdminServer).AddClient(ctx, req.(*AddClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HashAdmin_GetClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashAdminServer).GetClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashinterface.v1.HashAdmin/GetClients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashAdminServer).GetClients(ctx, req.(*GetClientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HashAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashinterface.v1.HashAdmin",
+	HandlerType: (*HashAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddClient",
+			Handler:    _HashAdmin_AddClient_Handler,
+		},
+		{
+			MethodName: "GetClients",
+			Handler:    _HashAdmin_GetClients_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hash.proto",
+}
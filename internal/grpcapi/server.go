@@ -0,0 +1,200 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/grpcapi/pb"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/ratelimit"
+	"hash_interface/tools"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+/* gRPC 서버
+ * 인증 / 권한 / 요청 제한은 HTTP 미들웨어 (RequireRole, LimitRequests) 와 같은 규칙을 인터셉터로 적용한다.
+ * 토큰 : metadata "authorization: Bearer <token>"
+ */
+
+// methodRoles : gRPC 메소드 -> 필요한 권한 (HTTP 라우터와 동일)
+var methodRoles map[string]auth.Role
+
+// requestLimiter, concurrencyLimiter : gRPC 요청 전용 (설정 값은 HTTP 와 동일)
+var requestLimiter *ratelimit.Limiter
+var concurrencyLimiter *ratelimit.ConcurrencyLimiter
+
+var limitSettings ratelimit.Settings
+
+func init() {
+	if methodRoles == nil {
+		methodRoles = map[string]auth.Role{
+			"/hashinterface.v1.HashData/Get":         auth.RoleRead,
+			"/hashinterface.v1.HashData/BatchGet":    auth.RoleRead,
+			"/hashinterface.v1.HashData/Watch":       auth.RoleRead,
+			"/hashinterface.v1.HashData/Set":         auth.RoleWrite,
+			"/hashinterface.v1.HashData/BatchSet":    auth.RoleWrite,
+			"/hashinterface.v1.HashData/Delete":      auth.RoleWrite,
+			"/hashinterface.v1.HashAdmin/AddClient":  auth.RoleAdmin,
+			"/hashinterface.v1.HashAdmin/GetClients": auth.RoleAdmin,
+		}
+	}
+	limitSettings = ratelimit.DefaultSettings()
+
+	if requestLimiter == nil {
+		requestLimiter = ratelimit.NewLimiter(limitSettings.RatePerSecond, limitSettings.Burst)
+	}
+	if concurrencyLimiter == nil {
+		concurrencyLimiter = ratelimit.NewConcurrencyLimiter(limitSettings.MaxConcurrent)
+	}
+}
+
+// SetUpRequestLimits : 요청 제한 설정 (서버 시작 전 호출)
+//
+func SetUpRequestLimits(settings ratelimit.Settings) {
+
+	limitSettings = settings
+
+	requestLimiter = ratelimit.NewLimiter(settings.RatePerSecond, settings.Burst)
+	concurrencyLimiter = ratelimit.NewConcurrencyLimiter(settings.MaxConcurrent)
+}
+
+// NewServer : 데이터 / 관리 서비스가 등록된 gRPC 서버, @tlsConfig 가 nil 이면 평문
+//
+func NewServer(tlsConfig *tls.Config) *grpc.Server {
+
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	}
+
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(serverOptions...)
+
+	pb.RegisterHashDataServer(server, dataService{})
+	pb.RegisterHashAdminServer(server, adminService{})
+
+	return server
+}
+
+// ListenAndServe : @address 에서 gRPC 요청 대기
+//
+func ListenAndServe(address string, tlsConfig *tls.Config) error {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return NewServer(tlsConfig).Serve(listener)
+}
+
+// unaryInterceptor : 동시 처리 수 제한 -> 인증 / 권한 -> 요청 속도 제한
+//
+func unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+
+	if !concurrencyLimiter.TryAcquire() {
+		err := fmt.Errorf("서버 처리 한도 초과, 잠시 후 다시 시도하세요")
+		return nil, statusError(ctx, codes.Unavailable, response.CodeOverloaded, err)
+	}
+	defer concurrencyLimiter.Release()
+
+	if err := checkRequest(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// streamInterceptor : Watch 는 오래 유지되므로 동시 처리 수 제한에 포함하지 않는다
+//
+func streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+	if err := checkRequest(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+// checkRequest : RequireRole + LimitRequests 의 요청 속도 제한
+//
+func checkRequest(ctx context.Context, fullMethod string) error {
+
+	limitKey := "ip:" + peerIP(ctx)
+
+	if auth.IsEnabled() {
+		requiredRole, isSet := methodRoles[fullMethod]
+		if !isSet {
+			requiredRole = auth.RoleAdmin
+		}
+
+		identity, err := auth.Authenticate(authorizationOf(ctx))
+		if err != nil {
+			tools.InfoLogger.Printf("gRPC %s 인증 실패 - %s", fullMethod, err.Error())
+			return statusError(ctx, codes.Unauthenticated, response.CodeUnauthorized, err)
+		}
+
+		if err := identity.Authorize(requiredRole); err != nil {
+			tools.InfoLogger.Printf(
+				"gRPC %s(%s) => %s 권한 없음 (필요 : %s)",
+				identity.Name,
+				identity.Role,
+				fullMethod,
+				requiredRole,
+			)
+			return statusError(ctx, codes.PermissionDenied, response.CodeForbidden, err)
+		}
+
+		limitKey = "token:" + identity.TokenHash
+	}
+
+	if isAllowed, _ := requestLimiter.Allow(limitKey); !isAllowed {
+		err := fmt.Errorf("요청 속도 제한 초과 (초당 %.0f 회)", limitSettings.RatePerSecond)
+		return statusError(ctx, codes.ResourceExhausted, response.CodeRateLimited, err)
+	}
+
+	return nil
+}
+
+// authorizationOf : metadata 의 "authorization" 값 (HTTP Authorization 헤더와 같은 형식)
+func authorizationOf(ctx context.Context) string {
+
+	md, isSet := metadata.FromIncomingContext(ctx)
+	if !isSet {
+		return ""
+	}
+
+	values := md.Get(strings.ToLower(auth.HeaderName))
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func peerIP(ctx context.Context) string {
+
+	requestPeer, isSet := peer.FromContext(ctx)
+	if !isSet {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(requestPeer.Addr.String())
+	if err != nil {
+		return requestPeer.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,75 @@
+package grpcapi
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"hash_interface/internal/auth"
+	"hash_interface/tools"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// setUpTestTokens : read / write / admin 토큰으로 인증 활성화
+// 토큰은 "<role>-token" (ex. "Bearer write-token")
+//
+func setUpTestTokens(t *testing.T) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	tokenFile, err := ioutil.TempFile("", "tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+
+	for _, eachRole := range []auth.Role{auth.RoleRead, auth.RoleWrite, auth.RoleAdmin} {
+		tokenFile.WriteString(auth.HashToken(eachRole.String()+"-token") + " " + eachRole.String() + "\n")
+	}
+	tokenFile.Close()
+
+	if err := auth.LoadTokenFile(tokenFile.Name()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckRequestRoles(t *testing.T) {
+
+	setUpTestTokens(t)
+
+	testCases := []struct {
+		method        string
+		authorization string
+		expected      codes.Code
+	}{
+		{"/hashinterface.v1.HashData/Get", "", codes.Unauthenticated},
+		{"/hashinterface.v1.HashData/Get", "Bearer unknown-token", codes.Unauthenticated},
+		{"/hashinterface.v1.HashData/Get", "Bearer read-token", codes.OK},
+		{"/hashinterface.v1.HashData/Watch", "Bearer read-token", codes.OK},
+		{"/hashinterface.v1.HashData/Set", "Bearer read-token", codes.PermissionDenied},
+		{"/hashinterface.v1.HashData/BatchSet", "Bearer write-token", codes.OK},
+		{"/hashinterface.v1.HashData/Delete", "Bearer admin-token", codes.OK},
+		{"/hashinterface.v1.HashAdmin/AddClient", "Bearer write-token", codes.PermissionDenied},
+		{"/hashinterface.v1.HashAdmin/GetClients", "Bearer admin-token", codes.OK},
+		// 등록되지 않은 메소드는 admin 권한 필요
+		{"/hashinterface.v1.HashAdmin/Unknown", "Bearer write-token", codes.PermissionDenied},
+	}
+
+	for _, eachCase := range testCases {
+
+		ctx := context.Background()
+		if eachCase.authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", eachCase.authorization))
+		}
+
+		if code := status.Code(checkRequest(ctx, eachCase.method)); code != eachCase.expected {
+			t.Errorf("%s (%q) : expected %s, got %s", eachCase.method, eachCase.authorization, eachCase.expected, code)
+		}
+	}
+}
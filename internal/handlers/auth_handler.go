@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/models/response"
+	"hash_interface/tools"
+)
+
+type contextKey string
+
+// identityContextKey : 인증된 auth.Identity 를 요청 Context 에 저장하는 키
+const identityContextKey contextKey = "identity"
+
+// RequireRole : @requiredRole 이상의 권한을 가진 토큰의 요청만 @next 핸들러로 전달
+// 인증을 명시적으로 비활성화한 경우 (AUTH_DISABLED=true) 에만 그대로 전달
+//
+func RequireRole(requiredRole auth.Role, next http.HandlerFunc) http.HandlerFunc {
+
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		if !auth.IsEnabled() {
+			next(res, req)
+			return
+		}
+
+		identity, err := auth.Authenticate(req.Header.Get(auth.HeaderName))
+		if err != nil {
+			tools.InfoLogger.Printf(
+				"RequireRole() : %s %s 인증 실패 - %s",
+				req.Method,
+				req.URL.Path,
+				err.Error(),
+			)
+
+			res.Header().Set("WWW-Authenticate", `Bearer realm="hash_interface"`)
+			responseError(res, http.StatusUnauthorized, response.CodeUnauthorized, err)
+			return
+		}
+
+		if err := identity.Authorize(requiredRole); err != nil {
+			tools.InfoLogger.Printf(
+				"RequireRole() : %s(%s) => %s %s 권한 없음 (필요 : %s)",
+				identity.Name,
+				identity.Role,
+				req.Method,
+				req.URL.Path,
+				requiredRole,
+			)
+
+			responseError(res, http.StatusForbidden, response.CodeForbidden, err)
+			return
+		}
+
+		ctx := context.WithValue(req.Context(), identityContextKey, identity)
+		next(res, req.WithContext(ctx))
+	}
+}
@@ -0,0 +1,81 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/handlers"
+	"hash_interface/internal/models/response"
+	"hash_interface/tools"
+)
+
+func TestRequireRole(t *testing.T) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	tokenFile, err := ioutil.TempFile("", "tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+
+	tokenFile.WriteString(auth.HashToken("read-token") + " read reader\n")
+	tokenFile.WriteString(auth.HashToken("admin-token") + " admin operator\n")
+	tokenFile.Close()
+
+	if err := auth.LoadTokenFile(tokenFile.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	adminOnly := handlers.RequireRole(auth.RoleAdmin, func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNoContent)
+	})
+
+	testCases := []struct {
+		name          string
+		authorization string
+		statusCode    int
+		errorCode     string
+	}{
+		{"missing token", "", http.StatusUnauthorized, response.CodeUnauthorized},
+		{"unknown token", "Bearer unknown-token", http.StatusUnauthorized, response.CodeUnauthorized},
+		{"read token", "Bearer read-token", http.StatusForbidden, response.CodeForbidden},
+		{"admin token", "Bearer admin-token", http.StatusNoContent, ""},
+	}
+
+	for _, eachCase := range testCases {
+
+		req := httptest.NewRequest(http.MethodPost, "/clients", nil)
+		if eachCase.authorization != "" {
+			req.Header.Set(auth.HeaderName, eachCase.authorization)
+		}
+
+		recorder := httptest.NewRecorder()
+		adminOnly(recorder, req)
+
+		if recorder.Code != eachCase.statusCode {
+			t.Errorf("%s : expected %d, got %d", eachCase.name, eachCase.statusCode, recorder.Code)
+			continue
+		}
+
+		if eachCase.errorCode == "" {
+			continue
+		}
+
+		errorBody := response.ErrorTemplate{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &errorBody); err != nil || errorBody.Error.Code != eachCase.errorCode {
+			t.Errorf("%s : expected %s, got %q (%v)", eachCase.name, eachCase.errorCode, recorder.Body.String(), err)
+		}
+
+		if eachCase.statusCode == http.StatusUnauthorized && recorder.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s : 401 must include WWW-Authenticate", eachCase.name)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/hash"
+	"hash_interface/internal/models/response"
+)
+
+// TopologyEpochHeader : 모든 응답에 현재 토폴로지 Epoch 를 담는 헤더
+// 클라이언트는 캐시한 슬롯 맵의 Epoch 보다 크면 GET /cluster/slots 로 다시 조회한다
+const TopologyEpochHeader = "X-Topology-Epoch"
+
+// SetTopologyEpoch : 응답 헤더에 현재 토폴로지 Epoch 설정
+//
+func SetTopologyEpoch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+
+		res.Header().Set(TopologyEpochHeader, strconv.FormatUint(cluster.TopologyEpoch(), 10))
+
+		next.ServeHTTP(res, req)
+	})
+}
+
+// @Summary Get Hash Slot Map with Topology Epoch
+// @Description ## 해쉬 슬롯 구간별 마스터 / 슬레이브 주소
+// @Description epoch 는 슬롯 할당 (마스터 추가, 장애 복구 등) 이나 마스터 슬레이브 구성이 바뀔 때마다 증가한다.
+// @Description 데이터 이동 중인 슬롯은 이동이 끝날 때까지 기존 마스터 소유로 보고한다.
+// @Produce json
+// @Router /cluster/slots [get]
+// @Security ApiToken
+// @Success 200 {object} response.SlotMapTemplate
+func GetClusterSlots(res http.ResponseWriter, req *http.Request) {
+
+	slotRanges, epoch := cluster.GetSlotRangesWithEpoch()
+
+	responseTemplate := response.SlotMapTemplate{
+		Epoch:         epoch,
+		HashAlgorithm: hash.Algorithm(),
+		Slots:         make([]response.SlotRangeResult, 0, len(slotRanges)),
+	}
+
+	for _, eachRange := range slotRanges {
+
+		slotRange := response.SlotRangeResult{
+			Start:  eachRange.Start,
+			End:    eachRange.End,
+			Master: newSlotNode(eachRange.Master),
+			Slaves: []response.SlotNode{},
+		}
+
+		for _, eachSlave := range eachRange.Slaves {
+			slotRange.Slaves = append(slotRange.Slaves, newSlotNode(eachSlave))
+		}
+
+		responseTemplate.Slots = append(responseTemplate.Slots, slotRange)
+	}
+
+	// 헤더도 응답 Body 와 같은 Epoch
+	res.Header().Set(TopologyEpochHeader, strconv.FormatUint(epoch, 10))
+
+	curMsg := fmt.Sprintf("CLUSTER SLOTS : %d ranges (epoch %d)", len(responseTemplate.Slots), epoch)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Replication Offsets of Each Slave
+// @Description ## 슬레이브 별 복제 현황
+// @Description master_offset 은 마스터의 마지막 쓰기 순번, acked_offset 은 슬레이브가 반영을 확인한 마지막 순번 (클러스터 전체 쓰기 순번)
+// @Description pending_entries 는 복제 Backlog 에서 아직 전송하지 못한 쓰기 수
+// @Description native 복제 모드는 레디스 INFO replication 의 link_status, redis_master_offset, redis_replica_offset 을 함께 응답
+// @Produce json
+// @Router /cluster/replication [get]
+// @Security ApiToken
+// @Success 200 {object} response.ReplicationTemplate
+func GetReplicationStatus(res http.ResponseWriter, req *http.Request) {
+
+	replicaStatuses := cluster.GetReplicationStatus()
+
+	responseTemplate := response.ReplicationTemplate{
+		Replicas: make([]response.ReplicaStatusResult, 0, len(replicaStatuses)),
+	}
+
+	for _, eachStatus := range replicaStatuses {
+		responseTemplate.Replicas = append(responseTemplate.Replicas, response.ReplicaStatusResult{
+			Master:         eachStatus.MasterAddress,
+			Slave:          eachStatus.SlaveAddress,
+			MasterOffset:   eachStatus.MasterOffset,
+			AckedOffset:    eachStatus.AckedOffset,
+			PendingEntries: eachStatus.PendingEntries,
+			LagMs:          int64(eachStatus.Lag / time.Millisecond),
+			IsStreaming:    eachStatus.IsStreaming,
+			LastError:      eachStatus.LastError,
+
+			Mode:               string(eachStatus.Mode),
+			LinkStatus:         eachStatus.LinkStatus,
+			RedisMasterOffset:  eachStatus.MasterReplOffset,
+			RedisReplicaOffset: eachStatus.SlaveReplOffset,
+		})
+	}
+
+	curMsg := fmt.Sprintf("REPLICATION : %d replicas", len(responseTemplate.Replicas))
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Receive Node State Change from Monitor Server
+// @Description ## 모니터 서버의 노드 상태 변경 알림 (모니터 서버가 호출)
+// @Description 인터페이스 서버는 시작 시 모니터 서버들에 이 경로를 구독한다 (MONITOR_EVENT_CALLBACK_URL).
+// @Description 마스터가 down 이면 바로 PING 으로 확인하고, 이 서버도 응답을 받지 못하면 Failover 를 시작한다.
+// @Accept json
+// @Param event body cluster.NodeStateEvent true "상태 변경"
+// @Router /monitor/events [post]
+// @Security ApiToken
+// @Success 204
+// @Failure 400 {object} response.ErrorTemplate "INVALID_BODY"
+func ReceiveNodeStateEvent(res http.ResponseWriter, req *http.Request) {
+
+	var event cluster.NodeStateEvent
+
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&event); err != nil || event.Address == "" {
+		if err == nil {
+			err = fmt.Errorf("address is required")
+		}
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
+		return
+	}
+
+	// Failover 확인은 알림 응답과 별개로 진행
+	go cluster.HandleMonitorEvent(event)
+
+	responseNoContent(res)
+}
+
+func newSlotNode(redisClient cluster.RedisClient) response.SlotNode {
+	return response.SlotNode{
+		Address: redisClient.Address,
+		ID:      cluster.NodeID(redisClient.Address),
+	}
+}
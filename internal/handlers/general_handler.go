@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"hash_interface/configs"
+	"hash_interface/internal/cluster"
 	"hash_interface/internal/models/response"
 	"hash_interface/tools"
 	"net/http"
@@ -13,7 +16,7 @@ func ExceptionHandle(res http.ResponseWriter, req *http.Request) {
 
 	err := fmt.Errorf("Not a proper path usage")
 
-	responseError(res, http.StatusTemporaryRedirect, err)
+	responseError(res, http.StatusTemporaryRedirect, response.CodeInvalidPath, err)
 
 	tools.InfoLogger.Printf(
 		"Not a proper path : %s\n",
@@ -21,12 +24,15 @@ func ExceptionHandle(res http.ResponseWriter, req *http.Request) {
 	)
 }
 
-func responseError(res http.ResponseWriter, ErrorCode int, err error) {
+// responseError : @errorCode 는 response 패키지의 고정 에러 코드 (ex. response.CodeKeyNotFound)
+//
+func responseError(res http.ResponseWriter, statusCode int, errorCode string, err error) {
 
-	responseTemplate := response.BasicTemplate{}
+	responseTemplate := response.ErrorTemplate{}
 	nextTaskMsg := fmt.Sprintf("Check the API document for proper use")
 
 	responseBody, err := responseTemplate.Marshal(
+		errorCode,
 		err.Error(),
 		nextTaskMsg,
 		configs.ApiDocumentPath,
@@ -37,10 +43,27 @@ func responseError(res http.ResponseWriter, ErrorCode int, err error) {
 	}
 
 	res.Header().Set(configs.ContentType, configs.JsonContent)
-	res.WriteHeader(ErrorCode)
+	res.WriteHeader(statusCode)
 	fmt.Fprint(res, string(responseBody))
 }
 
+// responseRedisError : 레디스 명령 실패
+// Circuit Breaker 가 열려 있거나 담당 마스터가 down 이면 503, 타임아웃이면 504, 그 외 500
+//
+func responseRedisError(res http.ResponseWriter, err error) {
+
+	switch {
+	case errors.Is(err, cluster.ErrNodeDown):
+		responseError(res, http.StatusServiceUnavailable, response.CodeNodeDown, err)
+	case errors.Is(err, cluster.ErrCircuitOpen):
+		responseError(res, http.StatusServiceUnavailable, response.CodeCircuitOpen, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		responseError(res, http.StatusGatewayTimeout, response.CodeRedisTimeout, err)
+	default:
+		responseError(res, http.StatusInternalServerError, response.CodeRedisCommand, err)
+	}
+}
+
 func responseOK(res http.ResponseWriter, responseBody []byte) {
 
 	tools.InfoLogger.Println("Response back to client Successful")
@@ -49,3 +72,20 @@ func responseOK(res http.ResponseWriter, responseBody []byte) {
 	res.WriteHeader(http.StatusOK)
 	fmt.Fprint(res, string(responseBody))
 }
+
+func responseCreated(res http.ResponseWriter, location string, responseBody []byte) {
+
+	tools.InfoLogger.Println("Response back to client Successful (Created)")
+
+	res.Header().Set("Location", location)
+	res.Header().Set(configs.ContentType, configs.JsonContent)
+	res.WriteHeader(http.StatusCreated)
+	fmt.Fprint(res, string(responseBody))
+}
+
+func responseNoContent(res http.ResponseWriter) {
+
+	tools.InfoLogger.Println("Response back to client Successful (No Content)")
+
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -2,20 +2,39 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"hash_interface/configs"
 	"hash_interface/internal/cluster"
 	"hash_interface/internal/hash"
 	"hash_interface/internal/models"
 	"hash_interface/internal/models/response"
+	"hash_interface/internal/namespace"
 	"hash_interface/tools"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/gorilla/mux"
 )
 
+/* Read Preference 요청 헤더
+ * X-Read-Preference : master / prefer-replica / replica-only (미지정 시 master)
+ * X-Max-Staleness   : 슬레이브 조회 시 허용하는 최대 복제 지연 (Go duration, 0 = 제한 없음)
+ * X-Session-Token   : 쓰기 응답으로 받은 세션 토큰, 조회 / 다음 쓰기 요청에 그대로 전달 (Read-your-writes)
+ * X-Write-Ack       : 쓰기 복제 수준 master / replica / all (미지정 시 네임스페이스 설정, 없으면 master)
+ */
+const (
+	ReadPreferenceHeader = "X-Read-Preference"
+	MaxStalenessHeader   = "X-Max-Staleness"
+	SessionTokenHeader   = "X-Session-Token"
+	WriteAckHeader       = "X-Write-Ack"
+
+	// GetResultTemplate.HandledRole
+	handledByMaster  = "master"
+	handledByReplica = "replica"
+)
+
 // SetKeyValue is a handler function for @POST, processing the reqeust
 //  1) Request Body에서 Key 값을 추출
 //  2) Hash(Key) => hashSlot Index
@@ -29,19 +48,44 @@ import (
 // @Accept json
 // @Produce json
 // @Router /hash/data [post]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Param X-Session-Token header string false "이전 쓰기 응답의 세션 토큰 (응답 토큰에 합쳐진다)"
+// @Param X-Write-Ack header string false "master / replica / all (미지정 시 네임스페이스 설정)"
 // @Param newSetData body models.DataRequestContainer true "Multiple Pairs can be set"
 // @Success 200 {object} response.SetResultTemplate
-// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 400 {object} response.ErrorTemplate "INVALID_BODY"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
+// @Failure 503 {object} response.ErrorTemplate "REPLICATION_ACK_FAILED (마스터에는 저장됨) / CIRCUIT_OPEN / NODE_DOWN"
+// @Failure 504 {object} response.ErrorTemplate "REDIS_TIMEOUT"
 func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 
 	// To check if load balancing(Round-robin) works
 	tools.InfoLogger.Printf("Interface server(IP : %s) Processing...\n", configs.CurrentIP)
 
+	targetNamespace, err := resolveNamespace(req)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
 	// 요청 Body 파싱
 	var DataRequestContainer models.DataRequestContainer
 	decoder := json.NewDecoder(req.Body)
 	if err := decoder.Decode(&DataRequestContainer); err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
+		return
+	}
+
+	sessionToken, err := cluster.ParseSessionToken(req.Header.Get(SessionTokenHeader))
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
+		return
+	}
+
+	writeAck, err := resolveWriteAck(req, targetNamespace)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
 		return
 	}
 
@@ -53,39 +97,43 @@ func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 
 		key := eachKeyValue.Key
 		value := eachKeyValue.Value
-		hashSlotIndex := hash.GetHashSlotIndex(key)
+
+		// 네임스페이스 Prefix 는 해쉬 슬롯 계산 전에 붙인다
+		storedKey, err := targetNamespace.PrefixKey(key)
+		if err != nil {
+			responseNamespaceError(res, err)
+			return
+		}
+		hashSlotIndex := hash.GetHashSlotIndex(storedKey)
 
 		tools.InfoLogger.Printf(
 			"SET Key : %s, Value : %s - 해쉬 슬롯 : %d",
-			key,
+			storedKey,
 			value,
 			hashSlotIndex,
 		)
 
-		// Key의 해쉬 슬롯을 담당하는 레디스 획득
-		// Get Redis Node which handles this hash slot
-		redisClient, err := cluster.GetRedisClient(hashSlotIndex)
+		reservation, err := targetNamespace.Reserve(key, value)
 		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+			responseNamespaceError(res, err)
 			return
 		}
 
-		// 레디스에 요청 명령 실행
-		_, err = redis.String(redisClient.Connection.Do("SET", key, value))
-		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+		// Key의 해쉬 슬롯을 담당하는 레디스에 저장, 데이터 로그 기록 & 슬레이브 전파
+		redisClient, err := cluster.SetData(req.Context(), storedKey, value, writeAck)
+		if errors.Is(err, cluster.ErrReplicationAck) {
+			// 마스터에는 저장되었으므로 할당량 / 세션 토큰에 반영
+			sessionToken.ObserveWrite(redisClient)
+			res.Header().Set(SessionTokenHeader, sessionToken.String())
+			responseError(res, http.StatusServiceUnavailable, response.CodeReplicationAck, err)
 			return
-		}
 
-		// 변경사항 데이터 로그 기록
-		err = redisClient.RecordModificationLog("SET", key, value)
-		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+		} else if err != nil {
+			reservation.Cancel()
+			responseRedisError(res, err)
 			return
 		}
-
-		// 슬레이브에게 전파
-		redisClient.ReplicateToSlave("SET", key, value)
+		sessionToken.ObserveWrite(redisClient)
 
 		responseTemplate.Results[i].NodeAdrress = redisClient.Address
 		responseTemplate.Results[i].Result = fmt.Sprintf(
@@ -109,6 +157,7 @@ func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	res.Header().Set(SessionTokenHeader, sessionToken.String())
 	responseOK(res, responseBody)
 }
 
@@ -118,12 +167,23 @@ func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 
 // @Summary Get stored Value with passed Key
 // @Description ## 요청한 Key 값에 저장된 Value 값 가져오기
+// @Description X-Read-Preference 로 조회할 노드 선택 (master / prefer-replica / replica-only, 기본값 master)
+// @Description 슬레이브 조회는 복제 지연이 X-Max-Staleness (ex. 500ms, 5s, 0 = 제한 없음, 기본값 10s) 이내인 경우만 허용한다.
 // @Accept json
 // @Produce json
 // @Router /hash/data/{key} [get]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Param X-Read-Preference header string false "master / prefer-replica / replica-only"
+// @Param X-Max-Staleness header string false "허용하는 최대 복제 지연 (Go duration)"
+// @Param X-Session-Token header string false "쓰기 응답의 세션 토큰 (Read-your-writes)"
 // @Param key path string true "Target Key"
 // @Success 200 {object} response.GetResultTemplate
-// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 400 {object} response.ErrorTemplate "INVALID_REQUEST"
+// @Failure 404 {object} response.ErrorTemplate "KEY_NOT_FOUND"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
+// @Failure 503 {object} response.ErrorTemplate "REPLICA_UNAVAILABLE / CIRCUIT_OPEN / NODE_DOWN"
+// @Failure 504 {object} response.ErrorTemplate "REDIS_TIMEOUT"
 func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 
 	// To check if load balancing(Round-robin) works
@@ -131,24 +191,34 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 
 	params := mux.Vars(req)
 	key := params["key"]
-	hashSlotIndex := hash.GetHashSlotIndex(key)
 
-	// Key의 해쉬 슬롯을 담당하는 레디스 획득
-	redisClient, err := cluster.GetRedisClient(hashSlotIndex)
+	storedKey, err := resolveStoredKey(req, key)
 	if err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseNamespaceError(res, err)
 		return
 	}
 
-	// 레디스에 요청 명령 실행
-	redisResponse, err := redis.String(redisClient.Connection.Do("GET", key))
-	if err == redis.ErrNil {
-		redisResponse = "nil(없음)"
+	readOptions, err := parseReadOptions(req)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
+		return
+	}
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 (또는 슬레이브) 에서 값 가져오기
+	readResult, err := cluster.GetDataWithOptions(req.Context(), storedKey, readOptions)
+	if err == cluster.ErrKeyNotFound {
+		responseError(res, http.StatusNotFound, response.CodeKeyNotFound, err)
+		return
+
+	} else if err == cluster.ErrReplicaUnavailable {
+		responseError(res, http.StatusServiceUnavailable, response.CodeReplicaUnavailable, err)
+		return
 
 	} else if err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseRedisError(res, err)
 		return
 	}
+	redisResponse, redisClient := readResult.Value, readResult.Client
 
 	curMsg := fmt.Sprintf(
 		"GET %s completed Success : Handled in Server(IP : %s)",
@@ -161,6 +231,11 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 	responseTemplate := response.GetResultTemplate{}
 	responseTemplate.Result = redisResponse
 	responseTemplate.NodeAdrress = redisClient.Address
+	responseTemplate.HandledRole = handledByMaster
+	if readResult.IsReplica {
+		responseTemplate.HandledRole = handledByReplica
+		responseTemplate.ReplicationLagMs = int64(readResult.ReplicationLag / time.Millisecond)
+	}
 
 	responseBody, err := responseTemplate.Marshal(
 		redisResponse,
@@ -170,22 +245,184 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 		nextLink,
 	)
 	if err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
 		return
 	}
 
 	responseOK(res, responseBody)
 }
 
+// PutValueOfKey is a handler function for @PUT, processing the reqeust
+// URI로 전달받은 Key에 요청 Body의 Value 저장
+//  - 새로 생성된 경우 201 (Created)
+//  - 기존 값을 덮어쓴 경우 204 (No Content)
+//
+
+// @Summary Create or Replace Value of passed Key
+// @Description ## 요청한 Key 에 Value 저장
+// @Description 새로 생성된 경우 **201**, 기존 값을 덮어쓴 경우 **204**
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key} [put]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Param key path string true "Target Key"
+// @Param X-Session-Token header string false "이전 쓰기 응답의 세션 토큰 (응답 토큰에 합쳐진다)"
+// @Param X-Write-Ack header string false "master / replica / all (미지정 시 네임스페이스 설정)"
+// @Param newValue body models.ValueRequestContainer true "Value to store"
+// @Success 201 {object} response.SetResultTemplate
+// @Success 204 "기존 값 덮어씀"
+// @Failure 400 {object} response.ErrorTemplate "INVALID_BODY"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
+// @Failure 503 {object} response.ErrorTemplate "REPLICATION_ACK_FAILED (마스터에는 저장됨) / CIRCUIT_OPEN / NODE_DOWN"
+// @Failure 504 {object} response.ErrorTemplate "REDIS_TIMEOUT"
+func PutValueOfKey(res http.ResponseWriter, req *http.Request) {
+
+	// To check if load balancing(Round-robin) works
+	tools.InfoLogger.Printf("Interface server(IP : %s) Processing...\n", configs.CurrentIP)
+
+	params := mux.Vars(req)
+	key := params["key"]
+
+	// 요청 Body 파싱
+	var valueRequest models.ValueRequestContainer
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&valueRequest); err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
+		return
+	}
+
+	sessionToken, err := cluster.ParseSessionToken(req.Header.Get(SessionTokenHeader))
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
+		return
+	}
+
+	targetNamespace, err := resolveNamespace(req)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
+	storedKey, err := targetNamespace.PrefixKey(key)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
+	writeAck, err := resolveWriteAck(req, targetNamespace)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
+		return
+	}
+
+	reservation, err := targetNamespace.Reserve(key, valueRequest.Value)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
+	isCreated, redisClient, err := cluster.ReplaceData(req.Context(), storedKey, valueRequest.Value, writeAck)
+	if err != nil && !errors.Is(err, cluster.ErrReplicationAck) {
+		reservation.Cancel()
+		responseRedisError(res, err)
+		return
+	}
+
+	sessionToken.ObserveWrite(redisClient)
+	res.Header().Set(SessionTokenHeader, sessionToken.String())
+
+	// 마스터에는 저장되었으므로 할당량은 유지
+	if err != nil {
+		responseError(res, http.StatusServiceUnavailable, response.CodeReplicationAck, err)
+		return
+	}
+
+	if !isCreated {
+		responseNoContent(res)
+		return
+	}
+
+	responseTemplate := response.SetResultTemplate{
+		Results: []response.RedisResult{
+			{
+				NodeAdrress: redisClient.Address,
+				Result:      fmt.Sprintf("%s %s %s", "SET", key, valueRequest.Value),
+			},
+		},
+	}
+
+	curMsg := fmt.Sprintf(
+		"PUT %s created Success : Handled in Server(IP : %s)",
+		key,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseCreated(res, req.URL.Path, responseBody)
+}
+
+// CheckKeyExist is a handler function for @HEAD, processing the reqeust
+// Body 없이 상태 코드로만 Key의 존재 여부 응답 (존재 : 200, 없음 : 404)
+//
+
+// @Summary Check whether passed Key exists
+// @Router /hash/data/{key} [head]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Param key path string true "Target Key"
+// @Success 200 "Key 존재"
+// @Failure 404 "Key 없음"
+// @Failure 500 "서버 오류"
+func CheckKeyExist(res http.ResponseWriter, req *http.Request) {
+
+	// To check if load balancing(Round-robin) works
+	tools.InfoLogger.Printf("Interface server(IP : %s) Processing...\n", configs.CurrentIP)
+
+	params := mux.Vars(req)
+	key := params["key"]
+
+	storedKey, err := resolveStoredKey(req, key)
+	if err != nil {
+		tools.ErrorLogger.Printf("CheckKeyExist() : %s", err.Error())
+		res.WriteHeader(namespaceErrorStatus(err))
+		return
+	}
+
+	isExist, _, err := cluster.IsDataExist(req.Context(), storedKey)
+	if err != nil {
+		tools.ErrorLogger.Printf("CheckKeyExist() : %s", err.Error())
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !isExist {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	res.WriteHeader(http.StatusOK)
+}
+
 // @Summary Add New Master/Slave Redis Clients
 // @Description **Slave 추가 시,** 반드시 요청 바디에 **"master_address" 필드에 타겟 노드 주소 설정**
 // @Description Master, Slave 운용하고 싶지 않은 경우, 모두 Master로 등록
+// @Description **role 이 "standby" 인 경우,** 슬레이브가 부족한 마스터 (MIN_REPLICAS_PER_MASTER 미만) 에 자동으로 슬레이브로 연결
 // @Accept json
 // @Produce json
 // @Router /clients [post]
+// @Security ApiToken
 // @Param newSetData body models.NewClientRequestContainer true "Specifying Role and Address of New Node"
 // @Success 200 {object} response.RedisListTemplate
-// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 400 {object} response.ErrorTemplate "INVALID_BODY / INVALID_REQUEST"
+// @Failure 500 {object} response.ErrorTemplate "CLIENT_SETUP_FAILED"
 func AddNewClient(res http.ResponseWriter, req *http.Request) {
 
 	// To check if load balancing(Round-robin) works
@@ -195,14 +432,14 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 	var newClientRequest models.NewClientRequestContainer
 	decoder := json.NewDecoder(req.Body)
 	if err := decoder.Decode(&newClientRequest); err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
 		return
 	}
 
 	// 요청 오류 체크
 	if newClientRequest.IsEmpty() {
 		err := fmt.Errorf("AddNewClient() : request body of 'client' is empty")
-		responseError(res, http.StatusBadRequest, err)
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
 		return
 	}
 
@@ -210,7 +447,7 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 	case cluster.MasterRole:
 		err := cluster.AddNewMaster(newClientRequest.Address)
 		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+			responseError(res, http.StatusInternalServerError, response.CodeClientSetup, err)
 			return
 		}
 
@@ -223,7 +460,7 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 				"AddNewClient() : 슬레이브 추가 에러 - %s",
 				err.Error(),
 			)
-			responseError(res, http.StatusBadRequest, err)
+			responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
 			return
 		}
 
@@ -233,7 +470,7 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 				"AddNewClient() : 슬레이브 추가 에러 - %s",
 				err.Error(),
 			)
-			responseError(res, http.StatusBadRequest, err)
+			responseError(res, http.StatusBadRequest, response.CodeInvalidRequest, err)
 			return
 		}
 
@@ -243,14 +480,26 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 				"AddNewClient() : 슬레이브 추가 에러 - %s",
 				err.Error(),
 			)
-			responseError(res, http.StatusInternalServerError, err)
+			responseError(res, http.StatusInternalServerError, response.CodeClientSetup, err)
+			return
+		}
+
+	case cluster.StandbyRole:
+
+		// 슬레이브가 부족한 마스터가 생기면 슬레이브로 연결된다
+		if err := cluster.AddNewStandby(newClientRequest.Address); err != nil {
+			tools.ErrorLogger.Printf(
+				"AddNewClient() : 대기 노드 추가 에러 - %s",
+				err.Error(),
+			)
+			responseError(res, http.StatusInternalServerError, response.CodeClientSetup, err)
 			return
 		}
 
 	default:
 		err := fmt.Errorf("AddNewClient() : 지원하지 않는 %s role", newClientRequest.Role)
 		tools.ErrorLogger.Printf(err.Error())
-		responseError(res, http.StatusBadRequest, err)
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
 		return
 	}
 
@@ -281,8 +530,9 @@ func AddNewClient(res http.ResponseWriter, req *http.Request) {
 // @Accept json
 // @Produce json
 // @Router /clients [get]
+// @Security ApiToken
 // @Success 200 {object} response.RedisListTemplate
-// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
 func GetClients(res http.ResponseWriter, req *http.Request) {
 
 	// To check if load balancing(Round-robin) works
@@ -309,3 +559,45 @@ func GetClients(res http.ResponseWriter, req *http.Request) {
 	responseOK(res, responseBody)
 
 }
+
+// resolveWriteAck : X-Write-Ack 헤더, 없으면 네임스페이스의 기본 쓰기 복제 수준
+//
+func resolveWriteAck(req *http.Request, targetNamespace *namespace.Namespace) (cluster.WriteAck, error) {
+
+	if writeAck := req.Header.Get(WriteAckHeader); writeAck != "" {
+		return cluster.ParseWriteAck(writeAck)
+	}
+
+	return cluster.ParseWriteAck(targetNamespace.DefaultWriteAck())
+}
+
+// parseReadOptions : Read Preference 요청 헤더 => cluster.ReadOptions
+//
+func parseReadOptions(req *http.Request) (cluster.ReadOptions, error) {
+
+	preference, err := cluster.ParseReadPreference(req.Header.Get(ReadPreferenceHeader))
+	if err != nil {
+		return cluster.ReadOptions{}, err
+	}
+
+	sessionToken, err := cluster.ParseSessionToken(req.Header.Get(SessionTokenHeader))
+	if err != nil {
+		return cluster.ReadOptions{}, err
+	}
+
+	readOptions := cluster.ReadOptions{
+		Preference:   preference,
+		MaxStaleness: configs.DefaultMaxStalenessMs * time.Millisecond,
+		Session:      sessionToken,
+		SessionWait:  configs.SessionWaitMs * time.Millisecond,
+	}
+
+	if maxStaleness := req.Header.Get(MaxStalenessHeader); maxStaleness != "" {
+		readOptions.MaxStaleness, err = time.ParseDuration(maxStaleness)
+		if err != nil || readOptions.MaxStaleness < 0 {
+			return cluster.ReadOptions{}, fmt.Errorf("%s 헤더 형식 오류 (ex. 500ms, 5s) : %s", MaxStalenessHeader, maxStaleness)
+		}
+	}
+
+	return readOptions, nil
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/ratelimit"
+	"hash_interface/tools"
+)
+
+// requestLimiter : API 토큰 (없으면 클라이언트 IP) 별 요청 속도 제한
+var requestLimiter *ratelimit.Limiter
+
+// concurrencyLimiter : 인터페이스 서버 전체 동시 처리 요청 수 제한
+// 마스터 별 뮤텍스 뒤에 요청이 무한히 쌓이지 않도록 한다
+var concurrencyLimiter *ratelimit.ConcurrencyLimiter
+
+var limitSettings ratelimit.Settings
+
+// trustedProxies : X-Real-IP / X-Forwarded-For 를 믿을 수 있는 프록시, 비어있으면 헤더 무시
+var trustedProxies ratelimit.TrustedProxies
+
+func init() {
+	limitSettings = ratelimit.DefaultSettings()
+
+	if requestLimiter == nil {
+		requestLimiter = ratelimit.NewLimiter(
+			limitSettings.RatePerSecond,
+			limitSettings.Burst,
+		)
+	}
+	if concurrencyLimiter == nil {
+		concurrencyLimiter = ratelimit.NewConcurrencyLimiter(
+			limitSettings.MaxConcurrent,
+		)
+	}
+}
+
+// SetUpRequestLimits : 요청 제한 설정 & 신뢰하는 프록시 설정 (서버 시작 전 호출)
+//
+func SetUpRequestLimits(settings ratelimit.Settings, proxies ratelimit.TrustedProxies) {
+
+	limitSettings = settings
+	trustedProxies = proxies
+
+	requestLimiter = ratelimit.NewLimiter(settings.RatePerSecond, settings.Burst)
+	concurrencyLimiter = ratelimit.NewConcurrencyLimiter(settings.MaxConcurrent)
+}
+
+// LimitRequests : 라우터 미들웨어
+//  1. 동시 처리 요청 수 초과 시 503 (Retry-After)
+//  2. 토큰 / IP 별 요청 속도 초과 시 429 (Retry-After)
+//
+func LimitRequests(next http.Handler) http.Handler {
+
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+
+		if !concurrencyLimiter.TryAcquire() {
+			tools.ErrorLogger.Printf(
+				"LimitRequests() : 동시 처리 요청 수 초과 (%d) - %s %s",
+				limitSettings.MaxConcurrent,
+				req.Method,
+				req.URL.Path,
+			)
+
+			err := fmt.Errorf("서버 처리 한도 초과, 잠시 후 다시 시도하세요")
+			res.Header().Set("Retry-After", "1")
+			responseError(res, http.StatusServiceUnavailable, response.CodeOverloaded, err)
+			return
+		}
+		defer concurrencyLimiter.Release()
+
+		limitKey := rateLimitKey(req)
+
+		isAllowed, retryAfter := requestLimiter.Allow(limitKey)
+		if !isAllowed {
+			tools.InfoLogger.Printf(
+				"LimitRequests() : 요청 속도 제한 (%s) - %s %s",
+				limitKey,
+				req.Method,
+				req.URL.Path,
+			)
+
+			err := fmt.Errorf("요청 속도 제한 초과 (초당 %.0f 회)", limitSettings.RatePerSecond)
+			res.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			responseError(res, http.StatusTooManyRequests, response.CodeRateLimited, err)
+			return
+		}
+
+		next.ServeHTTP(res, req)
+	})
+}
+
+// rateLimitKey : 유효한 API 토큰이면 토큰 기준, 아니면 클라이언트 IP 기준
+// 유효하지 않은 토큰을 바꿔가며 제한을 피하지 못하도록 인증된 토큰만 사용한다
+//
+func rateLimitKey(req *http.Request) string {
+
+	if auth.IsEnabled() {
+		if identity, err := auth.Authenticate(req.Header.Get(auth.HeaderName)); err == nil {
+			return "token:" + identity.TokenHash
+		}
+	}
+
+	return "ip:" + clientIP(req)
+}
+
+// clientIP : 요청을 보낸 주소, 신뢰하는 프록시 (Nginx) 를 거친 경우에만 X-Real-IP / X-Forwarded-For 사용
+// 클라이언트가 헤더를 바꿔가며 새 bucket 을 받지 못하도록 한다
+//
+func clientIP(req *http.Request) string {
+	return trustedProxies.ClientIP(
+		req.RemoteAddr,
+		req.Header.Get("X-Real-IP"),
+		req.Header.Get("X-Forwarded-For"),
+	)
+}
+
+// retryAfterSeconds : Retry-After 헤더는 초 단위 정수 (올림, 최소 1초)
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds()))))
+}
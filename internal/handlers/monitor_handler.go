@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"hash_interface/configs"
+	"hash_interface/internal/auth"
 	"hash_interface/internal/cluster"
 	"hash_interface/internal/models/response"
 	"hash_interface/tools"
@@ -10,7 +11,6 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/gorilla/mux"
 )
 
@@ -46,8 +46,8 @@ func CheckRedisNodeStatus(res http.ResponseWriter, req *http.Request) {
 	)
 
 	// 연결인 안되어있는 경우 1.
-	// 레디스 컨테이너가 죽었다 살아난 경우, 기존 Connection = nil
-	if redisClient.Connection == nil {
+	// 레디스 컨테이너가 죽었다 살아난 경우, 기존 Connection Pool = nil
+	if redisClient.Pool == nil {
 
 		err = fmt.Errorf(
 			"레디스(%s) Ping test 실패, connection nil",
@@ -60,7 +60,7 @@ func CheckRedisNodeStatus(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	result, err := redis.String(redisClient.Connection.Do("PING"))
+	result, err := redisClient.Ping()
 
 	// 연결인 안되어있는 경우 2.
 	// 레디스 컨테이너 죽어있는 경우
@@ -120,11 +120,8 @@ func RegisterNewRedis(res http.ResponseWriter, req *http.Request) {
 
 	var newRedisClient cluster.RedisClient
 
-	newRedisClient.Connection, err = redis.Dial(
-		"tcp",
-		targetRedisAddress,
-		redis.DialConnectTimeout(cluster.ConnTimeoutDuration),
-	)
+	// 인터페이스 서버와 동일한 노드 설정 (AUTH / TLS) 으로 연결
+	newRedisClient.Pool, err = cluster.NewNodePool(targetRedisAddress)
 
 	if err != nil {
 		tools.ErrorLogger.Printf(
@@ -146,6 +143,89 @@ func RegisterNewRedis(res http.ResponseWriter, req *http.Request) {
 	responseWithCurrentRedisList(res, responseBody, "RegisterNewRedis")
 }
 
+// RequireSubscriber : 구독 요청 토큰 또는 mutual TLS 로 확인된 인터페이스 서버의 요청만 @next 핸들러로 전달
+//
+func RequireSubscriber(next http.HandlerFunc) http.HandlerFunc {
+
+	return func(res http.ResponseWriter, req *http.Request) {
+
+		isClientVerified := req.TLS != nil && len(req.TLS.VerifiedChains) > 0
+
+		if err := cluster.AuthorizeSubscriber(req.Header.Get(auth.HeaderName), isClientVerified); err != nil {
+			tools.InfoLogger.Printf(
+				"RequireSubscriber() : %s %s (%s) 인증 실패 - %s",
+				req.Method,
+				req.URL.Path,
+				req.RemoteAddr,
+				err.Error(),
+			)
+
+			res.Header().Set("WWW-Authenticate", `Bearer realm="hash_interface_monitor"`)
+			responseError(res, http.StatusUnauthorized, response.CodeUnauthorized, err)
+			return
+		}
+
+		next(res, req)
+	}
+}
+
+// SubscribeNodeEvents : 노드 상태 변경 알림 구독 (인터페이스 서버가 주기적으로 갱신)
+//
+func SubscribeNodeEvents(res http.ResponseWriter, req *http.Request) {
+
+	var subscription cluster.EventSubscription
+
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&subscription); err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	if err := cluster.AddEventSubscriber(subscription.CallbackURL); err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	responseWithCurrentRedisList(res, cluster.MonitorServerResponse{}, "SubscribeNodeEvents")
+}
+
+// UnsubscribeNodeEvents : 노드 상태 변경 알림 구독 해제
+//
+func UnsubscribeNodeEvents(res http.ResponseWriter, req *http.Request) {
+
+	var subscription cluster.EventSubscription
+
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&subscription); err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	cluster.RemoveEventSubscriber(subscription.CallbackURL)
+
+	responseWithCurrentRedisList(res, cluster.MonitorServerResponse{}, "UnsubscribeNodeEvents")
+}
+
+// GetNodeHealthHistory : 레디스 노드의 최근 확인 결과 (오래된 순)
+//
+func GetNodeHealthHistory(res http.ResponseWriter, req *http.Request) {
+
+	pathVars := mux.Vars(req)
+	targetRedisAddress := pathVars["redis_address"]
+
+	responseBody, err := json.Marshal(cluster.MonitorServerResponse{
+		RedisNodeAddress: targetRedisAddress,
+		IsAlive:          cluster.GetNodeState(targetRedisAddress) != cluster.NodeDown,
+		Data:             cluster.GetHealthHistory(targetRedisAddress),
+	})
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
 func ShowCurrentRedisList(res http.ResponseWriter, req *http.Request) {
 
 	responseBody := cluster.MonitorServerResponse{}
@@ -163,7 +243,7 @@ func responseWithCurrentRedisList(res http.ResponseWriter, checkResult cluster.M
 
 	responseBody, err := json.Marshal(checkResult)
 	if err != nil {
-		responseError(res, http.StatusInternalServerError, err)
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
 		return
 	}
 
@@ -183,7 +263,7 @@ func monitorResponseError(res http.ResponseWriter, err error) {
 
 	responseBody, encodErr := json.Marshal(checkResult)
 	if encodErr != nil {
-		responseError(res, http.StatusInternalServerError, encodErr)
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, encodErr)
 		return
 	}
 
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"hash_interface/configs"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/namespace"
+	"hash_interface/tools"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary Register Namespace or Update its Quotas
+// @Description ## 네임스페이스 등록 / 할당량 변경
+// @Description 할당량 0 은 제한 없음. 이미 등록된 경우 할당량 / 기본 쓰기 복제 수준만 변경된다.
+// @Description 사용량은 등록 / 변경 시 레디스를 SCAN 하여 다시 계산한다 (할당량은 인터페이스 서버 별로 적용).
+// @Description write_ack : 요청에 X-Write-Ack 가 없을 때의 쓰기 복제 수준 (master / replica / all)
+// @Accept json
+// @Produce json
+// @Router /namespaces/{namespace} [put]
+// @Security ApiToken
+// @Param namespace path string true "Namespace name ([A-Za-z0-9_-], max 64)"
+// @Param quota body models.NamespaceRequestContainer true "Key count / bytes quota"
+// @Success 200 {object} response.NamespaceListTemplate
+// @Failure 400 {object} response.ErrorTemplate "INVALID_NAMESPACE"
+// @Failure 503 {object} response.ErrorTemplate "NODE_DOWN, CIRCUIT_OPEN"
+// @Failure 504 {object} response.ErrorTemplate "REDIS_TIMEOUT"
+func RegisterNamespace(res http.ResponseWriter, req *http.Request) {
+
+	params := mux.Vars(req)
+	name := params["namespace"]
+
+	var namespaceRequest models.NamespaceRequestContainer
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&namespaceRequest); err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
+		return
+	}
+
+	writeAck, err := cluster.ParseWriteAck(namespaceRequest.WriteAck)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidBody, err)
+		return
+	}
+
+	registered, err := namespace.Register(
+		name,
+		namespaceRequest.MaxKeys,
+		namespaceRequest.MaxBytes,
+	)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, response.CodeInvalidNamespace, err)
+		return
+	}
+	registered.SetWriteAck(string(writeAck))
+
+	// 등록 전부터 있던 Key, 다른 인터페이스 서버 / 재시작 전에 저장된 Key 를 사용량에 반영
+	registered.StartUsageScan()
+	storedKeySizes, err := cluster.ScanKeySizes(req.Context(), registered.ScanPattern())
+	if err != nil {
+		registered.CancelUsageScan()
+		responseRedisError(res, err)
+		return
+	}
+	registered.ResetUsage(storedKeySizes)
+
+	tools.InfoLogger.Printf(
+		"RegisterNamespace() : 네임스페이스(%s) 할당량 - keys : %d, bytes : %d, ack : %s",
+		name,
+		namespaceRequest.MaxKeys,
+		namespaceRequest.MaxBytes,
+		writeAck,
+	)
+
+	responseTemplate := response.NamespaceListTemplate{
+		Namespaces: []namespace.Namespace{registered.Snapshot()},
+	}
+
+	curMsg := fmt.Sprintf("네임스페이스(%s) 등록 성공", name)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Registered Namespaces with Quotas and Usage
+// @Produce json
+// @Router /namespaces [get]
+// @Security ApiToken
+// @Success 200 {object} response.NamespaceListTemplate
+func GetNamespaces(res http.ResponseWriter, req *http.Request) {
+
+	responseTemplate := response.NamespaceListTemplate{
+		Namespaces: namespace.GetAll(),
+	}
+
+	curMsg := "현재 네임스페이스"
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// ScanKeys : 요청한 네임스페이스에 속한 Key 목록 (Prefix 제거 후 반환)
+//
+
+// @Summary Scan Keys in Namespace
+// @Produce json
+// @Router /hash/keys [get]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Success 200 {object} response.KeyListTemplate
+// @Failure 404 {object} response.ErrorTemplate "NAMESPACE_NOT_FOUND"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
+func ScanKeys(res http.ResponseWriter, req *http.Request) {
+
+	targetNamespace, err := resolveNamespace(req)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
+	keys, err := scanNamespaceKeys(req.Context(), targetNamespace)
+	if err != nil {
+		responseRedisError(res, err)
+		return
+	}
+
+	responseTemplate := response.KeyListTemplate{
+		Namespace: targetNamespace.Name,
+		Keys:      keys,
+	}
+
+	curMsg := fmt.Sprintf("SCAN completed Success : %d keys", len(keys))
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// ExportData : 요청한 네임스페이스에 속한 (Key, Value) 전체 반환
+//
+
+// @Summary Export Key, Value Pairs in Namespace
+// @Produce json
+// @Router /hash/export [get]
+// @Security ApiToken
+// @Param X-Namespace header string false "Namespace (미지정 시 기본 네임스페이스)"
+// @Success 200 {object} response.ExportTemplate
+// @Failure 404 {object} response.ErrorTemplate "NAMESPACE_NOT_FOUND"
+// @Failure 500 {object} response.ErrorTemplate "서버 오류"
+func ExportData(res http.ResponseWriter, req *http.Request) {
+
+	targetNamespace, err := resolveNamespace(req)
+	if err != nil {
+		responseNamespaceError(res, err)
+		return
+	}
+
+	keys, err := scanNamespaceKeys(req.Context(), targetNamespace)
+	if err != nil {
+		responseRedisError(res, err)
+		return
+	}
+
+	responseTemplate := response.ExportTemplate{
+		Namespace: targetNamespace.Name,
+		Data:      []cluster.KeyValuePair{},
+	}
+
+	for _, eachKey := range keys {
+
+		storedKey, _ := targetNamespace.PrefixKey(eachKey)
+
+		value, _, err := cluster.GetData(req.Context(), storedKey)
+		if err == cluster.ErrKeyNotFound {
+			// 스캔 이후 삭제된 Key
+			continue
+
+		} else if err != nil {
+			responseRedisError(res, err)
+			return
+		}
+
+		responseTemplate.Data = append(responseTemplate.Data, cluster.KeyValuePair{
+			Key:   eachKey,
+			Value: value,
+		})
+	}
+
+	curMsg := fmt.Sprintf("EXPORT completed Success : %d pairs", len(responseTemplate.Data))
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, response.CodeInternal, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// resolveNamespace : 경로 변수 {namespace} 를 우선, 없으면 X-Namespace 헤더로 네임스페이스 선택
+// 둘 다 없으면 기본 네임스페이스
+//
+func resolveNamespace(req *http.Request) (*namespace.Namespace, error) {
+
+	name, isSet := mux.Vars(req)["namespace"]
+	if !isSet {
+		name = req.Header.Get(namespace.HeaderName)
+	}
+
+	return namespace.Get(name)
+}
+
+// resolveStoredKey : 요청의 네임스페이스 기준, 레디스에 저장되는 Key 반환
+//
+func resolveStoredKey(req *http.Request, key string) (string, error) {
+
+	targetNamespace, err := resolveNamespace(req)
+	if err != nil {
+		return "", err
+	}
+
+	return targetNamespace.PrefixKey(key)
+}
+
+// scanNamespaceKeys : 네임스페이스에 속한 Key 만 Prefix 를 제거하여 반환
+//
+func scanNamespaceKeys(ctx context.Context, targetNamespace *namespace.Namespace) ([]string, error) {
+
+	storedKeys, err := cluster.ScanKeys(ctx, targetNamespace.ScanPattern())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+
+	for _, eachStoredKey := range storedKeys {
+		if key, isOwned := targetNamespace.StripKey(eachStoredKey); isOwned {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func namespaceErrorStatus(err error) int {
+
+	switch err {
+	case namespace.ErrNotFound:
+		return http.StatusNotFound
+	case namespace.ErrKeyCountQuota, namespace.ErrBytesQuota:
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func responseNamespaceError(res http.ResponseWriter, err error) {
+
+	var errorCode string
+
+	switch err {
+	case namespace.ErrNotFound:
+		errorCode = response.CodeNamespaceNotFound
+	case namespace.ErrInvalidName:
+		errorCode = response.CodeInvalidNamespace
+	case namespace.ErrReservedKey:
+		errorCode = response.CodeInvalidKey
+	case namespace.ErrKeyCountQuota, namespace.ErrBytesQuota:
+		errorCode = response.CodeQuotaExceeded
+	default:
+		errorCode = response.CodeInvalidRequest
+	}
+
+	responseError(res, namespaceErrorStatus(err), errorCode, err)
+}
@@ -1,6 +1,11 @@
 package hash
 
-import "github.com/howeyc/crc16"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/howeyc/crc16"
+)
 
 /* CRC key = 16384 = 2^14
  * In polynomial : x^14
@@ -11,21 +16,90 @@ const (
 	HashSlotsNumber = 16384
 )
 
-var checkSumTable *crc16.Table
+/* 해쉬 슬롯 계산 방식 (HASH_SLOT_ALGORITHM)
+ *   - legacy (기본) : CRC16-CCITT, Key 전체 사용 (이전 버전과 같은 슬롯)
+ *   - redis : 레디스 클러스터와 동일한 CRC16-XMODEM + Hash tag, RESP redirect 모드에 필요
+ * 방식을 바꾸면 대부분의 Key 의 슬롯이 달라지므로 데이터가 있는 클러스터는 다시 분배해야 한다.
+ */
+const (
+	AlgorithmLegacy = "legacy"
+	AlgorithmRedis  = "redis"
+)
+
+var legacyTable *crc16.Table
+var redisTable *crc16.Table
+
+// algorithm : 현재 해쉬 슬롯 계산 방식
+var algorithm string
 
 func init() {
-	if checkSumTable == nil {
+	if legacyTable == nil {
 		// CRC16-CCITT 를 이용하여 Table을 만든다.
-		checkSumTable = crc16.MakeTable(crc16.CCITT)
+		legacyTable = crc16.MakeTable(crc16.CCITT)
+	}
+	if redisTable == nil {
+		// 레디스 클러스터와 동일한 CRC16-XMODEM (다항식 0x1021, 초기값 0)
+		// 클러스터 클라이언트가 계산한 슬롯과 일치해야 MOVED / ASK 가 동작한다
+		redisTable = crc16.CCITTFalseTable
+	}
+	if algorithm == "" {
+		algorithm = AlgorithmLegacy
+	}
+}
+
+// SetUpAlgorithm : 해쉬 슬롯 계산 방식 설정, 빈 문자열이면 legacy (서버 시작 전 호출)
+//
+func SetUpAlgorithm(name string) error {
+
+	switch name {
+	case "":
+		algorithm = AlgorithmLegacy
+	case AlgorithmLegacy, AlgorithmRedis:
+		algorithm = name
+	default:
+		return fmt.Errorf("지원하지 않는 해쉬 슬롯 계산 방식 : %s (%s / %s)", name, AlgorithmLegacy, AlgorithmRedis)
 	}
+
+	return nil
+}
+
+// Algorithm : 현재 해쉬 슬롯 계산 방식
+//
+func Algorithm() string {
+	return algorithm
 }
 
 // GetHashSlotIndex gets the index of Hash Slots
 // By using CRC16 with @data and Modulo 16384 (Like Redis Cluster)
 func GetHashSlotIndex(data string) uint16 {
+	return SlotOf(algorithm, data)
+}
+
+// SlotOf : @algorithmName 방식의 @data 해쉬 슬롯 (SDK 가 서버와 같은 방식으로 계산할 때 사용)
+// redis 방식은 Key 에 비어있지 않은 "{...}" (Hash tag) 가 있으면 첫 번째 태그 안의 문자열만 사용
+//
+func SlotOf(algorithmName string, data string) uint16 {
 
 	// Redis는 CRC16 의 Modulo 16384를 사용한다.
-	hashSlotIndex := crc16.Checksum([]byte(data), checkSumTable) % HashSlotsNumber
+	if algorithmName == AlgorithmRedis {
+		return crc16.Update(0, redisTable, []byte(hashTagOf(data))) % HashSlotsNumber
+	}
+
+	return crc16.Checksum([]byte(data), legacyTable) % HashSlotsNumber
+}
+
+// hashTagOf : "{user1000}.following" => "user1000"
+func hashTagOf(key string) string {
+
+	tagStart := strings.IndexByte(key, '{')
+	if tagStart < 0 {
+		return key
+	}
+
+	tagLength := strings.IndexByte(key[tagStart+1:], '}')
+	if tagLength <= 0 {
+		return key
+	}
 
-	return hashSlotIndex
+	return key[tagStart+1 : tagStart+1+tagLength]
 }
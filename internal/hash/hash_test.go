@@ -0,0 +1,53 @@
+package hash
+
+import (
+	"testing"
+
+	"github.com/howeyc/crc16"
+)
+
+// 레디스 CLUSTER KEYSLOT 결과와 비교
+func TestGetHashSlotIndex(t *testing.T) {
+
+	if err := SetUpAlgorithm(AlgorithmRedis); err != nil {
+		t.Fatal(err)
+	}
+	defer SetUpAlgorithm(AlgorithmLegacy)
+
+	for key, expectedSlot := range map[string]uint16{
+		"foo":                   12182,
+		"123456789":             12739,
+		"{user1000}.following":  GetHashSlotIndex("user1000"),
+		"{user1000}.followers":  GetHashSlotIndex("user1000"),
+		"foo{}{bar}":            GetHashSlotIndex("foo{}{bar}"),
+		"foo{{bar}}zap":         GetHashSlotIndex("{bar"),
+		"foo{bar}{zap}":         GetHashSlotIndex("bar"),
+		"namespace:{tag}value":  GetHashSlotIndex("tag"),
+		"unterminated{tag-only": GetHashSlotIndex("unterminated{tag-only"),
+	} {
+		if slot := GetHashSlotIndex(key); slot != expectedSlot {
+			t.Errorf("%q : expected slot %d, got %d", key, expectedSlot, slot)
+		}
+	}
+}
+
+// 기본 (legacy) 방식은 이전 버전과 같은 슬롯 (Hash tag 없음)
+func TestLegacyHashSlotIndex(t *testing.T) {
+
+	if Algorithm() != AlgorithmLegacy {
+		t.Fatalf("expected default algorithm %s, got %s", AlgorithmLegacy, Algorithm())
+	}
+
+	table := crc16.MakeTable(crc16.CCITT)
+
+	for _, eachKey := range []string{"foo", "123456789", "{user1000}.following"} {
+		expectedSlot := crc16.Checksum([]byte(eachKey), table) % HashSlotsNumber
+		if slot := GetHashSlotIndex(eachKey); slot != expectedSlot {
+			t.Errorf("%q : expected legacy slot %d, got %d", eachKey, expectedSlot, slot)
+		}
+	}
+
+	if err := SetUpAlgorithm("crc32"); err == nil {
+		t.Fatal("expected unsupported algorithm error")
+	}
+}
@@ -0,0 +1,622 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"unicode"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/namespace"
+	"hash_interface/tools"
+)
+
+// command : 지원하는 명령 정보
+type command struct {
+	handler func(client *client, fields []string)
+
+	// requiredRole : 인증 활성화 시 필요한 API 토큰 권한
+	requiredRole auth.Role
+}
+
+// storageRequest : set / add / replace / cas 명령 줄
+//
+//	<command> <key> <flags> <exptime> <bytes> [<cas unique>] [noreply]
+type storageRequest struct {
+	command   string
+	key       string
+	flags     uint32
+	exptime   int64
+	length    int
+	casUnique uint64
+	noReply   bool
+}
+
+// maxSwapRetry : replace / incr / decr 중 다른 요청이 먼저 값을 바꾼 경우 다시 시도하는 횟수
+const maxSwapRetry = 16
+
+var commandTable map[string]command
+
+func init() {
+	if commandTable == nil {
+		commandTable = map[string]command{
+			// 연결 관리
+			"version":   {handler: version},
+			"verbosity": {handler: verbosity},
+			"quit":      {handler: quit},
+
+			// 데이터
+			"get":     {handler: get, requiredRole: auth.RoleRead},
+			"gets":    {handler: get, requiredRole: auth.RoleRead},
+			"set":     {handler: storage, requiredRole: auth.RoleWrite},
+			"add":     {handler: storage, requiredRole: auth.RoleWrite},
+			"replace": {handler: storage, requiredRole: auth.RoleWrite},
+			"cas":     {handler: storage, requiredRole: auth.RoleWrite},
+			"delete":  {handler: deleteCommand, requiredRole: auth.RoleWrite},
+			"incr":    {handler: incrDecr, requiredRole: auth.RoleWrite},
+			"decr":    {handler: incrDecr, requiredRole: auth.RoleWrite},
+		}
+	}
+}
+
+/****************************************
+ *
+ *
+ *        Connection Commands
+ *
+ *
+ ****************************************/
+
+func version(client *client, fields []string) {
+	client.writeLine("VERSION " + Version)
+}
+
+// verbosity : 로그 수준은 인터페이스 서버 설정을 따르므로 무시한다
+//
+func verbosity(client *client, fields []string) {
+	if len(fields) > 1 && fields[len(fields)-1] == "noreply" {
+		return
+	}
+	client.writeLine("OK")
+}
+
+func quit(client *client, fields []string) {
+	client.isClosing = true
+}
+
+/****************************************
+ *
+ *
+ *        Data Commands
+ *
+ *
+ ****************************************/
+
+// get : get / gets <key>*, gets 는 CAS 값을 함께 응답
+//
+func get(client *client, fields []string) {
+
+	if len(fields) < 2 {
+		client.writeLine("ERROR")
+		return
+	}
+
+	withCas := fields[0] == "gets"
+
+	for _, eachKey := range fields[1:] {
+
+		if !isValidKey(eachKey) {
+			client.writeLine("CLIENT_ERROR bad command line format")
+			return
+		}
+
+		current, _, isFound, err := loadItem(eachKey)
+		if err != nil {
+			client.writeLine("SERVER_ERROR " + err.Error())
+			return
+		}
+
+		if !isFound {
+			continue
+		}
+
+		valueLine := "VALUE " + eachKey + " " +
+			strconv.FormatUint(uint64(current.flags), 10) + " " +
+			strconv.Itoa(len(current.data))
+		if withCas {
+			valueLine += " " + strconv.FormatUint(current.casUnique, 10)
+		}
+
+		client.writeLine(valueLine)
+		client.writer.Write(current.data)
+		client.writeLine("")
+	}
+
+	client.writeLine("END")
+}
+
+// storage : set / add / replace / cas
+//
+func storage(client *client, fields []string) {
+
+	request, errorLine := parseStorageRequest(fields, fields[0] == "cas")
+	if errorLine != "" {
+		client.writeLine(errorLine)
+		return
+	}
+
+	data, ok := client.readStorageData(request)
+	if !ok {
+		return
+	}
+
+	newItem := item{
+		flags:     request.flags,
+		expiresAt: expiresAtOf(request.exptime, time.Now()),
+		casUnique: nextCasUnique(),
+		data:      data,
+	}
+
+	result := storeItem(request, newItem)
+	if request.noReply {
+		return
+	}
+
+	client.writeLine(result)
+}
+
+// parseStorageRequest : 명령 줄 검증, 실패 시 응답할 @errorLine
+//
+func parseStorageRequest(fields []string, withCas bool) (request storageRequest, errorLine string) {
+
+	argCount := 5
+	if withCas {
+		argCount = 6
+	}
+
+	if len(fields) != argCount && len(fields) != argCount+1 {
+		return request, "ERROR"
+	}
+
+	request.command = fields[0]
+	request.key = fields[1]
+
+	if len(fields) == argCount+1 {
+		if fields[argCount] != "noreply" {
+			return request, "CLIENT_ERROR bad command line format"
+		}
+		request.noReply = true
+	}
+
+	if !isValidKey(request.key) {
+		return request, "CLIENT_ERROR bad command line format"
+	}
+
+	flags, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return request, "CLIENT_ERROR bad command line format"
+	}
+	request.flags = uint32(flags)
+
+	if request.exptime, err = strconv.ParseInt(fields[3], 10, 64); err != nil {
+		return request, "CLIENT_ERROR bad command line format"
+	}
+
+	if request.length, err = strconv.Atoi(fields[4]); err != nil || request.length < 0 {
+		return request, "CLIENT_ERROR bad data chunk"
+	}
+
+	if withCas {
+		if request.casUnique, err = strconv.ParseUint(fields[5], 10, 64); err != nil {
+			return request, "CLIENT_ERROR bad command line format"
+		}
+	}
+
+	return request, ""
+}
+
+// readStorageData : 명령 줄 다음의 데이터 블록, 실패 시 응답까지 처리하고 ok = false
+//
+func (client *client) readStorageData(request storageRequest) (data []byte, ok bool) {
+
+	if request.length > maxItemSize {
+		if err := client.discardData(request.length); err != nil {
+			client.isClosing = true
+			return nil, false
+		}
+		client.writeLine("SERVER_ERROR object too large for cache")
+		return nil, false
+	}
+
+	data, isValid, err := client.readData(request.length)
+	if err != nil {
+		client.isClosing = true
+		return nil, false
+	}
+
+	if !isValid {
+		client.writeLine("CLIENT_ERROR bad data chunk")
+		return nil, false
+	}
+
+	return data, true
+}
+
+// storeItem : 명령에 따라 저장하고 응답 줄 반환
+//   - set : 항상 저장
+//   - add : 저장되어 있지 않을 때만
+//   - replace : 저장되어 있을 때만
+//   - cas : 저장된 항목의 CAS 값이 요청과 같을 때만
+//
+// 데이터를 저장한 뒤 flags Key 를 저장한다
+//
+func storeItem(request storageRequest, newItem item) string {
+
+	targetNamespace, storedKey, err := storedKeyOf(request.key)
+	if err != nil {
+		return "CLIENT_ERROR " + err.Error()
+	}
+
+	unlockItem := lockItem(request.key)
+	defer unlockItem()
+
+	// 만료된 항목을 먼저 삭제해서 add 가 저장되고, 할당량이 새 항목 기준으로 반영되도록 한다
+	if request.command != "set" {
+		if _, _, _, err := loadItem(request.key); err != nil {
+			return "SERVER_ERROR " + err.Error()
+		}
+	}
+
+	ack, err := writeAckOf(targetNamespace)
+	if err != nil {
+		return "SERVER_ERROR " + err.Error()
+	}
+
+	value := string(newItem.data)
+
+	reservation, err := targetNamespace.Reserve(request.key, value)
+	if err == namespace.ErrKeyCountQuota || err == namespace.ErrBytesQuota {
+		return "SERVER_ERROR out of memory storing object"
+
+	} else if err != nil {
+		return "CLIENT_ERROR " + err.Error()
+	}
+
+	result, err := storeValue(request, storedKey, value, newItem.expiresAt, ack)
+	if result != "STORED" {
+		reservation.Cancel()
+		if err != nil {
+			return "SERVER_ERROR " + err.Error()
+		}
+		return result
+	}
+
+	// 복제 수준 미달 (ErrReplicationAck) 은 마스터에 반영된 상태이므로 할당량은 그대로 두고 flags Key 도 저장한다
+	if _, _, metaErr := cluster.SetDataWithExpiry(context.Background(), metaKeyOf(storedKey), newItem.encodeMeta(), newItem.expiresAt, cluster.SetAlways, ack); metaErr != nil && err == nil {
+		err = metaErr
+	}
+
+	if err != nil {
+		return "SERVER_ERROR " + err.Error()
+	}
+
+	return result
+}
+
+// storeValue : 데이터 저장, 저장되었으면 result 는 "STORED" (err 가 ErrReplicationAck 인 경우 포함)
+// @expiresAt (unix 초, 0 = 만료 없음) 은 레디스 Key 의 만료 시각으로도 설정한다
+// 다시 읽지 않는 만료 항목도 레디스에서 삭제되도록 (loadItem 의 만료 확인은 복사 / 재분배로 만료 시각이 빠진 경우 대비)
+//
+func storeValue(request storageRequest, storedKey string, value string, expiresAt int64, ack cluster.WriteAck) (result string, err error) {
+
+	switch request.command {
+	case "set":
+		isStored, _, err := cluster.SetDataWithExpiry(context.Background(), storedKey, value, expiresAt, cluster.SetAlways, ack)
+		if !isStored {
+			return "", err
+		}
+		return "STORED", err
+
+	case "add":
+		isStored, _, err := cluster.SetDataWithExpiry(context.Background(), storedKey, value, expiresAt, cluster.SetIfAbsent, ack)
+		if !isStored {
+			return "NOT_STORED", err
+		}
+		return "STORED", err
+
+	case "replace":
+		for i := 0; i < maxSwapRetry; i++ {
+			_, currentValue, isFound, err := loadItem(request.key)
+			if err != nil || !isFound {
+				return "NOT_STORED", err
+			}
+
+			isSwapped, _, err := cluster.CompareAndSwapDataWithExpiry(context.Background(), storedKey, currentValue, value, expiresAt, ack)
+			if isSwapped {
+				return "STORED", err
+			}
+			if err != nil {
+				return "", err
+			}
+		}
+		return "NOT_STORED", nil
+
+	case "cas":
+		current, currentValue, isFound, err := loadItem(request.key)
+		if err != nil {
+			return "", err
+		}
+		if !isFound {
+			return "NOT_FOUND", nil
+		}
+		if current.casUnique != request.casUnique {
+			return "EXISTS", nil
+		}
+
+		isSwapped, _, err := cluster.CompareAndSwapDataWithExpiry(context.Background(), storedKey, currentValue, value, expiresAt, ack)
+		if isSwapped {
+			return "STORED", err
+		}
+		if err != nil {
+			return "", err
+		}
+		return "EXISTS", nil
+	}
+
+	return "ERROR", nil
+}
+
+// deleteCommand : delete <key> [noreply]
+//
+func deleteCommand(client *client, fields []string) {
+
+	if len(fields) != 2 && !(len(fields) == 3 && fields[2] == "noreply") {
+		client.writeLine("CLIENT_ERROR bad command line format.  Usage: delete <key> [noreply]")
+		return
+	}
+
+	key := fields[1]
+	noReply := len(fields) == 3
+
+	result := "DELETED"
+
+	if isDeleted, err := deleteItem(key); err != nil {
+		result = "SERVER_ERROR " + err.Error()
+	} else if !isDeleted {
+		result = "NOT_FOUND"
+	}
+
+	if noReply {
+		return
+	}
+
+	client.writeLine(result)
+}
+
+func deleteItem(key string) (bool, error) {
+
+	if !isValidKey(key) {
+		return false, nil
+	}
+
+	unlockItem := lockItem(key)
+	defer unlockItem()
+
+	// 만료된 항목은 loadItem 에서 삭제되므로 NOT_FOUND
+	if _, _, isFound, err := loadItem(key); err != nil || !isFound {
+		return false, err
+	}
+
+	targetNamespace, storedKey, err := storedKeyOf(key)
+	if err != nil {
+		return false, err
+	}
+
+	ack, err := writeAckOf(targetNamespace)
+	if err != nil {
+		return false, err
+	}
+
+	isDeleted, _, err := cluster.DeleteData(context.Background(), storedKey, ack)
+	if isDeleted {
+		targetNamespace.Release(key)
+		deleteMeta(key, storedKey, ack)
+	}
+	if err != nil || !isDeleted {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deleteMeta : 데이터를 삭제한 항목의 flags Key 삭제, 실패해도 다음 저장 전까지는 데이터 해쉬가 달라 사용되지 않는다
+//
+func deleteMeta(key string, storedKey string, ack cluster.WriteAck) {
+	if _, _, err := cluster.DeleteData(context.Background(), metaKeyOf(storedKey), ack); err != nil && !errors.Is(err, cluster.ErrReplicationAck) {
+		tools.ErrorLogger.Printf("멤캐시 항목(%s) flags 삭제 실패 - %s", key, err.Error())
+	}
+}
+
+// incrDecr : incr / decr <key> <value> [noreply]
+// 64 비트 부호 없는 정수, incr 는 overflow 시 0 부터, decr 는 0 미만으로 내려가지 않는다 (memcached 와 동일)
+//
+func incrDecr(client *client, fields []string) {
+
+	if len(fields) != 3 && !(len(fields) == 4 && fields[3] == "noreply") {
+		client.writeLine("ERROR")
+		return
+	}
+
+	key := fields[1]
+	noReply := len(fields) == 4
+
+	delta, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil || !isValidKey(key) {
+		if !noReply {
+			client.writeLine("CLIENT_ERROR invalid numeric delta argument")
+		}
+		return
+	}
+
+	result := applyDelta(key, delta, fields[0] == "incr")
+	if noReply {
+		return
+	}
+
+	client.writeLine(result)
+}
+
+func applyDelta(key string, delta uint64, isIncrement bool) string {
+
+	targetNamespace, storedKey, err := storedKeyOf(key)
+	if err != nil {
+		return "CLIENT_ERROR " + err.Error()
+	}
+
+	ack, err := writeAckOf(targetNamespace)
+	if err != nil {
+		return "SERVER_ERROR " + err.Error()
+	}
+
+	unlockItem := lockItem(key)
+	defer unlockItem()
+
+	for i := 0; i < maxSwapRetry; i++ {
+
+		current, currentValue, isFound, err := loadItem(key)
+		if err != nil {
+			return "SERVER_ERROR " + err.Error()
+		}
+		if !isFound {
+			return "NOT_FOUND"
+		}
+
+		number, err := strconv.ParseUint(string(current.data), 10, 64)
+		if err != nil {
+			return "CLIENT_ERROR cannot increment or decrement non-numeric value"
+		}
+
+		if isIncrement {
+			number += delta
+		} else if delta > number {
+			number = 0
+		} else {
+			number -= delta
+		}
+
+		current.data = []byte(strconv.FormatUint(number, 10))
+		current.casUnique = nextCasUnique()
+		value := string(current.data)
+
+		reservation, err := targetNamespace.Reserve(key, value)
+		if err != nil {
+			return "SERVER_ERROR out of memory storing object"
+		}
+
+		// 만료 시각은 그대로 유지
+		isSwapped, _, err := cluster.CompareAndSwapDataWithExpiry(context.Background(), storedKey, currentValue, value, cluster.KeepExpiry, ack)
+		if !isSwapped {
+			reservation.Cancel()
+			if err != nil {
+				return "SERVER_ERROR " + err.Error()
+			}
+			continue
+		}
+
+		// flags Key 가 없는 항목 (다른 프로토콜로 저장한 값) 은 CAS 값이 데이터 해쉬이므로 그대로 둔다
+		if current.hasMeta {
+			if _, _, metaErr := cluster.SetDataWithExpiry(context.Background(), metaKeyOf(storedKey), current.encodeMeta(), cluster.KeepExpiry, cluster.SetIfExist, ack); metaErr != nil && err == nil {
+				err = metaErr
+			}
+		}
+
+		if err != nil {
+			return "SERVER_ERROR " + err.Error()
+		}
+
+		return value
+	}
+
+	return "SERVER_ERROR too many concurrent updates"
+}
+
+// loadItem : 저장된 항목과 레디스 Value, 만료된 항목은 삭제하고 isFound = false
+//
+func loadItem(key string) (current item, storedValue string, isFound bool, err error) {
+
+	targetNamespace, storedKey, err := storedKeyOf(key)
+	if err != nil {
+		return item{}, "", false, err
+	}
+
+	storedValue, _, err = cluster.GetData(context.Background(), storedKey)
+	if err == cluster.ErrKeyNotFound {
+		return item{}, "", false, nil
+
+	} else if err != nil {
+		return item{}, "", false, err
+	}
+
+	meta, _, err := cluster.GetData(context.Background(), metaKeyOf(storedKey))
+	if err != nil && err != cluster.ErrKeyNotFound {
+		return item{}, "", false, err
+	}
+
+	current = decodeItem(storedValue, meta)
+
+	if current.isExpired(time.Now()) {
+		// 그 사이 다른 값이 저장되었으면 지우지 않는다 (요청한 쓰기가 아니므로 복제는 기다리지 않는다)
+		isDeleted, _, err := cluster.CompareAndDeleteData(context.Background(), storedKey, storedValue, cluster.AckMaster)
+		if err != nil {
+			tools.ErrorLogger.Printf("만료된 항목(%s) 삭제 실패 - %s", key, err.Error())
+		} else if isDeleted {
+			targetNamespace.Release(key)
+			deleteMeta(key, storedKey, cluster.AckMaster)
+		}
+		return item{}, "", false, nil
+	}
+
+	return current, storedValue, true, nil
+}
+
+// writeAckOf : 네임스페이스의 기본 쓰기 복제 수준 (HTTP / gRPC 와 동일, 멤캐시는 요청별 지정 없음)
+//
+func writeAckOf(targetNamespace *namespace.Namespace) (cluster.WriteAck, error) {
+	return cluster.ParseWriteAck(targetNamespace.DefaultWriteAck())
+}
+
+// storedKeyOf : 기본 네임스페이스 기준 레디스에 저장되는 Key
+//
+func storedKeyOf(key string) (*namespace.Namespace, string, error) {
+
+	defaultNamespace, err := namespace.Get("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	storedKey, err := defaultNamespace.PrefixKey(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return defaultNamespace, storedKey, nil
+}
+
+// isValidKey : 최대 250 바이트, 공백 / 제어 문자 불가 (memcached 와 동일)
+//
+func isValidKey(key string) bool {
+
+	if len(key) == 0 || len(key) > maxKeyLength {
+		return false
+	}
+
+	for _, eachRune := range key {
+		if unicode.IsControl(eachRune) || unicode.IsSpace(eachRune) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,161 @@
+package memcache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hash_interface/internal/namespace"
+)
+
+/* 멤캐시 항목 저장 형식
+ * 데이터는 HTTP / RESP / gRPC 와 같이 Key 에 그대로 저장한다 (다른 프로토콜로 조회해도 같은 값).
+ * flags / 만료 시각 / CAS 값은 예약된 Key (metaKeyOf) 에 따로 저장한다.
+ *   <flags>:<만료 unix 초, 0 = 만료 없음>:<cas unique>:<데이터 해쉬>
+ * 두 Key 모두 만료 시각이 있으면 레디스 Key 의 만료 시각 (EXPIREAT) 으로 설정 (데이터 로그 / 슬레이브에도 전파),
+ * 읽을 때의 만료 확인은 만료 시각 없이 복사된 경우 (데이터 재분배) 를 위한 대비
+ * 데이터 해쉬가 현재 데이터와 다르면 (다른 프로토콜로 덮어쓴 값) 또는 flags Key 가 없으면 flags 0, 만료 없음으로 읽는다.
+ */
+
+const (
+	// metaKeyPrefix : flags Key 의 Prefix (서버 내부 Key 로 예약된 namespace.InternalName 사용)
+	metaKeyPrefix = namespace.InternalName + namespace.Separator + "memcache" + namespace.Separator
+
+	// relativeExptimeLimit : exptime 이 30 일 이하이면 현재 시각 기준 초, 초과하면 unix 시각 (memcached 와 동일)
+	relativeExptimeLimit = 60 * 60 * 24 * 30
+
+	// itemLockCount : 항목 Lock 개수 (Key 해쉬로 나눠 쓴다)
+	itemLockCount = 256
+)
+
+// item : 멤캐시 항목
+type item struct {
+	flags uint32
+	// expiresAt : 만료 unix 시각 (초), 0 = 만료 없음
+	expiresAt int64
+	casUnique uint64
+	data      []byte
+
+	// hasMeta : 현재 데이터의 flags Key 가 저장되어 있는지
+	hasMeta bool
+}
+
+// lastCasUnique : 마지막으로 발급한 CAS 값
+var lastCasUnique uint64
+
+// itemMutexes : 같은 Key 의 멤캐시 쓰기 (데이터, flags Key 저장) 순서 보장, 인터페이스 서버 안에서만 적용된다
+var itemMutexes []sync.Mutex
+
+func init() {
+	if itemMutexes == nil {
+		itemMutexes = make([]sync.Mutex, itemLockCount)
+	}
+}
+
+// lockItem : @key 의 항목 Lock, 반환된 함수로 해제
+//
+func lockItem(key string) func() {
+
+	keyHash := fnv.New32a()
+	keyHash.Write([]byte(key))
+
+	mutex := &itemMutexes[keyHash.Sum32()%itemLockCount]
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+// nextCasUnique : 증가하는 CAS 값 (nano 초 기준이라 재시작 후에도 이전 값과 겹치지 않는다)
+//
+func nextCasUnique() uint64 {
+
+	for {
+		last := atomic.LoadUint64(&lastCasUnique)
+
+		next := uint64(time.Now().UnixNano())
+		if next <= last {
+			next = last + 1
+		}
+
+		if atomic.CompareAndSwapUint64(&lastCasUnique, last, next) {
+			return next
+		}
+	}
+}
+
+// expiresAtOf : 요청의 @exptime 을 만료 unix 시각으로 변환, 음수이면 이미 만료
+//
+func expiresAtOf(exptime int64, now time.Time) int64 {
+
+	switch {
+	case exptime == 0:
+		return 0
+	case exptime < 0:
+		return now.Unix() - 1
+	case exptime <= relativeExptimeLimit:
+		return now.Unix() + exptime
+	default:
+		return exptime
+	}
+}
+
+func (item item) isExpired(now time.Time) bool {
+	return item.expiresAt != 0 && item.expiresAt <= now.Unix()
+}
+
+// metaKeyOf : 레디스에 저장되는 Key (@storedKey) 의 flags Key
+//
+func metaKeyOf(storedKey string) string {
+	return metaKeyPrefix + storedKey
+}
+
+// dataHash : 데이터의 해쉬, flags Key 가 현재 데이터의 것인지 확인하고 flags Key 가 없는 데이터의 CAS 값으로 사용
+//
+func dataHash(data []byte) uint64 {
+
+	valueHash := fnv.New64a()
+	valueHash.Write(data)
+
+	return valueHash.Sum64()
+}
+
+// encodeMeta : flags Key 에 저장할 Value
+//
+func (item item) encodeMeta() string {
+	return fmt.Sprintf("%d:%d:%d:%d", item.flags, item.expiresAt, item.casUnique, dataHash(item.data))
+}
+
+// decodeItem : 저장된 데이터 @value 와 flags Key 의 값 @meta 를 항목으로 변환
+// @meta 가 없거나 (빈 문자열) 다른 데이터의 것이면 flags 0, 만료 없음, CAS 값은 데이터 해쉬 (값이 바뀌면 달라진다)
+//
+func decodeItem(value string, meta string) item {
+
+	current := item{data: []byte(value)}
+
+	fields := strings.Split(meta, ":")
+	if len(fields) != 4 {
+		current.casUnique = dataHash(current.data)
+		return current
+	}
+
+	flags, flagsErr := strconv.ParseUint(fields[0], 10, 32)
+	expiresAt, expiresAtErr := strconv.ParseInt(fields[1], 10, 64)
+	casUnique, casErr := strconv.ParseUint(fields[2], 10, 64)
+	metaDataHash, hashErr := strconv.ParseUint(fields[3], 10, 64)
+
+	if flagsErr != nil || expiresAtErr != nil || casErr != nil || hashErr != nil || metaDataHash != dataHash(current.data) {
+		current.casUnique = dataHash(current.data)
+		return current
+	}
+
+	current.flags = uint32(flags)
+	current.expiresAt = expiresAt
+	current.casUnique = casUnique
+	current.hasMeta = true
+
+	return current
+}
@@ -0,0 +1,121 @@
+package memcache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestItemMetaEncodeDecode(t *testing.T) {
+
+	original := item{
+		flags:     42,
+		expiresAt: 1700000000,
+		casUnique: 123456789,
+		data:      []byte("hello world\r\n:with separators:"),
+	}
+
+	// 데이터는 그대로 저장되고 flags Key 에서 나머지를 읽는다
+	decoded := decodeItem(string(original.data), original.encodeMeta())
+
+	if decoded.flags != original.flags ||
+		decoded.expiresAt != original.expiresAt ||
+		decoded.casUnique != original.casUnique ||
+		!decoded.hasMeta ||
+		!bytes.Equal(decoded.data, original.data) {
+		t.Fatalf("decoded item mismatch : %+v", decoded)
+	}
+
+	if metaKeyOf("greeting") != "__hash_interface:memcache:greeting" {
+		t.Fatalf("unexpected meta key %q", metaKeyOf("greeting"))
+	}
+}
+
+func TestDecodeRawValue(t *testing.T) {
+
+	staleMeta := item{flags: 7, expiresAt: 1700000000, casUnique: 1, data: []byte("before")}.encodeMeta()
+
+	// HTTP / RESP 로 저장된 값 (flags Key 없음, 깨진 flags Key, 다른 데이터의 flags Key) 은 데이터 그대로, flags 0
+	for _, eachMeta := range []string{"", "broken", "1:2:3:%%%", staleMeta} {
+		for _, eachValue := range []string{"world", "mc1:1:2:3:aGVsbG8="} {
+
+			decoded := decodeItem(eachValue, eachMeta)
+
+			if string(decoded.data) != eachValue || decoded.flags != 0 || decoded.expiresAt != 0 || decoded.hasMeta {
+				t.Fatalf("raw value %q (meta %q) decoded as %+v", eachValue, eachMeta, decoded)
+			}
+
+			if decoded.casUnique != decodeItem(eachValue, "").casUnique {
+				t.Fatalf("raw value %q must have a stable cas unique", eachValue)
+			}
+		}
+	}
+
+	if decodeItem("a", "").casUnique == decodeItem("b", "").casUnique {
+		t.Fatal("different raw values must have different cas unique")
+	}
+}
+
+func TestExpiresAt(t *testing.T) {
+
+	now := time.Unix(1700000000, 0)
+
+	cases := []struct {
+		exptime   int64
+		expiresAt int64
+	}{
+		{0, 0},
+		{-1, now.Unix() - 1},
+		{60, now.Unix() + 60},
+		{relativeExptimeLimit, now.Unix() + relativeExptimeLimit},
+		{1800000000, 1800000000},
+	}
+
+	for _, eachCase := range cases {
+		if expiresAt := expiresAtOf(eachCase.exptime, now); expiresAt != eachCase.expiresAt {
+			t.Fatalf("exptime %d : expected %d, got %d", eachCase.exptime, eachCase.expiresAt, expiresAt)
+		}
+	}
+
+	if !(item{expiresAt: expiresAtOf(-1, now)}).isExpired(now) {
+		t.Fatal("negative exptime must expire immediately")
+	}
+	if (item{}).isExpired(now) {
+		t.Fatal("item without exptime must not expire")
+	}
+}
+
+func TestParseStorageRequest(t *testing.T) {
+
+	request, errorLine := parseStorageRequest([]string{"cas", "key", "5", "0", "3", "99", "noreply"}, true)
+	if errorLine != "" {
+		t.Fatal(errorLine)
+	}
+	if request.key != "key" || request.flags != 5 || request.length != 3 || request.casUnique != 99 || !request.noReply {
+		t.Fatalf("unexpected request : %+v", request)
+	}
+
+	for _, eachFields := range [][]string{
+		{"set", "key", "0", "0"},
+		{"set", "key", "-1", "0", "3"},
+		{"set", "key", "0", "0", "-3"},
+		{"set", "key", "0", "0", "3", "maybe"},
+		{"set", string(make([]byte, maxKeyLength+1)), "0", "0", "3"},
+	} {
+		if _, errorLine := parseStorageRequest(eachFields, false); errorLine == "" {
+			t.Fatalf("%q must be rejected", eachFields)
+		}
+	}
+}
+
+func TestNextCasUnique(t *testing.T) {
+
+	previous := nextCasUnique()
+	for i := 0; i < 1000; i++ {
+		next := nextCasUnique()
+		if next <= previous {
+			t.Fatalf("cas unique must increase : %d -> %d", previous, next)
+		}
+		previous = next
+	}
+}
@@ -0,0 +1,245 @@
+package memcache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"hash_interface/internal/auth"
+	"hash_interface/tools"
+)
+
+/* memcached ASCII 프로토콜 리스너
+ * memcached 클라이언트가 클러스터를 그대로 사용할 수 있도록
+ * HTTP / RESP 와 같은 경로 (해쉬 슬롯 -> 마스터, 데이터 로그, 슬레이브 전파) 로 명령을 처리한다.
+ */
+
+const (
+	// Version : version 명령 응답
+	Version = "1.6.0-hash_interface"
+
+	// maxLineLength : 명령 한 줄 최대 길이 (memcached 와 동일)
+	maxLineLength = 2048
+	// maxKeyLength : Key 최대 길이 (memcached 와 동일)
+	maxKeyLength = 250
+	// maxItemSize : 데이터 최대 크기 (memcached 기본 -I 1m)
+	maxItemSize = 1024 * 1024
+)
+
+// errLineTooLong : 명령 줄이 maxLineLength 를 넘으면 연결 종료
+var errLineTooLong = errors.New("line too long")
+
+// client : memcached 연결 하나의 상태
+type client struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+
+	// identity : SASL 대신 memcached ASCII 인증 (set <any> 0 0 <len> / "<username> <token>") 으로 인증된 API 토큰
+	identity        auth.Identity
+	isAuthenticated bool
+
+	// isClosing : quit 요청 또는 복구할 수 없는 프로토콜 에러
+	isClosing bool
+}
+
+// ListenAndServe : @address 에서 memcached 연결 대기
+// @tlsConfig 가 nil 이 아니면 TLS 리스너
+//
+func ListenAndServe(address string, tlsConfig *tls.Config) error {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	return Serve(listener)
+}
+
+// Serve : @listener 로 들어오는 연결마다 고루틴으로 처리
+//
+func Serve(listener net.Listener) error {
+
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// 일시적인 에러 (fd 부족 등) 는 계속 대기
+			if netErr, isNetErr := err.(net.Error); isNetErr && netErr.Temporary() {
+				tools.ErrorLogger.Printf("memcached 연결 수락 에러 - %s", err.Error())
+				continue
+			}
+			return err
+		}
+
+		go newClient(conn).serve()
+	}
+}
+
+func newClient(conn net.Conn) *client {
+	return &client{
+		conn:   conn,
+		reader: bufio.NewReaderSize(conn, maxLineLength),
+		writer: bufio.NewWriter(conn),
+	}
+}
+
+// serve : 연결이 끊기거나 quit 요청까지 명령 처리
+//
+func (client *client) serve() {
+
+	defer client.conn.Close()
+
+	for !client.isClosing {
+
+		line, err := client.readLine()
+		if err == errLineTooLong {
+			client.writeLine("CLIENT_ERROR line too long")
+			client.writer.Flush()
+			return
+
+		} else if err != nil {
+			if err != io.EOF {
+				tools.ErrorLogger.Printf("memcached 클라이언트(%s) 읽기 에러 - %s", client.conn.RemoteAddr(), err.Error())
+			}
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			client.writeLine("ERROR")
+		} else {
+			client.execute(fields)
+		}
+
+		// 파이프라인 요청은 응답을 모아서 전송
+		if client.reader.Buffered() > 0 {
+			continue
+		}
+
+		if err := client.writer.Flush(); err != nil {
+			return
+		}
+	}
+
+	client.writer.Flush()
+}
+
+// readLine : "\r\n" (또는 "\n") 으로 끝나는 명령 한 줄
+//
+func (client *client) readLine() (string, error) {
+
+	line, err := client.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return "", errLineTooLong
+
+	} else if err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimRight(line, "\r\n")), nil
+}
+
+// readData : 저장 명령의 데이터 블록 (@length 바이트 + "\r\n")
+// 데이터가 "\r\n" 으로 끝나지 않으면 isValid = false
+//
+func (client *client) readData(length int) (data []byte, isValid bool, err error) {
+
+	block := make([]byte, length+2)
+	if _, err := io.ReadFull(client.reader, block); err != nil {
+		return nil, false, err
+	}
+
+	if block[length] != '\r' || block[length+1] != '\n' {
+		return nil, false, nil
+	}
+
+	return block[:length], true, nil
+}
+
+// discardData : 처리하지 않는 데이터 블록을 읽어서 버린다 (다음 명령 줄과 섞이지 않도록)
+//
+func (client *client) discardData(length int) error {
+	_, err := io.CopyN(ioutil.Discard, client.reader, int64(length)+2)
+	return err
+}
+
+func (client *client) writeLine(line string) {
+	client.writer.WriteString(line)
+	client.writer.WriteString("\r\n")
+}
+
+// execute : 명령 이름, 권한 확인 후 처리
+//
+func (client *client) execute(fields []string) {
+
+	commandName := strings.ToLower(fields[0])
+
+	targetCommand, isSupported := commandTable[commandName]
+	if !isSupported {
+		client.writeLine("ERROR")
+		return
+	}
+
+	if targetCommand.requiredRole != auth.RoleNone && auth.IsEnabled() {
+		// 인증 전에는 set 명령의 데이터를 "<username> <token>" 으로 사용 (memcached ASCII 인증과 동일)
+		if !client.isAuthenticated {
+			if commandName == "set" {
+				client.authenticate(fields)
+				return
+			}
+			client.writeLine("CLIENT_ERROR unauthenticated")
+			return
+		}
+
+		if err := client.identity.Authorize(targetCommand.requiredRole); err != nil {
+			client.writeLine("CLIENT_ERROR permission denied")
+			return
+		}
+	}
+
+	targetCommand.handler(client, fields)
+}
+
+// authenticate : set <any> <flags> <exptime> <bytes>\r\n<username> <token>\r\n, username 은 무시한다
+//
+func (client *client) authenticate(fields []string) {
+
+	request, errorLine := parseStorageRequest(fields, false)
+	if errorLine != "" {
+		client.writeLine(errorLine)
+		return
+	}
+
+	data, ok := client.readStorageData(request)
+	if !ok {
+		return
+	}
+
+	credentials := strings.Fields(string(data))
+	if len(credentials) != 2 {
+		client.writeLine("CLIENT_ERROR authentication failure")
+		return
+	}
+
+	identity, err := auth.Authenticate(auth.BearerPrefix + credentials[1])
+	if err != nil {
+		client.writeLine("CLIENT_ERROR authentication failure")
+		return
+	}
+
+	client.identity = identity
+	client.isAuthenticated = true
+
+	client.writeLine("STORED")
+}
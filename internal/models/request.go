@@ -8,11 +8,24 @@ type DataRequestContainer struct {
 	Data []cluster.KeyValuePair `json:"data"`
 }
 
+// ValueRequestContainer : URI 로 Key 를 전달하는 요청(PUT)의 Body
+type ValueRequestContainer struct {
+	Value string `json:"value"`
+}
+
+// NamespaceRequestContainer : 네임스페이스 할당량 설정, 0 이면 제한 없음
+type NamespaceRequestContainer struct {
+	MaxKeys  int64 `json:"max_keys"`
+	MaxBytes int64 `json:"max_bytes"`
+	// WriteAck : 기본 쓰기 복제 수준 (master / replica / all), 비어있으면 master
+	WriteAck string `json:"write_ack"`
+}
+
 type NewClientRequestContainer struct {
 	// Address : 레디스 노드 주소, IP + Port
 	Address string `json:"address"`
 
-	// Role : "Master" / "Slave"
+	// Role : "Master" / "Slave" / "Standby" (슬레이브가 부족한 마스터에 자동 연결)
 	Role string `json:"role"`
 
 	// MasterAddress : Role = "Slave" 일 때 반드시 필요한 옵션 (Role = "Master" 일 경우 무시)
@@ -16,7 +16,7 @@ type BasicTemplate struct {
 
 type NextLink struct {
 	Message string `json:"message"`
-	Href    string `json:"href`
+	Href    string `json:"href"`
 }
 
 type RedisListTemplate struct {
@@ -0,0 +1,81 @@
+package response
+
+import (
+	"encoding/json"
+)
+
+// SlotNode : 슬롯 구간을 담당하는 레디스 노드
+type SlotNode struct {
+	Address string `json:"address"`
+	// ID : 레디스 클러스터 노드 ID 형식 (CLUSTER NODES 와 동일)
+	ID string `json:"id"`
+}
+
+// SlotRangeResult : [Start, End] (End 포함) 구간
+type SlotRangeResult struct {
+	Start  uint16     `json:"start"`
+	End    uint16     `json:"end"`
+	Master SlotNode   `json:"master"`
+	Slaves []SlotNode `json:"slaves"`
+}
+
+// SlotMapTemplate : 해쉬 슬롯 맵과 토폴로지 Epoch (슬롯 할당 / 마스터 슬레이브 구성이 바뀔 때마다 증가)
+// HashAlgorithm : 해쉬 슬롯 계산 방식 (legacy / redis), 클라이언트가 Key 의 슬롯을 같은 방식으로 계산하도록
+type SlotMapTemplate struct {
+	Epoch         uint64            `json:"epoch"`
+	HashAlgorithm string            `json:"hash_algorithm"`
+	Slots         []SlotRangeResult `json:"slots"`
+	BasicTemplate
+}
+
+func (template SlotMapTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+// ReplicaStatusResult : (마스터, 슬레이브) 쌍의 복제 현황
+type ReplicaStatusResult struct {
+	Master string `json:"master"`
+	Slave  string `json:"slave"`
+	// MasterOffset : 마스터의 마지막 쓰기 순번, AckedOffset : 슬레이브가 반영을 확인한 마지막 순번
+	MasterOffset   uint64 `json:"master_offset"`
+	AckedOffset    uint64 `json:"acked_offset"`
+	PendingEntries int    `json:"pending_entries"`
+	LagMs          int64  `json:"lag_ms"`
+	IsStreaming    bool   `json:"is_streaming"`
+	LastError      string `json:"last_error,omitempty"`
+	// Mode : 복제 모드 (stream / native), native 모드는 레디스 INFO replication 의 링크 상태와 Offset 을 함께 응답
+	Mode               string `json:"mode"`
+	LinkStatus         string `json:"link_status,omitempty"`
+	RedisMasterOffset  int64  `json:"redis_master_offset,omitempty"`
+	RedisReplicaOffset int64  `json:"redis_replica_offset,omitempty"`
+}
+
+// ReplicationTemplate : 슬레이브 별 복제 현황
+type ReplicationTemplate struct {
+	Replicas []ReplicaStatusResult `json:"replicas"`
+	BasicTemplate
+}
+
+func (template ReplicationTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"encoding/json"
+)
+
+/* Error Codes
+ * 클라이언트가 메세지 문자열 대신 분기할 수 있는 고정된 에러 코드
+ * 한 번 정해진 코드 값은 변경하지 않는다.
+ */
+const (
+	CodeInvalidPath    = "INVALID_PATH"
+	CodeInvalidBody    = "INVALID_BODY"
+	CodeInvalidRequest = "INVALID_REQUEST"
+	CodeKeyNotFound    = "KEY_NOT_FOUND"
+	CodeRedisCommand   = "REDIS_COMMAND_FAILED"
+	CodeClientSetup    = "CLIENT_SETUP_FAILED"
+	CodeInternal       = "INTERNAL_ERROR"
+
+	CodeNamespaceNotFound = "NAMESPACE_NOT_FOUND"
+	CodeInvalidNamespace  = "INVALID_NAMESPACE"
+	CodeInvalidKey        = "INVALID_KEY"
+	CodeQuotaExceeded     = "QUOTA_EXCEEDED"
+
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+
+	CodeRateLimited = "RATE_LIMITED"
+	CodeOverloaded  = "SERVER_OVERLOADED"
+
+	// CodeReplicaUnavailable : replica-only 조회에 사용할 수 있는 슬레이브가 없음
+	CodeReplicaUnavailable = "REPLICA_UNAVAILABLE"
+	// CodeReplicationAck : 요청한 쓰기 복제 수준 (ack) 을 만족하지 못함, 마스터에는 저장된 상태
+	CodeReplicationAck = "REPLICATION_ACK_FAILED"
+
+	// CodeWatchLagged : gRPC Watch 구독자가 변경 사항을 제때 받지 못해 스트림 종료
+	CodeWatchLagged = "WATCH_LAGGED"
+
+	// CodeCircuitOpen : 담당 노드의 Circuit Breaker 가 열려 명령을 보내지 않음
+	CodeCircuitOpen = "CIRCUIT_OPEN"
+	// CodeRedisTimeout : 요청 Deadline 또는 노드의 명령 타임아웃 안에 레디스가 응답하지 않음
+	CodeRedisTimeout = "REDIS_TIMEOUT"
+	// CodeNodeDown : 담당 마스터가 응답하지 않아 Failover 진행 중
+	CodeNodeDown = "NODE_DOWN"
+)
+
+type ErrorTemplate struct {
+	Error ErrorDetail `json:"error"`
+	BasicTemplate
+}
+
+type ErrorDetail struct {
+	// Code : 고정된 에러 코드 (ex. KEY_NOT_FOUND)
+	Code string `json:"code"`
+
+	// Message : 사람이 읽기 위한 에러 설명, 내용은 바뀔 수 있다
+	Message string `json:"message"`
+}
+
+func (template ErrorTemplate) Marshal(
+	code, curMsg, nextMsg, nextLink string,
+) ([]byte, error) {
+
+	template.Error.Code = code
+	template.Error.Message = curMsg
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
@@ -7,6 +7,10 @@ import (
 
 type GetResultTemplate struct {
 	RedisResult
+	// HandledRole : 값을 읽은 노드의 역할 (master / replica)
+	HandledRole string `json:"handled_role"`
+	// ReplicationLagMs : 슬레이브에서 읽은 경우 조회 시점의 복제 지연 (ms), 마스터는 0
+	ReplicationLagMs int64 `json:"replication_lag_ms"`
 	BasicTemplate
 }
 
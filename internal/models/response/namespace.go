@@ -0,0 +1,66 @@
+package response
+
+import (
+	"encoding/json"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/namespace"
+)
+
+type NamespaceListTemplate struct {
+	Namespaces []namespace.Namespace `json:"namespaces"`
+	BasicTemplate
+}
+
+type KeyListTemplate struct {
+	Namespace string   `json:"namespace"`
+	Keys      []string `json:"keys"`
+	BasicTemplate
+}
+
+type ExportTemplate struct {
+	Namespace string                 `json:"namespace"`
+	Data      []cluster.KeyValuePair `json:"data"`
+	BasicTemplate
+}
+
+func (template NamespaceListTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+func (template KeyListTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+func (template ExportTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
@@ -0,0 +1,448 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/* 네임스페이스 (테넌트)
+ * 각 팀이 같은 클러스터를 사용하면서 Key 충돌이 나지 않도록
+ * 모든 Key 앞에 "<네임스페이스>:" 를 붙여 저장한다. (해쉬 슬롯 계산 전)
+ * Key 개수 / 바이트 할당량은 인터페이스 서버 메모리에서 추적한다.
+ *   - 등록 / 할당량 변경 시 레디스를 SCAN 하여 사용량을 다시 계산 (ResetUsage), 이후에는 이 프로세스를 거친 쓰기만 반영
+ *   - SCAN 중 이 프로세스를 거친 쓰기 / 삭제는 SCAN 결과보다 우선한다 (StartUsageScan)
+ *   - 할당량은 인터페이스 서버 (프로세스) 별로 적용된다
+ */
+
+const (
+	// HeaderName : 네임스페이스를 선택하는 요청 헤더
+	HeaderName = "X-Namespace"
+
+	// Separator : 네임스페이스와 Key 사이 구분자
+	Separator = ":"
+
+	// Unlimited : 할당량 제한 없음
+	Unlimited = 0
+
+	// InternalName : 서버 내부 Key ("__hash_interface:...") 용으로 예약, 네임스페이스로 등록하거나 기본 네임스페이스에서 사용할 수 없다
+	InternalName = "__hash_interface"
+)
+
+var (
+	ErrNotFound      = errors.New("등록되지 않은 네임스페이스")
+	ErrInvalidName   = errors.New("네임스페이스 이름은 영문, 숫자, '-', '_' 로만 구성 (최대 64자)")
+	ErrKeyCountQuota = errors.New("네임스페이스 Key 개수 할당량 초과")
+	ErrBytesQuota    = errors.New("네임스페이스 바이트 할당량 초과")
+	ErrReservedKey   = errors.New("다른 네임스페이스의 Key 형식은 기본 네임스페이스에서 사용 불가")
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+type Namespace struct {
+	Name string `json:"name"`
+
+	// MaxKeys, MaxBytes : 0 (Unlimited) 이면 제한 없음
+	MaxKeys  int64 `json:"max_keys"`
+	MaxBytes int64 `json:"max_bytes"`
+
+	// WriteAck : 요청에 지정하지 않은 경우의 쓰기 복제 수준 (master / replica / all), 빈 문자열이면 master
+	WriteAck string `json:"write_ack,omitempty"`
+
+	KeyCount  int64 `json:"key_count"`
+	UsedBytes int64 `json:"used_bytes"`
+
+	// keySizes : Key -> 저장된 (Key + Value) 바이트 크기
+	keySizes map[string]int64
+	mutex    *sync.Mutex
+
+	// usageScans : 진행 중인 사용량 SCAN 수, scanTouchedKeys : SCAN 시작 후 사용량이 바뀐 Key
+	usageScans      int
+	scanTouchedKeys map[string]struct{}
+}
+
+// Reservation : 저장 전 미리 반영한 할당량, 저장 실패 시 Cancel() 로 되돌린다
+type Reservation struct {
+	namespace *Namespace
+	key       string
+	prevSize  int64
+	isExisted bool
+}
+
+// registry : 네임스페이스 이름 -> 네임스페이스
+var registry map[string]*Namespace
+var registryMutex *sync.RWMutex
+
+// defaultNamespace : 네임스페이스를 지정하지 않은 요청, Key 를 변환하지 않는다
+var defaultNamespace *Namespace
+
+func init() {
+	if registry == nil {
+		registry = make(map[string]*Namespace)
+	}
+	if registryMutex == nil {
+		registryMutex = &sync.RWMutex{}
+	}
+	if defaultNamespace == nil {
+		defaultNamespace = newNamespace("", Unlimited, Unlimited)
+	}
+}
+
+func newNamespace(name string, maxKeys int64, maxBytes int64) *Namespace {
+	return &Namespace{
+		Name:     name,
+		MaxKeys:  maxKeys,
+		MaxBytes: maxBytes,
+		keySizes: make(map[string]int64),
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// Register : @name 네임스페이스 등록, 이미 존재하면 할당량만 변경
+//
+func Register(name string, maxKeys int64, maxBytes int64) (*Namespace, error) {
+
+	if !validName.MatchString(name) || name == InternalName {
+		return nil, ErrInvalidName
+	}
+
+	if maxKeys < 0 || maxBytes < 0 {
+		return nil, fmt.Errorf("할당량은 0 (무제한) 이상이어야 합니다")
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if namespace, isExist := registry[name]; isExist {
+		namespace.mutex.Lock()
+		namespace.MaxKeys = maxKeys
+		namespace.MaxBytes = maxBytes
+		namespace.mutex.Unlock()
+
+		return namespace, nil
+	}
+
+	registry[name] = newNamespace(name, maxKeys, maxBytes)
+
+	return registry[name], nil
+}
+
+// Get : @name 네임스페이스 반환, 빈 문자열이면 기본 네임스페이스
+//
+func Get(name string) (*Namespace, error) {
+
+	if name == "" {
+		return defaultNamespace, nil
+	}
+
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	namespace, isExist := registry[name]
+	if !isExist {
+		return nil, ErrNotFound
+	}
+
+	return namespace, nil
+}
+
+// GetAll : 등록된 모든 네임스페이스의 현재 상태 (이름 순)
+//
+func GetAll() []Namespace {
+
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	namespaces := []Namespace{}
+
+	for _, eachNamespace := range registry {
+		namespaces = append(namespaces, eachNamespace.Snapshot())
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+
+	return namespaces
+}
+
+// Snapshot : 할당량, 사용량만 복사한 값 (JSON 응답용)
+func (namespace *Namespace) Snapshot() Namespace {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	return Namespace{
+		Name:      namespace.Name,
+		MaxKeys:   namespace.MaxKeys,
+		MaxBytes:  namespace.MaxBytes,
+		WriteAck:  namespace.WriteAck,
+		KeyCount:  namespace.KeyCount,
+		UsedBytes: namespace.UsedBytes,
+	}
+}
+
+// SetWriteAck : 기본 쓰기 복제 수준 변경 (값 검증은 호출하는 쪽에서)
+//
+func (namespace *Namespace) SetWriteAck(writeAck string) {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	namespace.WriteAck = writeAck
+}
+
+// DefaultWriteAck : 기본 쓰기 복제 수준
+//
+func (namespace *Namespace) DefaultWriteAck() string {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	return namespace.WriteAck
+}
+
+func (namespace *Namespace) IsDefault() bool {
+	return namespace.Name == ""
+}
+
+// PrefixKey : 사용자 Key => 레디스에 저장되는 Key
+//
+func (namespace *Namespace) PrefixKey(key string) (string, error) {
+
+	if namespace.IsDefault() {
+		// 기본 네임스페이스에서 다른 네임스페이스의 Key 에 접근하는 것을 막는다
+		if isReservedKey(key) {
+			return "", ErrReservedKey
+		}
+		return key, nil
+	}
+
+	return namespace.Name + Separator + key, nil
+}
+
+// StripKey : 레디스에 저장된 Key => 사용자 Key
+// 네임스페이스에 속하지 않는 Key 인 경우 false
+//
+func (namespace *Namespace) StripKey(storedKey string) (string, bool) {
+
+	if namespace.IsDefault() {
+		if isReservedKey(storedKey) {
+			return "", false
+		}
+		return storedKey, true
+	}
+
+	prefix := namespace.Name + Separator
+	if !strings.HasPrefix(storedKey, prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(storedKey, prefix), true
+}
+
+// ScanPattern : SCAN MATCH 에 사용할 패턴
+// 네임스페이스 이름은 glob 특수문자를 포함하지 않는다 (validName)
+//
+func (namespace *Namespace) ScanPattern() string {
+
+	if namespace.IsDefault() {
+		return "*"
+	}
+
+	return namespace.Name + Separator + "*"
+}
+
+// Reserve : (key, value) 저장 전 할당량 확인 & 사용량 반영
+// 저장에 실패하면 반드시 Reservation.Cancel() 호출
+//
+func (namespace *Namespace) Reserve(key string, value string) (*Reservation, error) {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	newSize := int64(len(key) + len(value))
+	prevSize, isExisted := namespace.keySizes[key]
+
+	keyCount := namespace.KeyCount
+	if !isExisted {
+		keyCount++
+	}
+	usedBytes := namespace.UsedBytes - prevSize + newSize
+
+	if namespace.MaxKeys != Unlimited && keyCount > namespace.MaxKeys {
+		return nil, ErrKeyCountQuota
+	}
+
+	if namespace.MaxBytes != Unlimited && usedBytes > namespace.MaxBytes {
+		return nil, ErrBytesQuota
+	}
+
+	namespace.keySizes[key] = newSize
+	namespace.KeyCount = keyCount
+	namespace.UsedBytes = usedBytes
+	namespace.touchLocked(key)
+
+	return &Reservation{
+		namespace: namespace,
+		key:       key,
+		prevSize:  prevSize,
+		isExisted: isExisted,
+	}, nil
+}
+
+// StartUsageScan : 사용량 SCAN 시작, 이후 Reserve / Cancel / Release 된 Key 는 ResetUsage 에서 현재 값을 유지한다
+// 반드시 ResetUsage 또는 CancelUsageScan 으로 끝낸다
+//
+func (namespace *Namespace) StartUsageScan() {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	if namespace.usageScans == 0 {
+		namespace.scanTouchedKeys = make(map[string]struct{})
+	}
+	namespace.usageScans++
+}
+
+// CancelUsageScan : SCAN 실패 시 StartUsageScan 취소 (사용량은 그대로)
+//
+func (namespace *Namespace) CancelUsageScan() {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	namespace.endUsageScanLocked()
+}
+
+// ResetUsage : 사용량을 @storedKeySizes (레디스에 저장된 Key -> 바이트 크기) 기준으로 다시 계산
+// 네임스페이스에 속하지 않는 Key 는 제외, StartUsageScan 이후 사용량이 바뀐 Key 는 현재 값 유지
+//
+func (namespace *Namespace) ResetUsage(storedKeySizes map[string]int64) {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	keySizes := make(map[string]int64)
+
+	for eachStoredKey, eachSize := range storedKeySizes {
+
+		key, isOwned := namespace.StripKey(eachStoredKey)
+		if !isOwned {
+			continue
+		}
+
+		if _, isTouched := namespace.scanTouchedKeys[key]; isTouched {
+			continue
+		}
+
+		keySizes[key] = eachSize
+	}
+
+	for eachKey := range namespace.scanTouchedKeys {
+		if size, isExist := namespace.keySizes[eachKey]; isExist {
+			keySizes[eachKey] = size
+		}
+	}
+
+	usedBytes := int64(0)
+	for _, eachSize := range keySizes {
+		usedBytes += eachSize
+	}
+
+	namespace.keySizes = keySizes
+	namespace.KeyCount = int64(len(keySizes))
+	namespace.UsedBytes = usedBytes
+
+	namespace.endUsageScanLocked()
+}
+
+// touchLocked : SCAN 중이면 @key 의 사용량이 바뀌었음을 기록 (namespace.mutex 를 잡은 상태에서 호출)
+//
+func (namespace *Namespace) touchLocked(key string) {
+	if namespace.usageScans > 0 {
+		namespace.scanTouchedKeys[key] = struct{}{}
+	}
+}
+
+// endUsageScanLocked : 진행 중인 SCAN 하나 종료 (namespace.mutex 를 잡은 상태에서 호출)
+//
+func (namespace *Namespace) endUsageScanLocked() {
+
+	if namespace.usageScans == 0 {
+		return
+	}
+
+	namespace.usageScans--
+	if namespace.usageScans == 0 {
+		namespace.scanTouchedKeys = nil
+	}
+}
+
+// Cancel : Reserve() 로 반영한 사용량 되돌리기
+//
+func (reservation *Reservation) Cancel() {
+
+	namespace := reservation.namespace
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	currentSize, isExist := namespace.keySizes[reservation.key]
+	if !isExist {
+		return
+	}
+	namespace.touchLocked(reservation.key)
+
+	if reservation.isExisted {
+		namespace.keySizes[reservation.key] = reservation.prevSize
+		namespace.UsedBytes += reservation.prevSize - currentSize
+		return
+	}
+
+	delete(namespace.keySizes, reservation.key)
+	namespace.KeyCount--
+	namespace.UsedBytes -= currentSize
+}
+
+// Release : @key 삭제 후 사용량에서 제외
+//
+func (namespace *Namespace) Release(key string) {
+
+	namespace.mutex.Lock()
+	defer namespace.mutex.Unlock()
+
+	namespace.touchLocked(key)
+
+	size, isExist := namespace.keySizes[key]
+	if !isExist {
+		return
+	}
+
+	delete(namespace.keySizes, key)
+	namespace.KeyCount--
+	namespace.UsedBytes -= size
+}
+
+// isReservedKey : "<등록된 네임스페이스>:" 또는 "<InternalName>:" 로 시작하는 Key 인지 확인
+func isReservedKey(key string) bool {
+
+	separatorIndex := strings.Index(key, Separator)
+	if separatorIndex < 0 {
+		return false
+	}
+
+	if key[:separatorIndex] == InternalName {
+		return true
+	}
+
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	_, isExist := registry[key[:separatorIndex]]
+
+	return isExist
+}
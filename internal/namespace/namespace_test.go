@@ -0,0 +1,160 @@
+package namespace_test
+
+import (
+	"hash_interface/internal/namespace"
+	"testing"
+)
+
+func TestQuotaReservation(t *testing.T) {
+
+	teamA, err := namespace.Register("team-a", 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "k1" + "1234" = 6 bytes
+	firstReservation, err := teamA.Reserve("k1", "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 6 + ("k2" + "12345") = 13 bytes > 10
+	if _, err := teamA.Reserve("k2", "12345"); err != namespace.ErrBytesQuota {
+		t.Fatalf("expected bytes quota error, got %v", err)
+	}
+
+	if _, err := teamA.Reserve("k2", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Key 개수 할당량 (2) 초과
+	if _, err := teamA.Reserve("k3", ""); err != namespace.ErrKeyCountQuota {
+		t.Fatalf("expected key count quota error, got %v", err)
+	}
+
+	// 기존 Key 덮어쓰기는 Key 개수를 늘리지 않는다
+	overwrite, err := teamA.Reserve("k1", "12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overwrite.Cancel()
+	firstReservation.Cancel()
+
+	usage := teamA.Snapshot()
+	if usage.KeyCount != 1 || usage.UsedBytes != 3 {
+		t.Fatalf("unexpected usage after cancel : %+v", usage)
+	}
+}
+
+func TestKeyIsolation(t *testing.T) {
+
+	teamB, err := namespace.Register("team-b", namespace.Unlimited, namespace.Unlimited)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedKey, err := teamB.PrefixKey("foo")
+	if err != nil || storedKey != "team-b:foo" {
+		t.Fatalf("unexpected stored key %q (%v)", storedKey, err)
+	}
+
+	defaultNamespace, _ := namespace.Get("")
+
+	// 기본 네임스페이스에서 다른 네임스페이스의 Key 접근 불가
+	if _, err := defaultNamespace.PrefixKey("team-b:foo"); err != namespace.ErrReservedKey {
+		t.Fatalf("expected reserved key error, got %v", err)
+	}
+
+	if _, isOwned := defaultNamespace.StripKey("team-b:foo"); isOwned {
+		t.Fatal("default namespace must not own team-b keys")
+	}
+
+	if key, isOwned := teamB.StripKey(storedKey); !isOwned || key != "foo" {
+		t.Fatalf("unexpected strip result %q %v", key, isOwned)
+	}
+
+	// 서버 내부 Key 는 기본 네임스페이스에서 사용 / 조회 불가, 같은 이름의 네임스페이스도 등록 불가
+	if _, err := defaultNamespace.PrefixKey(namespace.InternalName + ":resync_position"); err != namespace.ErrReservedKey {
+		t.Fatalf("expected reserved key error for internal key, got %v", err)
+	}
+	if _, isOwned := defaultNamespace.StripKey(namespace.InternalName + ":resync_position"); isOwned {
+		t.Fatal("default namespace must not own internal keys")
+	}
+	if _, err := namespace.Register(namespace.InternalName, namespace.Unlimited, namespace.Unlimited); err != namespace.ErrInvalidName {
+		t.Fatalf("expected invalid name error for internal namespace, got %v", err)
+	}
+
+	if _, err := namespace.Get("unknown"); err != namespace.ErrNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestResetUsage(t *testing.T) {
+
+	teamC, err := namespace.Register("team-c", namespace.Unlimited, namespace.Unlimited)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := teamC.Reserve("stale", "1234"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 레디스에 저장된 Key 기준으로 다시 계산, 다른 네임스페이스의 Key 는 제외
+	teamC.ResetUsage(map[string]int64{
+		"team-c:k1": 10,
+		"team-c:k2": 5,
+		"team-b:k1": 100,
+	})
+
+	usage := teamC.Snapshot()
+	if usage.KeyCount != 2 || usage.UsedBytes != 15 {
+		t.Fatalf("unexpected usage after reset : %+v", usage)
+	}
+
+	// 다시 계산한 Key 를 덮어쓰면 기존 크기를 뺀다
+	if _, err := teamC.Reserve("k1", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if usage := teamC.Snapshot(); usage.KeyCount != 2 || usage.UsedBytes != 8 {
+		t.Fatalf("unexpected usage after overwrite : %+v", usage)
+	}
+}
+
+func TestResetUsageDuringWrites(t *testing.T) {
+
+	teamD, err := namespace.Register("team-d", namespace.Unlimited, namespace.Unlimited)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := teamD.Reserve("deleted", "12345"); err != nil {
+		t.Fatal(err)
+	}
+
+	// SCAN 도중의 쓰기 / 삭제는 SCAN 결과보다 우선한다
+	teamD.StartUsageScan()
+
+	if _, err := teamD.Reserve("written", "1"); err != nil {
+		t.Fatal(err)
+	}
+	teamD.Release("deleted")
+
+	teamD.ResetUsage(map[string]int64{
+		"team-d:deleted": 12,
+		"team-d:written": 100,
+		"team-d:scanned": 3,
+	})
+
+	// scanned (3) + written (7 + 1)
+	if usage := teamD.Snapshot(); usage.KeyCount != 2 || usage.UsedBytes != 11 {
+		t.Fatalf("unexpected usage after merge : %+v", usage)
+	}
+
+	// SCAN 이 끝난 뒤에는 다시 레디스 기준으로 덮어쓴다
+	teamD.ResetUsage(map[string]int64{"team-d:written": 100})
+	if usage := teamD.Snapshot(); usage.KeyCount != 1 || usage.UsedBytes != 100 {
+		t.Fatalf("unexpected usage after reset : %+v", usage)
+	}
+}
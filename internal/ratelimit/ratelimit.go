@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+/* 요청 제한
+ * - Limiter : 키 (API 토큰 / 클라이언트 IP) 별 Token bucket
+ * - ConcurrencyLimiter : 동시에 처리 중인 전체 요청 수 제한
+ */
+
+// nowFunc : 테스트에서 시간 고정용
+var nowFunc = time.Now
+
+// bucketIdleTimeout : 이 시간 동안 요청이 없는 키의 bucket 은 제거 (메모리 제한)
+const bucketIdleTimeout = 10 * time.Minute
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// Limiter : 키 별 Token bucket, 초당 @ratePerSecond 개 충전 / 최대 @burst 개
+type Limiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	mutex     *sync.Mutex
+}
+
+func NewLimiter(ratePerSecond float64, burst int) *Limiter {
+	return &Limiter{
+		ratePerSecond: ratePerSecond,
+		burst:         float64(burst),
+		buckets:       make(map[string]*tokenBucket),
+		lastSweep:     nowFunc(),
+		mutex:         &sync.Mutex{},
+	}
+}
+
+// Allow : @key 의 bucket 에서 토큰 1개 사용
+// 토큰이 없으면 false 와 다음 토큰이 충전될 때까지의 시간 반환
+//
+func (limiter *Limiter) Allow(key string) (bool, time.Duration) {
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := nowFunc()
+	limiter.sweepIdleBuckets(now)
+
+	bucket, isExist := limiter.buckets[key]
+	if !isExist {
+		bucket = &tokenBucket{
+			tokens:     limiter.burst,
+			lastRefill: now,
+		}
+		limiter.buckets[key] = bucket
+	}
+
+	// 지난 요청 이후 경과 시간만큼 충전
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens = math.Min(limiter.burst, bucket.tokens+elapsed*limiter.ratePerSecond)
+	bucket.lastRefill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	retryAfter := time.Duration((1 - bucket.tokens) / limiter.ratePerSecond * float64(time.Second))
+
+	return false, retryAfter
+}
+
+// sweepIdleBuckets : bucketIdleTimeout 주기로 오래 사용되지 않은 bucket 제거
+// 가득 찬 bucket 과 상태가 같으므로 제거해도 결과가 바뀌지 않는다
+//
+func (limiter *Limiter) sweepIdleBuckets(now time.Time) {
+
+	if now.Sub(limiter.lastSweep) < bucketIdleTimeout {
+		return
+	}
+
+	for eachKey, eachBucket := range limiter.buckets {
+		if now.Sub(eachBucket.lastRefill) >= bucketIdleTimeout {
+			delete(limiter.buckets, eachKey)
+		}
+	}
+
+	limiter.lastSweep = now
+}
+
+// ConcurrencyLimiter : 동시에 처리 중인 요청 수를 @maxConcurrent 개로 제한
+// 자리가 없으면 대기하지 않고 바로 실패한다 (Load shedding)
+type ConcurrencyLimiter struct {
+	slots chan struct{}
+}
+
+func NewConcurrencyLimiter(maxConcurrent int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+// TryAcquire : 처리 자리 확보, 성공 시 반드시 Release() 호출
+func (limiter *ConcurrencyLimiter) TryAcquire() bool {
+	select {
+	case limiter.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (limiter *ConcurrencyLimiter) Release() {
+	<-limiter.slots
+}
+
+// InFlight : 현재 처리 중인 요청 수
+func (limiter *ConcurrencyLimiter) InFlight() int {
+	return len(limiter.slots)
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterRefill(t *testing.T) {
+
+	current := time.Unix(0, 0)
+	nowFunc = func() time.Time { return current }
+	defer func() { nowFunc = time.Now }()
+
+	// 초당 2개 충전, 최대 2개
+	limiter := NewLimiter(2, 2)
+
+	for i := 0; i < 2; i++ {
+		if isAllowed, _ := limiter.Allow("token"); !isAllowed {
+			t.Fatalf("request %d must be allowed within burst", i)
+		}
+	}
+
+	isAllowed, retryAfter := limiter.Allow("token")
+	if isAllowed {
+		t.Fatal("request over burst must be limited")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Fatalf("unexpected retry after : %v", retryAfter)
+	}
+
+	// 다른 키는 영향 없음
+	if isAllowed, _ := limiter.Allow("other"); !isAllowed {
+		t.Fatal("other key must have its own bucket")
+	}
+
+	current = current.Add(500 * time.Millisecond)
+	if isAllowed, _ := limiter.Allow("token"); !isAllowed {
+		t.Fatal("one token must be refilled after 500ms")
+	}
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+
+	limiter := NewConcurrencyLimiter(1)
+
+	if !limiter.TryAcquire() {
+		t.Fatal("first acquire must succeed")
+	}
+	if limiter.TryAcquire() {
+		t.Fatal("second acquire must fail without waiting")
+	}
+
+	limiter.Release()
+
+	if !limiter.TryAcquire() {
+		t.Fatal("acquire after release must succeed")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+
+	proxies, err := ParseTrustedProxies("10.0.0.1, 172.29.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 신뢰하지 않는 주소가 보낸 헤더는 무시
+	if ip := proxies.ClientIP("203.0.113.7:5000", "1.1.1.1", "2.2.2.2"); ip != "203.0.113.7" {
+		t.Fatalf("untrusted peer must use remote address, got %s", ip)
+	}
+
+	// 신뢰하는 프록시를 건너뛴 가장 오른쪽 주소, 클라이언트가 넣은 앞쪽 값은 무시
+	if ip := proxies.ClientIP("10.0.0.1:5000", "", "9.9.9.9, 203.0.113.7, 172.29.0.5"); ip != "203.0.113.7" {
+		t.Fatalf("unexpected forwarded client ip %s", ip)
+	}
+
+	if ip := proxies.ClientIP("172.29.0.9:5000", "203.0.113.8", ""); ip != "203.0.113.8" {
+		t.Fatalf("unexpected real ip %s", ip)
+	}
+
+	if _, err := ParseTrustedProxies("not-an-ip"); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
@@ -0,0 +1,151 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"hash_interface/configs"
+)
+
+// Settings : 요청 제한 설정, HTTP / gRPC 가 같은 값을 사용한다
+type Settings struct {
+	RatePerSecond float64
+	Burst         int
+	MaxConcurrent int
+}
+
+// DefaultSettings : 환경변수를 설정하지 않은 경우
+//
+func DefaultSettings() Settings {
+	return Settings{
+		RatePerSecond: configs.DefaultRateLimitPerSecond,
+		Burst:         configs.DefaultRateLimitBurst,
+		MaxConcurrent: configs.DefaultMaxConcurrentRequests,
+	}
+}
+
+// ParseSettings : 빈 문자열이면 기본값, 모두 0 보다 커야 한다
+//
+func ParseSettings(ratePerSecond string, burst string, maxConcurrent string) (Settings, error) {
+
+	settings := DefaultSettings()
+
+	if ratePerSecond != "" {
+		parsed, err := strconv.ParseFloat(ratePerSecond, 64)
+		if err != nil || parsed <= 0 {
+			return Settings{}, fmt.Errorf("%s 는 0 보다 큰 숫자여야 합니다 : %s", configs.RateLimitPerSecondEnv, ratePerSecond)
+		}
+		settings.RatePerSecond = parsed
+	}
+
+	if burst != "" {
+		parsed, err := strconv.Atoi(burst)
+		if err != nil || parsed <= 0 {
+			return Settings{}, fmt.Errorf("%s 는 0 보다 큰 정수여야 합니다 : %s", configs.RateLimitBurstEnv, burst)
+		}
+		settings.Burst = parsed
+	}
+
+	if maxConcurrent != "" {
+		parsed, err := strconv.Atoi(maxConcurrent)
+		if err != nil || parsed <= 0 {
+			return Settings{}, fmt.Errorf("%s 는 0 보다 큰 정수여야 합니다 : %s", configs.MaxConcurrentRequestsEnv, maxConcurrent)
+		}
+		settings.MaxConcurrent = parsed
+	}
+
+	return settings, nil
+}
+
+// TrustedProxies : X-Real-IP / X-Forwarded-For 헤더를 믿을 수 있는 프록시 (IP / CIDR)
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies : 쉼표로 구분한 IP 또는 CIDR 목록, 빈 문자열이면 신뢰하는 프록시 없음
+//
+func ParseTrustedProxies(list string) (TrustedProxies, error) {
+
+	proxies := TrustedProxies{}
+
+	for _, eachEntry := range strings.Split(list, ",") {
+
+		eachEntry = strings.TrimSpace(eachEntry)
+		if eachEntry == "" {
+			continue
+		}
+
+		if !strings.Contains(eachEntry, "/") {
+			ip := net.ParseIP(eachEntry)
+			if ip == nil {
+				return nil, fmt.Errorf("%s 형식 오류 : %s", configs.TrustedProxiesEnv, eachEntry)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(eachEntry)
+		if err != nil {
+			return nil, fmt.Errorf("%s 형식 오류 : %s", configs.TrustedProxiesEnv, eachEntry)
+		}
+		proxies = append(proxies, network)
+	}
+
+	return proxies, nil
+}
+
+// Contains : @ip 가 신뢰하는 프록시 주소인지 확인
+//
+func (proxies TrustedProxies) Contains(ip string) bool {
+
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, eachNetwork := range proxies {
+		if eachNetwork.Contains(parsedIP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIP : 요청을 보낸 클라이언트 IP
+// 직접 연결한 주소 (@remoteAddr) 가 신뢰하는 프록시일 때만 @realIP (X-Real-IP), @forwardedFor (X-Forwarded-For) 사용
+// X-Forwarded-For 는 오른쪽부터 신뢰하는 프록시를 건너뛴 첫 주소 (클라이언트가 앞쪽에 임의 값을 넣을 수 있으므로)
+//
+func (proxies TrustedProxies) ClientIP(remoteAddr string, realIP string, forwardedFor string) string {
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	if !proxies.Contains(host) {
+		return host
+	}
+
+	if forwardedFor != "" {
+		hops := strings.Split(forwardedFor, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop != "" && !proxies.Contains(hop) {
+				return hop
+			}
+		}
+	}
+
+	if realIP != "" {
+		return strings.TrimSpace(realIP)
+	}
+
+	return host
+}
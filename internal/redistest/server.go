@@ -0,0 +1,344 @@
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/* 테스트용 레디스 서버 (RESP)
+ * cluster / grpcapi 등의 테스트에서 실제 레디스 대신 사용한다.
+ * 문자열 명령 일부 (GET, SET, GETSET, DEL, EXISTS, EXPIREAT, PTTL, SCAN) 와
+ * 복제 설정 확인용 CONFIG SET, REPLICAOF 만 지원 (EVAL 미지원)
+ */
+
+// Server : 테스트용 레디스 서버
+type Server struct {
+	Address  string
+	listener net.Listener
+
+	mutex     sync.Mutex
+	values    map[string]string
+	expiresAt map[string]time.Time
+
+	// maxReplyDelay : 쓰기 명령은 반영 후 응답 전에 잠시 쉬어 동시 요청의 응답 순서가 반영 순서와 달라지게 한다
+	maxReplyDelay time.Duration
+
+	// config : CONFIG SET 으로 설정된 값, replicaOf : REPLICAOF 시점의 config 와 마스터 주소
+	config    map[string]string
+	replicaOf []string
+}
+
+// NewServer : 127.0.0.1 의 빈 포트에서 시작, Close() 로 종료
+// 포트를 열지 못하면 panic (httptest.NewServer 와 동일)
+//
+func NewServer() *Server {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("redistest: failed to listen on a port: %v", err))
+	}
+
+	server := &Server{
+		Address:   listener.Addr().String(),
+		listener:  listener,
+		values:    make(map[string]string),
+		expiresAt: make(map[string]time.Time),
+		config:    make(map[string]string),
+	}
+
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(connection)
+		}
+	}()
+
+	return server
+}
+
+func (server *Server) Close() {
+	server.listener.Close()
+}
+
+// SetMaxReplyDelay : 쓰기 명령의 응답을 최대 @delay 만큼 임의로 늦춘다
+//
+func (server *Server) SetMaxReplyDelay(delay time.Duration) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.maxReplyDelay = delay
+}
+
+// Get : 저장된 값 (만료된 Key 는 없음)
+//
+func (server *Server) Get(key string) (string, bool) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.getLocked(key)
+}
+
+// ReplicaOf : 마지막 REPLICAOF 의 마스터 주소와 그 시점의 복제 설정 ("masteruser=...", "masterauth=...", "tls-replication=...")
+//
+func (server *Server) ReplicaOf() []string {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return append([]string{}, server.replicaOf...)
+}
+
+func (server *Server) getLocked(key string) (string, bool) {
+
+	if expiresAt, isSet := server.expiresAt[key]; isSet && !time.Now().Before(expiresAt) {
+		delete(server.values, key)
+		delete(server.expiresAt, key)
+	}
+
+	value, isExist := server.values[key]
+
+	return value, isExist
+}
+
+func (server *Server) serve(connection net.Conn) {
+
+	defer connection.Close()
+
+	reader := bufio.NewReader(connection)
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		reply, replyDelay := server.execute(args)
+		if replyDelay > 0 {
+			time.Sleep(time.Duration(rand.Int63n(int64(replyDelay))))
+		}
+
+		if _, err := io.WriteString(connection, reply); err != nil {
+			return
+		}
+	}
+}
+
+// Execute : 명령 실행 후 RESP 응답
+//
+func (server *Server) Execute(args ...string) string {
+
+	reply, _ := server.execute(args)
+
+	return reply
+}
+
+// execute : 명령 실행 후 (RESP 응답, 쓰기 명령이면 응답 지연 최대값)
+//
+func (server *Server) execute(args []string) (string, time.Duration) {
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	command := strings.ToUpper(args[0])
+
+	switch {
+	case command == "PING":
+		return "+PONG\r\n", 0
+
+	case command == "GET" && len(args) == 2:
+		if value, isExist := server.getLocked(args[1]); isExist {
+			return bulkReply(value), 0
+		}
+		return "$-1\r\n", 0
+
+	case command == "EXISTS" && len(args) == 2:
+		_, isExist := server.getLocked(args[1])
+		return boolReply(isExist), 0
+
+	case command == "SET" && len(args) >= 3:
+		return server.set(args[1], args[2], args[3:]), server.maxReplyDelay
+
+	case command == "GETSET" && len(args) == 3:
+		value, isExist := server.getLocked(args[1])
+		server.values[args[1]] = args[2]
+		delete(server.expiresAt, args[1])
+		if isExist {
+			return bulkReply(value), server.maxReplyDelay
+		}
+		return "$-1\r\n", server.maxReplyDelay
+
+	case command == "DEL" && len(args) == 2:
+		_, isExist := server.getLocked(args[1])
+		delete(server.values, args[1])
+		delete(server.expiresAt, args[1])
+		return boolReply(isExist), server.maxReplyDelay
+
+	case command == "EXPIREAT" && len(args) == 3:
+		unixSeconds, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return "-ERR value is not an integer\r\n", server.maxReplyDelay
+		}
+		if _, isExist := server.getLocked(args[1]); !isExist {
+			return ":0\r\n", server.maxReplyDelay
+		}
+		server.expiresAt[args[1]] = time.Unix(unixSeconds, 0)
+		return ":1\r\n", server.maxReplyDelay
+
+	case command == "PTTL" && len(args) == 2:
+		if _, isExist := server.getLocked(args[1]); !isExist {
+			return ":-2\r\n", 0
+		}
+		expiresAt, isSet := server.expiresAt[args[1]]
+		if !isSet {
+			return ":-1\r\n", 0
+		}
+		return fmt.Sprintf(":%d\r\n", time.Until(expiresAt).Milliseconds()), 0
+
+	case command == "SCAN" && len(args) >= 2:
+		pattern := "*"
+		if len(args) >= 4 && strings.ToUpper(args[2]) == "MATCH" {
+			pattern = args[3]
+		}
+		keys := []string{}
+		for eachKey := range server.values {
+			if _, isExist := server.getLocked(eachKey); !isExist {
+				continue
+			}
+			if isMatched, _ := path.Match(pattern, eachKey); isMatched {
+				keys = append(keys, eachKey)
+			}
+		}
+		reply := "*2\r\n" + bulkReply("0") + fmt.Sprintf("*%d\r\n", len(keys))
+		for _, eachKey := range keys {
+			reply += bulkReply(eachKey)
+		}
+		return reply, 0
+
+	case command == "CONFIG" && len(args) == 4 && strings.ToUpper(args[1]) == "SET":
+		server.config[args[2]] = args[3]
+		return "+OK\r\n", 0
+
+	case command == "REPLICAOF" && len(args) == 3:
+		server.replicaOf = []string{args[1] + ":" + args[2]}
+		for _, eachName := range []string{"masteruser", "masterauth", "tls-replication"} {
+			server.replicaOf = append(server.replicaOf, eachName+"="+server.config[eachName])
+		}
+		return "+OK\r\n", 0
+
+	default:
+		return fmt.Sprintf("-ERR unsupported command '%s'\r\n", args[0]), 0
+	}
+}
+
+// set : SET key value [NX | XX] [EX seconds | PX milliseconds | KEEPTTL]
+//
+func (server *Server) set(key string, value string, options []string) string {
+
+	isIfAbsent, isIfExist, isKeepTTL := false, false, false
+	expiresAt := time.Time{}
+
+	for i := 0; i < len(options); i++ {
+		switch strings.ToUpper(options[i]) {
+		case "NX":
+			isIfAbsent = true
+		case "XX":
+			isIfExist = true
+		case "KEEPTTL":
+			isKeepTTL = true
+		case "EX", "PX":
+			if i+1 == len(options) {
+				return "-ERR syntax error\r\n"
+			}
+			amount, err := strconv.ParseInt(options[i+1], 10, 64)
+			if err != nil || amount <= 0 {
+				return "-ERR invalid expire time in 'set' command\r\n"
+			}
+			unit := time.Second
+			if strings.ToUpper(options[i]) == "PX" {
+				unit = time.Millisecond
+			}
+			expiresAt = time.Now().Add(time.Duration(amount) * unit)
+			i++
+		default:
+			return "-ERR syntax error\r\n"
+		}
+	}
+
+	_, isExist := server.getLocked(key)
+	if (isIfAbsent && isExist) || (isIfExist && !isExist) {
+		return "$-1\r\n"
+	}
+
+	server.values[key] = value
+	if !expiresAt.IsZero() {
+		server.expiresAt[key] = expiresAt
+	} else if !isKeepTTL {
+		delete(server.expiresAt, key)
+	}
+
+	return "+OK\r\n"
+}
+
+func bulkReply(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func boolReply(value bool) string {
+	if value {
+		return ":1\r\n"
+	}
+	return ":0\r\n"
+}
+
+// readCommand : RESP 배열 (*<개수> 뒤에 $<길이> 문자열) 하나 읽기
+//
+func readCommand(reader *bufio.Reader) ([]string, error) {
+
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(header, "*") {
+		return nil, fmt.Errorf("unexpected header %q", header)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("unexpected header %q", header)
+	}
+
+	args := make([]string, count)
+	for i := range args {
+
+		lengthLine, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(lengthLine, "$")))
+		if err != nil || length < 0 {
+			return nil, fmt.Errorf("unexpected length %q", lengthLine)
+		}
+
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:length])
+	}
+
+	return args, nil
+}
@@ -0,0 +1,400 @@
+package resp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"hash_interface/configs"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/hash"
+)
+
+/* 레디스 클러스터 프로토콜 흉내 (go-redis ClusterClient, Jedis 등 클러스터 클라이언트 지원)
+ * - proxy (기본) : 모든 슬롯 구간의 마스터로 이 인터페이스 서버를 알린다.
+ *                  모든 명령이 인터페이스 서버를 거치므로 데이터 로그 / 슬레이브 전파가 유지된다.
+ * - redirect : 실제 마스터 / 슬레이브 주소를 알리고, 인터페이스 서버로 온 데이터 명령은 MOVED / ASK 로 돌려보낸다.
+ *              클라이언트가 마스터에 직접 쓰면 인터페이스 서버의 데이터 로그, 슬레이브 전파를 거치지 않는다.
+ */
+
+const (
+	ClusterModeProxy    = "proxy"
+	ClusterModeRedirect = "redirect"
+)
+
+// clusterMode : ClusterModeProxy / ClusterModeRedirect
+var clusterMode = ClusterModeProxy
+
+// announceHost, announcePort : proxy 모드에서 클라이언트에게 알리는 이 서버의 RESP 주소
+var announceHost string
+var announcePort int
+
+// SetUpClusterMode : @mode (빈 문자열이면 proxy), @announceAddress ("host:port") 설정
+//
+func SetUpClusterMode(mode string, announceAddress string) error {
+
+	switch mode {
+	case "":
+		mode = ClusterModeProxy
+	case ClusterModeProxy, ClusterModeRedirect:
+	default:
+		return fmt.Errorf("지원하지 않는 RESP 클러스터 모드 : %s (%s / %s)", mode, ClusterModeProxy, ClusterModeRedirect)
+	}
+
+	// 클러스터 클라이언트는 레디스 방식으로 슬롯을 계산하므로 MOVED / ASK 가 맞으려면 같은 방식이어야 한다
+	if mode == ClusterModeRedirect && hash.Algorithm() != hash.AlgorithmRedis {
+		return fmt.Errorf(
+			"RESP 클러스터 모드 %s 는 %s=%s 필요 (현재 %s)",
+			mode,
+			configs.HashSlotAlgorithmEnv,
+			hash.AlgorithmRedis,
+			hash.Algorithm(),
+		)
+	}
+
+	host, port, err := splitAddress(announceAddress)
+	if err != nil {
+		return err
+	}
+
+	clusterMode = mode
+	announceHost = host
+	announcePort = port
+
+	return nil
+}
+
+func splitAddress(address string) (string, int, error) {
+
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, err
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return host, port, nil
+}
+
+func announceAddress() string {
+	return net.JoinHostPort(announceHost, strconv.Itoa(announcePort))
+}
+
+// redirectIfNotServed : redirect 모드에서 데이터 명령의 Key 가 속한 슬롯의 마스터로 MOVED / ASK
+// 응답을 작성했으면 true
+//
+func (client *client) redirectIfNotServed(targetCommand command, args []string) bool {
+
+	if clusterMode != ClusterModeRedirect || targetCommand.firstKey == 0 {
+		return false
+	}
+
+	keys := targetCommand.keysOf(args)
+	if len(keys) == 0 {
+		return false
+	}
+
+	slot := hash.GetHashSlotIndex(keys[0])
+	for _, eachKey := range keys[1:] {
+		if hash.GetHashSlotIndex(eachKey) != slot {
+			client.writer.WriteError("CROSSSLOT Keys in request don't hash to the same slot")
+			return true
+		}
+	}
+
+	owner, migration := cluster.GetSlotOwner(slot)
+	if owner == nil {
+		client.writer.WriteError(fmt.Sprintf("CLUSTERDOWN Hash slot %d not served", slot))
+		return true
+	}
+
+	// 이동 중인 슬롯 : Source 에 없는 Key 는 Target 으로 ASK
+	if migration != nil && len(keys) == 1 {
+		isMigrated, err := migration.IsKeyMigrated(keys[0])
+		if err != nil {
+			client.writer.WriteError("ERR " + err.Error())
+			return true
+		}
+
+		if isMigrated {
+			client.writer.WriteError(fmt.Sprintf("ASK %d %s", slot, migration.TargetAddress))
+			return true
+		}
+	}
+
+	client.writer.WriteError(fmt.Sprintf("MOVED %d %s", slot, owner.Address))
+
+	return true
+}
+
+// clusterCommand : CLUSTER SLOTS | NODES | SHARDS | INFO | MYID | KEYSLOT <key>
+//
+func clusterCommand(client *client, args []string) {
+
+	switch subCommand := strings.ToUpper(args[1]); {
+	case subCommand == "SLOTS" && len(args) == 2:
+		client.writeClusterSlots()
+	case subCommand == "NODES" && len(args) == 2:
+		client.writer.WriteBulkString(clusterNodes())
+	case subCommand == "SHARDS" && len(args) == 2:
+		client.writeClusterShards()
+	case subCommand == "INFO" && len(args) == 2:
+		client.writer.WriteBulkString(clusterInfo())
+	case subCommand == "MYID" && len(args) == 2:
+		client.writer.WriteBulkString(cluster.NodeID(announceAddress()))
+	case subCommand == "KEYSLOT" && len(args) == 3:
+		client.writer.WriteInteger(int64(hash.GetHashSlotIndex(args[2])))
+	default:
+		client.writer.WriteError("ERR unknown subcommand or wrong number of arguments for '" + args[1] + "'")
+	}
+}
+
+// readOnlyCommand : READONLY, READWRITE, ASKING
+// 슬레이브 읽기 / ASK 재요청 시 클라이언트가 보내는 명령, 상태를 바꾸지 않는다
+//
+func readOnlyCommand(client *client, args []string) {
+	client.writer.WriteSimpleString("OK")
+}
+
+// clusterNode : 응답에 사용할 노드 정보
+type clusterNode struct {
+	id   string
+	host string
+	port int
+	role string
+}
+
+func newClusterNode(redisClient cluster.RedisClient) clusterNode {
+
+	host, port, _ := splitAddress(redisClient.Address)
+
+	return clusterNode{
+		id:   cluster.NodeID(redisClient.Address),
+		host: host,
+		port: port,
+		role: redisClient.Role,
+	}
+}
+
+func selfClusterNode() clusterNode {
+	return clusterNode{
+		id:   cluster.NodeID(announceAddress()),
+		host: announceHost,
+		port: announcePort,
+		role: cluster.MasterRole,
+	}
+}
+
+// clusterShard : 한 마스터 (proxy 모드는 인터페이스 서버) 의 슬롯 구간과 노드
+type clusterShard struct {
+	slotRanges []cluster.SlotRange
+	nodes      []clusterNode
+}
+
+// getClusterShards : 모드에 맞게 슬롯 구간을 노드 별로 묶는다 (첫 번째 노드가 마스터)
+//
+func getClusterShards() []clusterShard {
+
+	slotRanges := cluster.GetSlotRanges()
+
+	if clusterMode == ClusterModeProxy {
+		return []clusterShard{{
+			slotRanges: slotRanges,
+			nodes:      []clusterNode{selfClusterNode()},
+		}}
+	}
+
+	shards := []clusterShard{}
+	shardIndexes := make(map[string]int)
+
+	for _, eachRange := range slotRanges {
+
+		shardIndex, isExist := shardIndexes[eachRange.Master.Address]
+		if !isExist {
+			nodes := []clusterNode{newClusterNode(eachRange.Master)}
+			for _, eachSlave := range eachRange.Slaves {
+				nodes = append(nodes, newClusterNode(eachSlave))
+			}
+
+			shardIndex = len(shards)
+			shardIndexes[eachRange.Master.Address] = shardIndex
+			shards = append(shards, clusterShard{nodes: nodes})
+		}
+
+		shards[shardIndex].slotRanges = append(shards[shardIndex].slotRanges, eachRange)
+	}
+
+	return shards
+}
+
+// writeClusterSlots : [시작 슬롯, 끝 슬롯, [마스터 host, port, id], [슬레이브 host, port, id] ...] 배열
+//
+func (client *client) writeClusterSlots() {
+
+	shards := getClusterShards()
+
+	rangeCount := 0
+	for _, eachShard := range shards {
+		rangeCount += len(eachShard.slotRanges)
+	}
+
+	client.writer.WriteArrayHeader(rangeCount)
+
+	for _, eachShard := range shards {
+		for _, eachRange := range eachShard.slotRanges {
+
+			client.writer.WriteArrayHeader(2 + len(eachShard.nodes))
+			client.writer.WriteInteger(int64(eachRange.Start))
+			client.writer.WriteInteger(int64(eachRange.End))
+
+			for _, eachNode := range eachShard.nodes {
+				client.writer.WriteArrayHeader(3)
+				client.writer.WriteBulkString(eachNode.host)
+				client.writer.WriteInteger(int64(eachNode.port))
+				client.writer.WriteBulkString(eachNode.id)
+			}
+		}
+	}
+}
+
+// clusterNodes : CLUSTER NODES 형식
+// <id> <ip:port@cport> <flags> <master id> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+//
+func clusterNodes() string {
+
+	var builder strings.Builder
+
+	for _, eachShard := range getClusterShards() {
+
+		masterID := eachShard.nodes[0].id
+
+		for i, eachNode := range eachShard.nodes {
+
+			flags := "master"
+			parentID := "-"
+			if i > 0 {
+				flags = "slave"
+				parentID = masterID
+			}
+			if clusterMode == ClusterModeProxy {
+				flags = "myself," + flags
+			}
+
+			fmt.Fprintf(
+				&builder,
+				"%s %s:%d@%d %s %s 0 0 0 connected",
+				eachNode.id,
+				eachNode.host,
+				eachNode.port,
+				eachNode.port+10000,
+				flags,
+				parentID,
+			)
+
+			if i == 0 {
+				for _, eachRange := range eachShard.slotRanges {
+					if eachRange.Start == eachRange.End {
+						fmt.Fprintf(&builder, " %d", eachRange.Start)
+						continue
+					}
+					fmt.Fprintf(&builder, " %d-%d", eachRange.Start, eachRange.End)
+				}
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// writeClusterShards : [{"slots": [시작, 끝, ...], "nodes": [{id, port, ip, endpoint, role, ...}]}] 배열
+//
+func (client *client) writeClusterShards() {
+
+	shards := getClusterShards()
+
+	client.writer.WriteArrayHeader(len(shards))
+
+	for _, eachShard := range shards {
+
+		client.writer.WriteMapHeader(2)
+
+		client.writer.WriteBulkString("slots")
+		client.writer.WriteArrayHeader(len(eachShard.slotRanges) * 2)
+		for _, eachRange := range eachShard.slotRanges {
+			client.writer.WriteInteger(int64(eachRange.Start))
+			client.writer.WriteInteger(int64(eachRange.End))
+		}
+
+		client.writer.WriteBulkString("nodes")
+		client.writer.WriteArrayHeader(len(eachShard.nodes))
+		for i, eachNode := range eachShard.nodes {
+
+			role := "master"
+			if i > 0 {
+				role = "replica"
+			}
+
+			client.writer.WriteMapHeader(7)
+			client.writer.WriteBulkString("id")
+			client.writer.WriteBulkString(eachNode.id)
+			client.writer.WriteBulkString("port")
+			client.writer.WriteInteger(int64(eachNode.port))
+			client.writer.WriteBulkString("ip")
+			client.writer.WriteBulkString(eachNode.host)
+			client.writer.WriteBulkString("endpoint")
+			client.writer.WriteBulkString(eachNode.host)
+			client.writer.WriteBulkString("role")
+			client.writer.WriteBulkString(role)
+			client.writer.WriteBulkString("replication-offset")
+			client.writer.WriteInteger(0)
+			client.writer.WriteBulkString("health")
+			client.writer.WriteBulkString("online")
+		}
+	}
+}
+
+// clusterInfo : CLUSTER INFO 형식 ("<field>:<value>\r\n")
+//
+func clusterInfo() string {
+
+	shards := getClusterShards()
+
+	assignedSlots := 0
+	knownNodes := 0
+	for _, eachShard := range shards {
+		for _, eachRange := range eachShard.slotRanges {
+			assignedSlots += int(eachRange.End-eachRange.Start) + 1
+		}
+		knownNodes += len(eachShard.nodes)
+	}
+
+	state := "ok"
+	if assignedSlots < hash.HashSlotsNumber {
+		state = "fail"
+	}
+
+	return fmt.Sprintf(
+		"cluster_enabled:1\r\n"+
+			"cluster_state:%s\r\n"+
+			"cluster_slots_assigned:%d\r\n"+
+			"cluster_slots_ok:%d\r\n"+
+			"cluster_slots_pfail:0\r\n"+
+			"cluster_slots_fail:0\r\n"+
+			"cluster_known_nodes:%d\r\n"+
+			"cluster_size:%d\r\n"+
+			"cluster_current_epoch:%d\r\n"+
+			"cluster_my_epoch:0\r\n",
+		state,
+		assignedSlots,
+		assignedSlots,
+		knownNodes,
+		len(shards),
+		cluster.TopologyEpoch(),
+	)
+}
@@ -0,0 +1,540 @@
+package resp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"hash_interface/internal/auth"
+	"hash_interface/internal/cluster"
+	"hash_interface/internal/namespace"
+)
+
+// command : 지원하는 명령 정보
+type command struct {
+	handler func(client *client, args []string)
+
+	// arity : 명령 이름을 포함한 인자 수, 음수이면 최소 인자 수 (레디스 COMMAND 와 동일)
+	arity int
+
+	// requiredRole : 인증 활성화 시 필요한 API 토큰 권한
+	requiredRole auth.Role
+
+	// firstKey, lastKey, keyStep : Key 인자 위치 (레디스 COMMAND 와 동일, lastKey -1 = 마지막 인자)
+	// firstKey 가 0 이면 Key 가 없는 명령
+	firstKey int
+	lastKey  int
+	keyStep  int
+}
+
+var commandTable map[string]command
+
+var (
+	// errSyntax, errInvalidExpireTime : 레디스와 같은 SET 옵션 에러 응답
+	errSyntax            = errors.New("ERR syntax error")
+	errInvalidExpireTime = errors.New("ERR invalid expire time in 'set' command")
+)
+
+// setOptions : SET 옵션
+type setOptions struct {
+	// expiresAt : 만료 unix 시각 (초), cluster.NoExpiry / cluster.KeepExpiry (KEEPTTL)
+	expiresAt int64
+	// condition : NX / XX
+	condition cluster.SetCondition
+}
+
+func init() {
+	if commandTable == nil {
+		commandTable = map[string]command{
+			// 연결 관리
+			"PING":    {handler: ping, arity: -1},
+			"ECHO":    {handler: echo, arity: 2},
+			"HELLO":   {handler: hello, arity: -1},
+			"AUTH":    {handler: authCommand, arity: -2},
+			"SELECT":  {handler: selectDB, arity: 2},
+			"CLIENT":  {handler: clientCommand, arity: -2},
+			"COMMAND": {handler: commandCommand, arity: -1},
+			"QUIT":    {handler: quit, arity: -1},
+
+			// 클러스터
+			"CLUSTER":   {handler: clusterCommand, arity: -2},
+			"READONLY":  {handler: readOnlyCommand, arity: 1},
+			"READWRITE": {handler: readOnlyCommand, arity: 1},
+			"ASKING":    {handler: readOnlyCommand, arity: 1},
+
+			// 데이터
+			"GET":    {handler: get, arity: 2, requiredRole: auth.RoleRead, firstKey: 1, lastKey: 1, keyStep: 1},
+			"MGET":   {handler: mget, arity: -2, requiredRole: auth.RoleRead, firstKey: 1, lastKey: -1, keyStep: 1},
+			"EXISTS": {handler: exists, arity: -2, requiredRole: auth.RoleRead, firstKey: 1, lastKey: -1, keyStep: 1},
+			"SET":    {handler: set, arity: -3, requiredRole: auth.RoleWrite, firstKey: 1, lastKey: 1, keyStep: 1},
+			"MSET":   {handler: mset, arity: -3, requiredRole: auth.RoleWrite, firstKey: 1, lastKey: -1, keyStep: 2},
+			"DEL":    {handler: del, arity: -2, requiredRole: auth.RoleWrite, firstKey: 1, lastKey: -1, keyStep: 1},
+		}
+	}
+}
+
+func (command command) isValidArity(argCount int) bool {
+	if command.arity < 0 {
+		return argCount >= -command.arity
+	}
+	return argCount == command.arity
+}
+
+// keysOf : @args 중 Key 인자
+//
+func (command command) keysOf(args []string) []string {
+
+	if command.firstKey == 0 || command.firstKey >= len(args) {
+		return nil
+	}
+
+	lastKey := command.lastKey
+	if lastKey < 0 || lastKey >= len(args) {
+		lastKey = len(args) - 1
+	}
+
+	keys := []string{}
+	for i := command.firstKey; i <= lastKey; i += command.keyStep {
+		keys = append(keys, args[i])
+	}
+
+	return keys
+}
+
+/****************************************
+ *
+ *
+ *        Connection Commands
+ *
+ *
+ ****************************************/
+
+func ping(client *client, args []string) {
+	if len(args) > 1 {
+		client.writer.WriteBulkString(args[1])
+		return
+	}
+	client.writer.WriteSimpleString("PONG")
+}
+
+func echo(client *client, args []string) {
+	client.writer.WriteBulkString(args[1])
+}
+
+// hello : HELLO [protover [AUTH username password] [SETNAME clientname]]
+//
+func hello(client *client, args []string) {
+
+	protocol := client.writer.Protocol
+
+	if len(args) > 1 {
+		version, err := strconv.Atoi(args[1])
+		if err != nil || (version != RESP2 && version != RESP3) {
+			client.writer.WriteError("NOPROTO unsupported protocol version")
+			return
+		}
+		protocol = version
+	}
+
+	for i := 2; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i]); {
+		case option == "AUTH" && i+2 < len(args):
+			if !client.authenticate(args[i+2]) {
+				client.writer.WriteError("WRONGPASS invalid username-password pair or user is disabled.")
+				return
+			}
+			i += 2
+		case option == "SETNAME" && i+1 < len(args):
+			client.name = args[i+1]
+			i++
+		default:
+			client.writer.WriteError("ERR syntax error in HELLO option '" + args[i] + "'")
+			return
+		}
+	}
+
+	if auth.IsEnabled() && !client.isAuthenticated {
+		client.writer.WriteError("NOAUTH HELLO must be called with the client already authenticated, otherwise the HELLO AUTH <user> <pass> option can be used to authenticate the client and select the RESP protocol version at the same time")
+		return
+	}
+
+	client.writer.Protocol = protocol
+
+	client.writer.WriteMapHeader(7)
+	client.writer.WriteBulkString("server")
+	client.writer.WriteBulkString("hash_interface")
+	client.writer.WriteBulkString("version")
+	client.writer.WriteBulkString("1.1")
+	client.writer.WriteBulkString("proto")
+	client.writer.WriteInteger(int64(protocol))
+	client.writer.WriteBulkString("id")
+	client.writer.WriteInteger(client.id)
+	// proxy 모드에서는 클라이언트에게 단일 레디스로 보인다
+	client.writer.WriteBulkString("mode")
+	if clusterMode == ClusterModeRedirect {
+		client.writer.WriteBulkString("cluster")
+	} else {
+		client.writer.WriteBulkString("standalone")
+	}
+	client.writer.WriteBulkString("role")
+	client.writer.WriteBulkString("master")
+	client.writer.WriteBulkString("modules")
+	client.writer.WriteArrayHeader(0)
+}
+
+// authCommand : AUTH <token> 또는 AUTH <username> <token>
+//
+func authCommand(client *client, args []string) {
+
+	if len(args) > 3 {
+		client.writer.WriteError("ERR syntax error")
+		return
+	}
+
+	if !auth.IsEnabled() {
+		client.writer.WriteError("ERR AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?")
+		return
+	}
+
+	if !client.authenticate(args[len(args)-1]) {
+		client.writer.WriteError("WRONGPASS invalid username-password pair or user is disabled.")
+		return
+	}
+
+	client.writer.WriteSimpleString("OK")
+}
+
+// selectDB : 데이터베이스는 0 번만 지원
+func selectDB(client *client, args []string) {
+	if args[1] != "0" {
+		client.writer.WriteError("ERR DB index is out of range")
+		return
+	}
+	client.writer.WriteSimpleString("OK")
+}
+
+// clientCommand : CLIENT ID | GETNAME | SETNAME <name> | SETINFO <attr> <value>
+//
+func clientCommand(client *client, args []string) {
+
+	switch subCommand := strings.ToUpper(args[1]); {
+	case subCommand == "ID" && len(args) == 2:
+		client.writer.WriteInteger(client.id)
+	case subCommand == "GETNAME" && len(args) == 2:
+		if client.name == "" {
+			client.writer.WriteNull()
+			return
+		}
+		client.writer.WriteBulkString(client.name)
+	case subCommand == "SETNAME" && len(args) == 3:
+		client.name = args[2]
+		client.writer.WriteSimpleString("OK")
+	case subCommand == "SETINFO" && len(args) == 4:
+		// 클라이언트 라이브러리가 연결 시 보내는 정보, 저장하지 않는다
+		client.writer.WriteSimpleString("OK")
+	default:
+		client.writer.WriteError("ERR unknown subcommand or wrong number of arguments for '" + args[1] + "'")
+	}
+}
+
+// commandCommand : COMMAND COUNT 외에는 빈 목록 (redis-cli 시작 시 COMMAND DOCS 요청)
+//
+func commandCommand(client *client, args []string) {
+	if len(args) == 2 && strings.ToUpper(args[1]) == "COUNT" {
+		client.writer.WriteInteger(int64(len(commandTable)))
+		return
+	}
+	client.writer.WriteArrayHeader(0)
+}
+
+func quit(client *client, args []string) {
+	client.writer.WriteSimpleString("OK")
+	client.isClosing = true
+}
+
+/****************************************
+ *
+ *
+ *        Data Commands
+ *
+ *
+ ****************************************/
+
+// storedKeyOf : HTTP 의 기본 네임스페이스와 동일하게 다른 네임스페이스의 Key 접근 차단
+//
+func storedKeyOf(key string) (string, error) {
+
+	defaultNamespace, err := namespace.Get("")
+	if err != nil {
+		return "", err
+	}
+
+	return defaultNamespace.PrefixKey(key)
+}
+
+func get(client *client, args []string) {
+
+	storedKey, err := storedKeyOf(args[1])
+	if err != nil {
+		client.writer.WriteError("ERR " + err.Error())
+		return
+	}
+
+	value, _, err := cluster.GetData(context.Background(), storedKey)
+	if err == cluster.ErrKeyNotFound {
+		client.writer.WriteNull()
+		return
+
+	} else if err != nil {
+		client.writer.WriteError("ERR " + err.Error())
+		return
+	}
+
+	client.writer.WriteBulkString(value)
+}
+
+// mget : 각 Key 는 서로 다른 마스터에 있을 수 있으므로 하나씩 조회
+//
+func mget(client *client, args []string) {
+
+	values := make([]*string, 0, len(args)-1)
+
+	for _, eachKey := range args[1:] {
+
+		storedKey, err := storedKeyOf(eachKey)
+		if err != nil {
+			// 접근할 수 없는 Key 는 없는 Key 로 취급 (레디스 MGET 은 Key 단위 에러가 없다)
+			values = append(values, nil)
+			continue
+		}
+
+		value, _, err := cluster.GetData(context.Background(), storedKey)
+		if err == cluster.ErrKeyNotFound {
+			values = append(values, nil)
+			continue
+
+		} else if err != nil {
+			client.writer.WriteError("ERR " + err.Error())
+			return
+		}
+
+		values = append(values, &value)
+	}
+
+	client.writer.WriteArrayHeader(len(values))
+	for _, eachValue := range values {
+		if eachValue == nil {
+			client.writer.WriteNull()
+			continue
+		}
+		client.writer.WriteBulkString(*eachValue)
+	}
+}
+
+func exists(client *client, args []string) {
+
+	var existCount int64
+
+	for _, eachKey := range args[1:] {
+
+		storedKey, err := storedKeyOf(eachKey)
+		if err != nil {
+			continue
+		}
+
+		isExist, _, err := cluster.IsDataExist(context.Background(), storedKey)
+		if err != nil {
+			client.writer.WriteError("ERR " + err.Error())
+			return
+		}
+
+		if isExist {
+			existCount++
+		}
+	}
+
+	client.writer.WriteInteger(existCount)
+}
+
+// set : SET key value [NX | XX] [EX seconds | PX milliseconds | KEEPTTL]
+// 조건 (NX / XX) 을 만족하지 않아 저장하지 않으면 Null
+//
+func set(client *client, args []string) {
+
+	options, err := parseSetOptions(args[3:], time.Now())
+	if err != nil {
+		client.writer.WriteError(err.Error())
+		return
+	}
+
+	isStored, err := setData(args[1], args[2], options)
+	if err != nil {
+		client.writer.WriteError("ERR " + err.Error())
+		return
+	}
+
+	if !isStored {
+		client.writer.WriteNull()
+		return
+	}
+
+	client.writer.WriteSimpleString("OK")
+}
+
+// parseSetOptions : SET key value 뒤의 옵션, EX / PX 는 @now 기준 만료 unix 시각 (초) 으로 변환
+// 만료 시각은 초 단위로 기록하므로 PX 는 초 단위로 올림
+//
+func parseSetOptions(args []string, now time.Time) (setOptions, error) {
+
+	options := setOptions{expiresAt: cluster.NoExpiry, condition: cluster.SetAlways}
+	isExpirySet := false
+
+	for i := 0; i < len(args); i++ {
+
+		switch option := strings.ToUpper(args[i]); option {
+		case "NX", "XX":
+			if options.condition != cluster.SetAlways {
+				return setOptions{}, errSyntax
+			}
+			options.condition = cluster.SetCondition(option)
+
+		case "KEEPTTL":
+			if isExpirySet {
+				return setOptions{}, errSyntax
+			}
+			isExpirySet = true
+			options.expiresAt = cluster.KeepExpiry
+
+		case "EX", "PX":
+			if isExpirySet || i+1 == len(args) {
+				return setOptions{}, errSyntax
+			}
+			isExpirySet = true
+
+			i++
+			amount, err := strconv.ParseInt(args[i], 10, 64)
+			if err != nil {
+				return setOptions{}, errors.New("ERR value is not an integer or out of range")
+			}
+			if amount <= 0 {
+				return setOptions{}, errInvalidExpireTime
+			}
+
+			nowMs := now.UnixNano() / int64(time.Millisecond)
+			if option == "EX" {
+				options.expiresAt = now.Unix() + amount
+			} else {
+				options.expiresAt = (nowMs + amount + 999) / 1000
+			}
+
+		default:
+			return setOptions{}, errSyntax
+		}
+	}
+
+	return options, nil
+}
+
+// mset : 해쉬 슬롯 별로 나누어 저장하므로 원자적이지 않다
+// 중간에 실패하면 앞서 저장된 Key 는 그대로 남는다
+//
+func mset(client *client, args []string) {
+
+	if len(args)%2 != 1 {
+		client.writer.WriteError("ERR wrong number of arguments for 'mset' command")
+		return
+	}
+
+	for i := 1; i < len(args); i += 2 {
+		if _, err := setData(args[i], args[i+1], setOptions{expiresAt: cluster.NoExpiry, condition: cluster.SetAlways}); err != nil {
+			client.writer.WriteError("ERR " + err.Error())
+			return
+		}
+	}
+
+	client.writer.WriteSimpleString("OK")
+}
+
+// writeAckOf : 네임스페이스의 기본 쓰기 복제 수준 (HTTP / gRPC 와 동일, RESP 는 요청별 지정 없음)
+//
+func writeAckOf(targetNamespace *namespace.Namespace) (cluster.WriteAck, error) {
+	return cluster.ParseWriteAck(targetNamespace.DefaultWriteAck())
+}
+
+// setData : HTTP SET 과 동일하게 할당량 반영 후 저장, 데이터 로그 기록 & 슬레이브 전파
+// @options 의 조건을 만족하지 않아 저장하지 않으면 isStored = false (할당량도 되돌린다)
+//
+func setData(key string, value string, options setOptions) (isStored bool, err error) {
+
+	defaultNamespace, err := namespace.Get("")
+	if err != nil {
+		return false, err
+	}
+
+	storedKey, err := defaultNamespace.PrefixKey(key)
+	if err != nil {
+		return false, err
+	}
+
+	ack, err := writeAckOf(defaultNamespace)
+	if err != nil {
+		return false, err
+	}
+
+	reservation, err := defaultNamespace.Reserve(key, value)
+	if err != nil {
+		return false, err
+	}
+
+	isStored, _, err = cluster.SetDataWithExpiry(context.Background(), storedKey, value, options.expiresAt, options.condition, ack)
+
+	// 복제 수준 미달은 마스터에 반영된 상태이므로 할당량은 그대로 둔다
+	if (err != nil && !errors.Is(err, cluster.ErrReplicationAck)) || (err == nil && !isStored) {
+		reservation.Cancel()
+	}
+
+	return isStored, err
+}
+
+func del(client *client, args []string) {
+
+	defaultNamespace, err := namespace.Get("")
+	if err != nil {
+		client.writer.WriteError("ERR " + err.Error())
+		return
+	}
+
+	ack, err := writeAckOf(defaultNamespace)
+	if err != nil {
+		client.writer.WriteError("ERR " + err.Error())
+		return
+	}
+
+	var deletedCount int64
+
+	for _, eachKey := range args[1:] {
+
+		storedKey, err := defaultNamespace.PrefixKey(eachKey)
+		if err != nil {
+			continue
+		}
+
+		isDeleted, _, err := cluster.DeleteData(context.Background(), storedKey, ack)
+		if errors.Is(err, cluster.ErrReplicationAck) {
+			defaultNamespace.Release(eachKey)
+		}
+		if err != nil {
+			client.writer.WriteError("ERR " + err.Error())
+			return
+		}
+
+		if isDeleted {
+			defaultNamespace.Release(eachKey)
+			deletedCount++
+		}
+	}
+
+	client.writer.WriteInteger(deletedCount)
+}
@@ -0,0 +1,205 @@
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/* RESP (REdis Serialization Protocol)
+ * 요청 : 클라이언트 라이브러리의 Bulk string 배열 ("*2\r\n$3\r\nGET\r\n$3\r\nkey\r\n")
+ *        또는 redis-cli / telnet 의 Inline 명령 ("GET key\r\n")
+ * 응답 : 연결 별로 HELLO 로 협상한 프로토콜 버전 (RESP2 / RESP3) 에 맞춰 작성
+ */
+
+const (
+	RESP2 = 2
+	RESP3 = 3
+
+	// maxBulkLength : Bulk string 최대 길이 (레디스 proto-max-bulk-len 기본값)
+	maxBulkLength = 512 * 1024 * 1024
+	// maxArrayLength : 요청 배열의 최대 원소 수
+	maxArrayLength = 1024 * 1024
+)
+
+// ErrProtocol : 잘못된 형식의 요청, 연결을 끊는다
+var ErrProtocol = errors.New("Protocol error")
+
+// Reader : 요청 명령 파서
+type Reader struct {
+	reader *bufio.Reader
+}
+
+func NewReader(reader io.Reader) *Reader {
+	return &Reader{reader: bufio.NewReader(reader)}
+}
+
+// ReadCommand : 명령 하나를 읽어 인자 배열로 반환 (args[0] = 명령 이름)
+// 빈 Inline 명령은 nil, nil 반환
+//
+func (reader *Reader) ReadCommand() ([]string, error) {
+
+	prefix, err := reader.reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix[0] != '*' {
+		return reader.readInlineCommand()
+	}
+
+	line, err := reader.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	argCount, err := strconv.Atoi(line[1:])
+	if err != nil || argCount > maxArrayLength {
+		return nil, fmt.Errorf("%w: invalid multibulk length", ErrProtocol)
+	}
+
+	if argCount <= 0 {
+		return nil, nil
+	}
+
+	args := make([]string, 0, argCount)
+
+	for i := 0; i < argCount; i++ {
+		arg, err := reader.readBulkString()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+// HasBuffered : 파이프라인으로 이미 도착한 요청이 남아있는지 확인
+// 남아있으면 응답을 모아서 한 번에 보낸다
+//
+func (reader *Reader) HasBuffered() bool {
+	return reader.reader.Buffered() > 0
+}
+
+func (reader *Reader) readInlineCommand() ([]string, error) {
+
+	line, err := reader.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	return args, nil
+}
+
+func (reader *Reader) readBulkString() (string, error) {
+
+	line, err := reader.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) == 0 || line[0] != '$' {
+		return "", fmt.Errorf("%w: expected '$', got '%.1s'", ErrProtocol, line)
+	}
+
+	length, err := strconv.Atoi(line[1:])
+	if err != nil || length < 0 || length > maxBulkLength {
+		return "", fmt.Errorf("%w: invalid bulk length", ErrProtocol)
+	}
+
+	// 데이터 + "\r\n"
+	buffer := make([]byte, length+2)
+	if _, err := io.ReadFull(reader.reader, buffer); err != nil {
+		return "", err
+	}
+
+	if buffer[length] != '\r' || buffer[length+1] != '\n' {
+		return "", fmt.Errorf("%w: bulk string not terminated by CRLF", ErrProtocol)
+	}
+
+	return string(buffer[:length]), nil
+}
+
+// readLine : "\r\n" (Inline 명령은 "\n" 도 허용) 을 제외한 한 줄
+func (reader *Reader) readLine() (string, error) {
+
+	line, err := reader.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+// Writer : 응답 작성, Flush() 전까지 버퍼에 모은다
+type Writer struct {
+	writer *bufio.Writer
+
+	// Protocol : RESP2 / RESP3
+	Protocol int
+}
+
+func NewWriter(writer io.Writer) *Writer {
+	return &Writer{
+		writer:   bufio.NewWriter(writer),
+		Protocol: RESP2,
+	}
+}
+
+func (writer *Writer) Flush() error {
+	return writer.writer.Flush()
+}
+
+// WriteSimpleString : "+OK"
+func (writer *Writer) WriteSimpleString(value string) {
+	writer.writer.WriteString("+" + value + "\r\n")
+}
+
+// WriteError : @message 는 "ERR ...", "NOAUTH ..." 처럼 에러 코드로 시작
+func (writer *Writer) WriteError(message string) {
+	// 에러 메세지에 줄바꿈이 있으면 응답 형식이 깨진다
+	message = strings.NewReplacer("\r", " ", "\n", " ").Replace(message)
+	writer.writer.WriteString("-" + message + "\r\n")
+}
+
+func (writer *Writer) WriteInteger(value int64) {
+	writer.writer.WriteString(":" + strconv.FormatInt(value, 10) + "\r\n")
+}
+
+func (writer *Writer) WriteBulkString(value string) {
+	writer.writer.WriteString("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n")
+}
+
+// WriteNull : 값 없음, RESP2 는 Null bulk string
+func (writer *Writer) WriteNull() {
+	if writer.Protocol == RESP3 {
+		writer.writer.WriteString("_\r\n")
+		return
+	}
+	writer.writer.WriteString("$-1\r\n")
+}
+
+// WriteArrayHeader : 이어서 @length 개의 값을 작성해야 한다
+func (writer *Writer) WriteArrayHeader(length int) {
+	writer.writer.WriteString("*" + strconv.Itoa(length) + "\r\n")
+}
+
+// WriteMapHeader : 이어서 @length 쌍의 (Key, Value) 를 작성해야 한다
+// RESP2 는 2 * @length 길이의 배열
+//
+func (writer *Writer) WriteMapHeader(length int) {
+	if writer.Protocol == RESP3 {
+		writer.writer.WriteString("%" + strconv.Itoa(length) + "\r\n")
+		return
+	}
+	writer.WriteArrayHeader(length * 2)
+}
@@ -0,0 +1,141 @@
+package resp
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"hash_interface/internal/cluster"
+)
+
+func TestReadCommand(t *testing.T) {
+
+	// 파이프라인 : Multibulk + Inline
+	input := "*3\r\n$3\r\nSET\r\n$5\r\nhello\r\n$11\r\nhello world\r\nGET hello\r\n"
+	reader := NewReader(strings.NewReader(input))
+
+	args, err := reader.ReadCommand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(args, []string{"SET", "hello", "hello world"}) {
+		t.Fatalf("unexpected multibulk args : %q", args)
+	}
+	if !reader.HasBuffered() {
+		t.Fatal("pipelined command must be buffered")
+	}
+
+	args, err = reader.ReadCommand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(args, []string{"GET", "hello"}) {
+		t.Fatalf("unexpected inline args : %q", args)
+	}
+}
+
+func TestReadCommandProtocolError(t *testing.T) {
+
+	for _, eachInput := range []string{
+		"*1\r\n+PING\r\n",
+		"*1\r\n$-5\r\n",
+		"*1\r\n$4\r\nPINGxx",
+	} {
+		_, err := NewReader(strings.NewReader(eachInput)).ReadCommand()
+		if !errors.Is(err, ErrProtocol) {
+			t.Fatalf("%q : expected protocol error, got %v", eachInput, err)
+		}
+	}
+}
+
+func TestWriterProtocolVersion(t *testing.T) {
+
+	var output bytes.Buffer
+	writer := NewWriter(&output)
+
+	writer.WriteNull()
+	writer.WriteMapHeader(1)
+	writer.Flush()
+
+	if output.String() != "$-1\r\n*2\r\n" {
+		t.Fatalf("unexpected RESP2 output : %q", output.String())
+	}
+
+	output.Reset()
+	writer.Protocol = RESP3
+
+	writer.WriteNull()
+	writer.WriteMapHeader(1)
+	writer.WriteError("ERR multi\nline")
+	writer.Flush()
+
+	if output.String() != "_\r\n%1\r\n-ERR multi line\r\n" {
+		t.Fatalf("unexpected RESP3 output : %q", output.String())
+	}
+}
+
+func TestParseSetOptions(t *testing.T) {
+
+	now := time.Unix(1700000000, 500*int64(time.Millisecond))
+
+	testCases := []struct {
+		args    []string
+		options setOptions
+	}{
+		{[]string{}, setOptions{cluster.NoExpiry, cluster.SetAlways}},
+		{[]string{"EX", "10"}, setOptions{1700000010, cluster.SetAlways}},
+		// 초 단위로 올림
+		{[]string{"px", "1200"}, setOptions{1700000002, cluster.SetAlways}},
+		{[]string{"NX", "EX", "10"}, setOptions{1700000010, cluster.SetIfAbsent}},
+		{[]string{"KEEPTTL", "xx"}, setOptions{cluster.KeepExpiry, cluster.SetIfExist}},
+	}
+
+	for _, eachCase := range testCases {
+		options, err := parseSetOptions(eachCase.args, now)
+		if err != nil || options != eachCase.options {
+			t.Errorf("%q : unexpected options %+v (%v)", eachCase.args, options, err)
+		}
+	}
+
+	errorCases := []struct {
+		args []string
+		err  error
+	}{
+		{[]string{"NX", "XX"}, errSyntax},
+		{[]string{"EX", "10", "PX", "10"}, errSyntax},
+		{[]string{"EX", "10", "KEEPTTL"}, errSyntax},
+		{[]string{"EX"}, errSyntax},
+		{[]string{"GET"}, errSyntax},
+		{[]string{"EX", "0"}, errInvalidExpireTime},
+		{[]string{"PX", "-5"}, errInvalidExpireTime},
+	}
+
+	for _, eachCase := range errorCases {
+		if _, err := parseSetOptions(eachCase.args, now); err != eachCase.err {
+			t.Errorf("%q : expected %v, got %v", eachCase.args, eachCase.err, err)
+		}
+	}
+
+	if _, err := parseSetOptions([]string{"EX", "ten"}, now); err == nil {
+		t.Fatal("expected integer error")
+	}
+}
+
+func TestSetOptionErrorReply(t *testing.T) {
+
+	var output bytes.Buffer
+	testClient := &client{writer: NewWriter(&output)}
+
+	// 잘못된 옵션은 저장 전에 레디스와 같은 에러로 응답
+	set(testClient, []string{"SET", "k", "v", "EX", "0"})
+	set(testClient, []string{"SET", "k", "v", "NX", "XX"})
+	testClient.writer.Flush()
+
+	expected := "-ERR invalid expire time in 'set' command\r\n-ERR syntax error\r\n"
+	if output.String() != expected {
+		t.Fatalf("unexpected reply : %q", output.String())
+	}
+}
@@ -0,0 +1,173 @@
+package resp
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"hash_interface/internal/auth"
+	"hash_interface/tools"
+)
+
+/* RESP 리스너
+ * redis-cli, 레디스 클라이언트 라이브러리가 클러스터 전체를 하나의 레디스처럼 사용할 수 있도록
+ * HTTP 핸들러와 같은 경로 (해쉬 슬롯 -> 마스터, 데이터 로그, 슬레이브 전파) 로 명령을 처리한다.
+ */
+
+// client : RESP 연결 하나의 상태
+type client struct {
+	id     int64
+	name   string
+	conn   net.Conn
+	reader *Reader
+	writer *Writer
+
+	// identity : AUTH / HELLO AUTH 로 인증된 API 토큰
+	identity        auth.Identity
+	isAuthenticated bool
+
+	// isClosing : QUIT 응답 후 연결 종료
+	isClosing bool
+}
+
+// lastClientID : CLIENT ID 발급용
+var lastClientID int64
+
+// ListenAndServe : @address 에서 RESP 연결 대기
+// @tlsConfig 가 nil 이 아니면 TLS 리스너
+//
+func ListenAndServe(address string, tlsConfig *tls.Config) error {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	return Serve(listener)
+}
+
+// Serve : @listener 로 들어오는 연결마다 고루틴으로 처리
+//
+func Serve(listener net.Listener) error {
+
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// 일시적인 에러 (fd 부족 등) 는 계속 대기
+			if netErr, isNetErr := err.(net.Error); isNetErr && netErr.Temporary() {
+				tools.ErrorLogger.Printf("RESP 연결 수락 에러 - %s", err.Error())
+				continue
+			}
+			return err
+		}
+
+		go newClient(conn).serve()
+	}
+}
+
+func newClient(conn net.Conn) *client {
+	return &client{
+		id:     atomic.AddInt64(&lastClientID, 1),
+		conn:   conn,
+		reader: NewReader(conn),
+		writer: NewWriter(conn),
+	}
+}
+
+// serve : 연결이 끊기거나 QUIT 요청까지 명령 처리
+//
+func (client *client) serve() {
+
+	defer client.conn.Close()
+
+	for !client.isClosing {
+
+		args, err := client.reader.ReadCommand()
+		if err != nil {
+			if errors.Is(err, ErrProtocol) {
+				client.writer.WriteError("ERR " + err.Error())
+				client.writer.Flush()
+			} else if err != io.EOF {
+				tools.ErrorLogger.Printf("RESP 클라이언트(%s) 읽기 에러 - %s", client.conn.RemoteAddr(), err.Error())
+			}
+			return
+		}
+
+		if len(args) == 0 {
+			continue
+		}
+
+		client.execute(args)
+
+		// 파이프라인 요청은 응답을 모아서 전송
+		if client.reader.HasBuffered() {
+			continue
+		}
+
+		if err := client.writer.Flush(); err != nil {
+			return
+		}
+	}
+
+	client.writer.Flush()
+}
+
+// execute : 명령 이름, 인자 수, 권한 확인 후 처리
+//
+func (client *client) execute(args []string) {
+
+	commandName := strings.ToUpper(args[0])
+
+	targetCommand, isSupported := commandTable[commandName]
+	if !isSupported {
+		client.writer.WriteError("ERR unknown command '" + args[0] + "'")
+		return
+	}
+
+	if !targetCommand.isValidArity(len(args)) {
+		client.writer.WriteError("ERR wrong number of arguments for '" + strings.ToLower(commandName) + "' command")
+		return
+	}
+
+	if targetCommand.requiredRole != auth.RoleNone && auth.IsEnabled() {
+		if !client.isAuthenticated {
+			client.writer.WriteError("NOAUTH Authentication required.")
+			return
+		}
+
+		if err := client.identity.Authorize(targetCommand.requiredRole); err != nil {
+			client.writer.WriteError("NOPERM this token has no permissions to run the '" + strings.ToLower(commandName) + "' command")
+			return
+		}
+	}
+
+	if client.redirectIfNotServed(targetCommand, args) {
+		return
+	}
+
+	targetCommand.handler(client, args)
+}
+
+// authenticate : AUTH [username] <token>, username 은 무시한다
+//
+func (client *client) authenticate(token string) bool {
+
+	identity, err := auth.Authenticate(auth.BearerPrefix + token)
+	if err != nil {
+		return false
+	}
+
+	client.identity = identity
+	client.isAuthenticated = true
+
+	return true
+}
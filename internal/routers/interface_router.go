@@ -5,14 +5,63 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"hash_interface/internal/auth"
 	"hash_interface/internal/handlers"
 )
 
+// SetUpInterfaceRouter : 각 경로에 필요한 권한 (auth.Role)
+//   - read  : 데이터 조회
+//   - write : 데이터 저장
+//   - admin : 레디스 클라이언트 / 네임스페이스 관리
+//
 func SetUpInterfaceRouter(router *mux.Router) {
 
-	router.HandleFunc("/clients", handlers.AddNewClient).Methods(http.MethodPost)
+	// 토큰 / IP 별 요청 속도 제한 & 전체 동시 처리 요청 수 제한
+	router.Use(handlers.LimitRequests)
 
-	router.HandleFunc("/clients", handlers.GetClients).Methods(http.MethodGet)
+	// 모든 응답에 토폴로지 Epoch 헤더 (X-Topology-Epoch)
+	router.Use(handlers.SetTopologyEpoch)
+
+	router.HandleFunc("/clients", handlers.RequireRole(auth.RoleAdmin, handlers.AddNewClient)).Methods(http.MethodPost)
+
+	router.HandleFunc("/clients", handlers.RequireRole(auth.RoleAdmin, handlers.GetClients)).Methods(http.MethodGet)
+
+	/* @GET
+	 * 해쉬 슬롯 구간별 마스터 / 슬레이브 주소 + 토폴로지 Epoch
+	 * Request URI : http://~/cluster/slots
+	 */
+	router.HandleFunc("/cluster/slots", handlers.RequireRole(auth.RoleRead, handlers.GetClusterSlots)).Methods(http.MethodGet)
+
+	/* @GET
+	 * 슬레이브 별 복제 Offset / 지연
+	 * Request URI : http://~/cluster/replication
+	 */
+	router.HandleFunc("/cluster/replication", handlers.RequireRole(auth.RoleRead, handlers.GetReplicationStatus)).Methods(http.MethodGet)
+
+	/* @POST
+	 * 모니터 서버의 노드 상태 변경 알림 (cluster.NodeStateEvent)
+	 * Request URI : http://~/monitor/events
+	 */
+	router.HandleFunc("/monitor/events", handlers.RequireRole(auth.RoleAdmin, handlers.ReceiveNodeStateEvent)).Methods(http.MethodPost)
+
+	/* 네임스페이스 관리
+	 * @PUT : 등록 / 할당량 변경, Request Data format : { max_keys : , max_bytes : }
+	 * @GET : 등록된 네임스페이스와 사용량
+	 */
+	router.HandleFunc("/namespaces", handlers.RequireRole(auth.RoleAdmin, handlers.GetNamespaces)).Methods(http.MethodGet)
+
+	router.HandleFunc("/namespaces/{namespace}", handlers.RequireRole(auth.RoleAdmin, handlers.RegisterNamespace)).Methods(http.MethodPut)
+
+	// 네임스페이스는 X-Namespace 헤더 또는 경로로 선택
+	// Request URI : http://~/hash/data/key (+ X-Namespace 헤더)
+	// Request URI : http://~/namespaces/{namespace}/hash/data/key
+	setUpDataRouter(router)
+
+	setUpDataRouter(router.PathPrefix("/namespaces/{namespace}").Subrouter())
+}
+
+// setUpDataRouter : 데이터 요청 경로 설정
+func setUpDataRouter(router *mux.Router) {
 
 	/* @POST
 	 * Set Value
@@ -24,16 +73,43 @@ func SetUpInterfaceRouter(router *mux.Router) {
 			]
 		}
 	*/
-	router.HandleFunc("/hash/data", handlers.SetKeyValue).Methods(http.MethodPost)
+	router.HandleFunc("/hash/data", handlers.RequireRole(auth.RoleWrite, handlers.SetKeyValue)).Methods(http.MethodPost)
 
 	/* @GET
 	 * Get Value From Key
 	 * Request URI : http://~/hash/data/key
 	 */
-	router.HandleFunc("/hash/data/{key}", handlers.GetValueFromKey).Methods(http.MethodGet)
+	router.HandleFunc("/hash/data/{key}", handlers.RequireRole(auth.RoleRead, handlers.GetValueFromKey)).Methods(http.MethodGet)
+
+	/* @PUT
+	 * Create or Replace Value of Key
+	 * Request URI : http://~/hash/data/key
+	 * Request Data format : { value : }
+	 * Response : 201 (Created) / 204 (Replaced)
+	 */
+	router.HandleFunc("/hash/data/{key}", handlers.RequireRole(auth.RoleWrite, handlers.PutValueOfKey)).Methods(http.MethodPut)
+
+	/* @HEAD
+	 * Check Key Exists
+	 * Request URI : http://~/hash/data/key
+	 * Response : 200 (Exist) / 404 (Not Found)
+	 */
+	router.HandleFunc("/hash/data/{key}", handlers.RequireRole(auth.RoleRead, handlers.CheckKeyExist)).Methods(http.MethodHead)
 
 	/* @DELETE
 	 * DELETE Value From Key
 	 * Request URI : http://~/hash/data/key
 	 */
+
+	/* @GET
+	 * Scan Keys in Namespace
+	 * Request URI : http://~/hash/keys
+	 */
+	router.HandleFunc("/hash/keys", handlers.RequireRole(auth.RoleRead, handlers.ScanKeys)).Methods(http.MethodGet)
+
+	/* @GET
+	 * Export (Key, Value) pairs in Namespace
+	 * Request URI : http://~/hash/export
+	 */
+	router.HandleFunc("/hash/export", handlers.RequireRole(auth.RoleRead, handlers.ExportData)).Methods(http.MethodGet)
 }
@@ -14,6 +14,15 @@ func SetUpMonitorRouter(router *mux.Router) {
 	// 모니터링 중인 레디스 클라이언트 삭제
 	router.PathPrefix("/connect/{redis_address}").HandlerFunc(handlers.UnregisterRedis).Methods(http.MethodDelete)
 
+	// 인터페이스 서버의 노드 상태 변경 알림 구독 / 구독 해제, Request Data format : { callback_url : }
+	// 구독 요청 토큰 (MONITOR_SUBSCRIBE_TOKEN) 또는 mutual TLS 인증서 필요
+	router.HandleFunc("/subscribers", handlers.RequireSubscriber(handlers.SubscribeNodeEvents)).Methods(http.MethodPost)
+
+	router.HandleFunc("/subscribers", handlers.RequireSubscriber(handlers.UnsubscribeNodeEvents)).Methods(http.MethodDelete)
+
+	// 레디스 클라이언트의 최근 확인 결과
+	router.HandleFunc("/history/{redis_address}", handlers.GetNodeHealthHistory).Methods(http.MethodGet)
+
 	// 모니터링 중인 레디스 클라이언트 Alive 테스트
 	router.HandleFunc("/{redis_address}", handlers.CheckRedisNodeStatus).Methods(http.MethodGet)
 
@@ -0,0 +1,405 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 인터페이스 서버 Go 클라이언트
+ * cmd/cli 의 HTTP 요청을 라이브러리로 제공한다.
+ *   - 데이터 요청 (Get / Set / Put / Exists / Keys / Export)
+ *   - 연결 재사용, 일시적인 에러 (연결 실패, 429, 502~504) 재시도 (지수 Backoff, Retry-After 반영)
+ *     쓰기 요청은 서버가 처리하지 않은 것이 확실한 경우 (요청 전송 전 연결 실패, 429 등) 에만 재시도
+
+ *   - 서버 에러 응답 => *Error
+ *   - (선택) 해쉬 슬롯 맵으로 조회 요청을 레디스 노드에 직접 전송
+ *   - 쓰기 응답의 세션 토큰을 다음 요청에 전달 (슬레이브 조회에서도 자신의 쓰기를 읽는다)
+ */
+
+// TopologyEpochHeader : 서버 응답의 현재 토폴로지 Epoch
+const TopologyEpochHeader = "X-Topology-Epoch"
+
+// Get 요청의 Read Preference 헤더
+const (
+	ReadPreferenceHeader = "X-Read-Preference"
+	MaxStalenessHeader   = "X-Max-Staleness"
+	SessionTokenHeader   = "X-Session-Token"
+	WriteAckHeader       = "X-Write-Ack"
+)
+
+// 쓰기 복제 수준 (WriteAck)
+const (
+	AckMaster  = "master"
+	AckReplica = "replica"
+	AckAll     = "all"
+)
+
+// Read Preference
+const (
+	ReadMaster        = "master"
+	ReadPreferReplica = "prefer-replica"
+	ReadReplicaOnly   = "replica-only"
+)
+
+const (
+	defaultMaxRetries          = 3
+	defaultMinBackoff          = 100 * time.Millisecond
+	defaultMaxBackoff          = 2 * time.Second
+	defaultSlotRefreshInterval = time.Minute
+	defaultDialTimeout         = 3 * time.Second
+)
+
+// Config : 클라이언트 설정, BaseURL 외에는 모두 선택
+type Config struct {
+	// BaseURL : 인터페이스 서버 주소 (ex. http://localhost:8001)
+	BaseURL string
+
+	// Token : API 토큰 (Authorization: Bearer <token>)
+	Token string
+
+	// Namespace : 비어있으면 기본 네임스페이스
+	Namespace string
+
+	// HTTPClient : 미설정 시 TLSConfig 를 사용하는 http.Client
+	HTTPClient *http.Client
+	TLSConfig  *tls.Config
+
+	// MaxRetries : 일시적인 에러 재시도 횟수 (0 = 기본값 3, 음수 = 재시도 안 함)
+	MaxRetries int
+	// MinBackoff, MaxBackoff : 재시도 대기 시간 (지수 증가 + Jitter)
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// DirectRead : 조회 (Get / Exists) 를 해쉬 슬롯 담당 노드에 직접 요청
+	// 노드 응답이 없거나 에러이면 인터페이스 서버로 다시 요청한다. (ReadPreference 가 슬레이브 조회이면 Get 은 서버로 요청)
+	DirectRead bool
+	// SlotRefreshInterval : 해쉬 슬롯 맵 (GET /cluster/slots) 갱신 주기
+	// 노드 에러 / MOVED 응답, 서버 응답의 토폴로지 Epoch 가 더 큰 경우에는 즉시 갱신
+	SlotRefreshInterval time.Duration
+	// ReadPreference : Get 요청의 조회 노드 (master / prefer-replica / replica-only), 미설정 시 master
+	ReadPreference string
+	// MaxStaleness : 슬레이브 조회 시 허용하는 최대 복제 지연, 미설정 시 서버 기본값 (10s)
+	MaxStaleness time.Duration
+
+	// WriteAck : 쓰기 (Set / Put) 복제 수준 (master / replica / all), 미설정 시 네임스페이스 설정
+	// 만족하지 못하면 ErrReplicationAck (마스터에는 저장된 상태)
+	WriteAck string
+
+	// DialNode : 레디스 노드 연결 (노드 AUTH / TLS 가 필요한 경우 설정), 미설정 시 TCP (+ TLSConfig)
+	DialNode func(address string) (redis.Conn, error)
+}
+
+// Client : 여러 고루틴에서 함께 사용할 수 있다
+type Client struct {
+	config     Config
+	baseURL    string
+	httpClient *http.Client
+
+	// slots : DirectRead 설정 시 해쉬 슬롯 맵
+	slots *slotMap
+
+	// sessionToken : 마지막으로 받은 세션 토큰 (서버가 요청의 토큰과 합쳐서 돌려준다), 모든 요청에 전달
+	sessionMutex sync.Mutex
+	sessionToken string
+}
+
+// New : @config 로 클라이언트 생성, DirectRead 설정 시 해쉬 슬롯 맵을 먼저 조회한다
+//
+func New(config Config) (*Client, error) {
+
+	if config.BaseURL == "" {
+		return nil, errors.New("client : BaseURL 이 비어있습니다")
+	}
+
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = defaultMinBackoff
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.SlotRefreshInterval <= 0 {
+		config.SlotRefreshInterval = defaultSlotRefreshInterval
+	}
+
+	client := &Client{
+		config:     config,
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		httpClient: config.HTTPClient,
+	}
+
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				TLSClientConfig:     config.TLSConfig,
+				MaxIdleConnsPerHost: 32,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		}
+	}
+
+	if config.DirectRead {
+		client.slots = newSlotMap(client)
+		if err := client.slots.refresh(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// Close : 레디스 노드 연결 정리
+//
+func (client *Client) Close() error {
+
+	if client.slots != nil {
+		client.slots.close()
+	}
+
+	return nil
+}
+
+// SessionToken : 마지막 쓰기 응답의 세션 토큰
+// 슬레이브 조회 (ReadPreference) 에서도 이 클라이언트의 쓰기를 읽을 수 있도록 모든 요청에 전달한다 (Read-your-writes)
+//
+func (client *Client) SessionToken() string {
+
+	client.sessionMutex.Lock()
+	defer client.sessionMutex.Unlock()
+
+	return client.sessionToken
+}
+
+// response : 서버 응답 (Body 는 모두 읽은 상태)
+type response struct {
+	statusCode int
+	body       []byte
+}
+
+// do : 서버에 요청, 일시적인 에러는 Backoff 후 재시도
+// 400 이상의 응답은 *Error
+//
+func (client *Client) do(ctx context.Context, method string, path string, requestData interface{}) (*response, error) {
+	return client.doWithHeader(ctx, method, path, nil, requestData)
+}
+
+// doWithHeader : @header 를 추가한 do
+//
+func (client *Client) doWithHeader(ctx context.Context, method string, path string, header http.Header, requestData interface{}) (*response, error) {
+
+	var requestBody []byte
+	if requestData != nil {
+		encodedData, err := json.Marshal(requestData)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = encodedData
+	}
+
+	for attempt := 0; ; attempt++ {
+
+		result, retryAfter, err := client.doOnce(ctx, method, path, header, requestBody)
+		if err == nil {
+			return result, nil
+		}
+
+		if !isRetryable(ctx, method, err) || attempt >= client.config.MaxRetries {
+			if failure, isConnectionError := err.(*connectionError); isConnectionError {
+				return nil, failure.err
+			}
+			return nil, err
+		}
+
+		if err := sleep(ctx, client.backoff(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (client *Client) doOnce(ctx context.Context, method string, path string, header http.Header, requestBody []byte) (*response, time.Duration, error) {
+
+	var body io.Reader
+	if requestBody != nil {
+		body = bytes.NewReader(requestBody)
+	}
+
+	request, err := http.NewRequest(method, client.baseURL+path, body)
+	if err != nil {
+		return nil, 0, err
+	}
+	request = request.WithContext(ctx)
+
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	if client.config.Namespace != "" {
+		request.Header.Set("X-Namespace", client.config.Namespace)
+	}
+
+	if client.config.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+client.config.Token)
+	}
+
+	if client.config.WriteAck != "" && method != http.MethodGet && method != http.MethodHead {
+		request.Header.Set(WriteAckHeader, client.config.WriteAck)
+	}
+
+	if sessionToken := client.SessionToken(); sessionToken != "" {
+		request.Header.Set(SessionTokenHeader, sessionToken)
+	}
+
+	// 요청 헤더를 쓰기 시작했으면 서버가 처리했을 수 있다
+	var isSent int32
+	request = request.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteHeaders: func() {
+			atomic.StoreInt32(&isSent, 1)
+		},
+	}))
+
+	res, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, 0, &connectionError{err: err, isSent: atomic.LoadInt32(&isSent) == 1}
+	}
+	defer res.Body.Close()
+
+	if client.slots != nil {
+		client.slots.observeEpoch(res.Header.Get(TopologyEpochHeader))
+	}
+
+	if sessionToken := res.Header.Get(SessionTokenHeader); sessionToken != "" {
+		client.sessionMutex.Lock()
+		client.sessionToken = sessionToken
+		client.sessionMutex.Unlock()
+	}
+
+	responseBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, &connectionError{err: err, isSent: true}
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, retryAfterOf(res), decodeError(res.StatusCode, responseBody)
+	}
+
+	return &response{statusCode: res.StatusCode, body: responseBody}, 0, nil
+}
+
+// decodeError : 서버의 에러 응답 Body (ErrorTemplate) => *Error
+//
+func decodeError(statusCode int, body []byte) *Error {
+
+	var errorResponse struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	serverError := &Error{StatusCode: statusCode}
+
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		serverError.Code = errorResponse.Error.Code
+		serverError.Message = errorResponse.Error.Message
+	}
+
+	return serverError
+}
+
+// connectionError : 응답을 받지 못한 요청, isSent 가 false 이면 서버에 요청이 전달되지 않았다
+type connectionError struct {
+	err    error
+	isSent bool
+}
+
+func (err *connectionError) Error() string {
+	return err.err.Error()
+}
+
+// isRetryable : 연결 에러, 429, 502 ~ 504 (요청이 취소 / 만료된 경우 제외)
+// 쓰기 요청 (GET, HEAD 외) 은 이미 반영되었을 수 있으므로 서버가 처리하지 않은 것이 확실한 경우만
+//   - 요청을 보내기 전의 연결 에러
+//   - 429, 처리 전에 거절한 503 (SERVER_OVERLOADED, CIRCUIT_OPEN, NODE_DOWN)
+//
+func isRetryable(ctx context.Context, method string, err error) bool {
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	isReadOnly := method == http.MethodGet || method == http.MethodHead
+
+	switch failure := err.(type) {
+	case *Error:
+		if isReadOnly {
+			return failure.isRetryable()
+		}
+		return failure.isRejectedBeforeWrite()
+
+	case *connectionError:
+		return isReadOnly || !failure.isSent
+
+	default:
+		return false
+	}
+}
+
+// backoff : MinBackoff * 2^attempt (MaxBackoff 이하) 의 50 ~ 100% , Retry-After 가 더 길면 Retry-After
+//
+func (client *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+
+	delay := client.config.MinBackoff << uint(attempt)
+	if delay <= 0 || delay > client.config.MaxBackoff {
+		delay = client.config.MaxBackoff
+	}
+
+	delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+	if retryAfter > delay {
+		return retryAfter
+	}
+
+	return delay
+}
+
+func retryAfterOf(res *http.Response) time.Duration {
+
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
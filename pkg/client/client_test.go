@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient : 테스트가 끝나면 반환된 서버를 Close()
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+
+	server := httptest.NewServer(handler)
+
+	testClient, err := New(Config{
+		BaseURL:    server.URL,
+		Token:      "secret",
+		Namespace:  "team-a",
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	return testClient, server
+}
+
+func TestGet(t *testing.T) {
+
+	testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/hash/data/a%2Fb" && req.URL.RawPath != "/hash/data/a%2Fb" {
+			t.Errorf("unexpected path : %s", req.URL.Path)
+		}
+		if req.Header.Get("Authorization") != "Bearer secret" || req.Header.Get("X-Namespace") != "team-a" {
+			t.Errorf("missing headers : %v", req.Header)
+		}
+		res.Write([]byte(`{"result":"hello","handled_node":"172.29.0.4:8000","message":"ok"}`))
+	})
+	defer server.Close()
+
+	value, err := testClient.Get(context.Background(), "a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value.Value != "hello" || value.NodeAddress != "172.29.0.4:8000" {
+		t.Fatalf("unexpected value : %+v", value)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+
+	testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusNotFound)
+		res.Write([]byte(`{"error":{"code":"KEY_NOT_FOUND","message":"no key"},"message":"no key"}`))
+	})
+	defer server.Close()
+
+	_, err := testClient.Get(context.Background(), "missing")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	var serverError *Error
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusNotFound || serverError.Message != "no key" {
+		t.Fatalf("unexpected error : %#v", err)
+	}
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+
+	var attempts int32
+
+	testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			res.Write([]byte(`{"error":{"code":"SERVER_OVERLOADED","message":"busy"}}`))
+			return
+		}
+		res.WriteHeader(http.StatusCreated)
+		res.Write([]byte(`{"results":[{"result":"SET k v","handled_node":"n1"}]}`))
+	})
+	defer server.Close()
+
+	isCreated, err := testClient.Put(context.Background(), "k", "v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isCreated || atomic.LoadInt32(&attempts) != 3 {
+		t.Fatalf("created : %v, attempts : %d", isCreated, attempts)
+	}
+}
+
+// 이미 반영되었을 수 있는 쓰기는 재시도하지 않는다 (조회는 재시도)
+func TestNoRetryAfterWrite(t *testing.T) {
+
+	for _, eachCase := range []struct {
+		status int
+		body   string
+	}{
+		{http.StatusServiceUnavailable, `{"error":{"code":"REPLICATION_ACK_FAILED","message":"ack"}}`},
+		{http.StatusGatewayTimeout, `{"error":{"code":"REDIS_TIMEOUT","message":"timeout"}}`},
+	} {
+		var attempts int32
+
+		testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			res.WriteHeader(eachCase.status)
+			res.Write([]byte(eachCase.body))
+		})
+
+		if _, err := testClient.Put(context.Background(), "k", "v"); err == nil {
+			t.Fatalf("HTTP %d : expected error", eachCase.status)
+		}
+		if atomic.LoadInt32(&attempts) != 1 {
+			t.Fatalf("HTTP %d : write must not be retried, %d attempts", eachCase.status, attempts)
+		}
+
+		atomic.StoreInt32(&attempts, 0)
+		testClient.Get(context.Background(), "k")
+
+		isReplicationAck := eachCase.status == http.StatusServiceUnavailable
+		if retried := atomic.LoadInt32(&attempts) > 1; retried == isReplicationAck {
+			t.Fatalf("HTTP %d : unexpected read retry, %d attempts", eachCase.status, attempts)
+		}
+
+		server.Close()
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+
+	var attempts int32
+
+	testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		res.WriteHeader(http.StatusBadRequest)
+		res.Write([]byte(`{"error":{"code":"QUOTA_EXCEEDED","message":"full"}}`))
+	})
+	defer server.Close()
+
+	_, err := testClient.Set(context.Background(), KeyValue{Key: "k", Value: "v"})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Fatalf("client errors must not be retried : %d attempts", attempts)
+	}
+}
+
+func TestExists(t *testing.T) {
+
+	testClient, server := newTestClient(t, func(res http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodHead {
+			t.Errorf("unexpected method : %s", req.Method)
+		}
+		if req.URL.Path == "/hash/data/missing" {
+			res.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	if isExist, err := testClient.Exists(context.Background(), "present"); err != nil || !isExist {
+		t.Fatalf("present : %v, %v", isExist, err)
+	}
+	if isExist, err := testClient.Exists(context.Background(), "missing"); err != nil || isExist {
+		t.Fatalf("missing : %v, %v", isExist, err)
+	}
+}
+
+func TestDirectReadFallback(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/cluster/slots":
+			res.Header().Set(TopologyEpochHeader, "5")
+			res.Write([]byte(`{"epoch":5,"hash_algorithm":"redis","slots":[` +
+				`{"start":8192,"end":16383,"master":{"address":"127.0.0.1:1"}},` +
+				`{"start":0,"end":8191,"master":{"address":"127.0.0.1:2"}}]}`))
+		default:
+			res.Header().Set(TopologyEpochHeader, "6")
+			res.Write([]byte(`{"result":"from-server","handled_node":"n1"}`))
+		}
+	}))
+	defer server.Close()
+
+	testClient, err := New(Config{BaseURL: server.URL, DirectRead: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testClient.Close()
+
+	if address, isOwned := testClient.slots.ownerOf("foo"); !isOwned || address != "127.0.0.1:1" {
+		t.Fatalf("slot of foo (12182) : %s, %v", address, isOwned)
+	}
+
+	// 노드에 연결할 수 없으면 서버로 다시 요청
+	value, err := testClient.Get(context.Background(), "foo")
+	if err != nil || value.Value != "from-server" {
+		t.Fatalf("fallback : %+v, %v", value, err)
+	}
+
+	testClient.slots.mutex.RLock()
+	isStale, epoch := testClient.slots.isStale, testClient.slots.epoch
+	testClient.slots.mutex.RUnlock()
+
+	if epoch != 5 || !isStale {
+		t.Fatalf("newer epoch / node error must mark slot map stale : epoch %d, stale %v", epoch, isStale)
+	}
+}
+
+func TestReadPreference(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(ReadPreferenceHeader) != ReadPreferReplica || req.Header.Get(MaxStalenessHeader) != "500ms" {
+			t.Errorf("missing read preference headers : %v", req.Header)
+		}
+		res.Write([]byte(`{"result":"v","handled_node":"172.29.0.7:8000","handled_role":"replica","replication_lag_ms":120}`))
+	}))
+	defer server.Close()
+
+	testClient, err := New(Config{BaseURL: server.URL, ReadPreference: ReadPreferReplica, MaxStaleness: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := testClient.Get(context.Background(), "k")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value.NodeRole != "replica" || value.ReplicationLag != 120*time.Millisecond || value.NodeAddress != "172.29.0.7:8000" {
+		t.Fatalf("unexpected value : %+v", value)
+	}
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// KeyValue : (Key, Value) 쌍
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Result : 저장 결과
+type Result struct {
+	Result string `json:"result"`
+	// NodeAddress : 요청을 처리한 레디스 노드
+	NodeAddress string `json:"handled_node"`
+}
+
+// Value : 조회 결과
+type Value struct {
+	Value string
+	// NodeAddress : 값을 읽은 레디스 노드
+	NodeAddress string
+	// NodeRole : 값을 읽은 노드의 역할 (master / replica), 노드에 직접 조회한 경우 master
+	NodeRole string
+	// ReplicationLag : 슬레이브에서 읽은 경우 복제 지연
+	ReplicationLag time.Duration
+}
+
+// Get : @key 의 값, 저장되어 있지 않으면 ErrKeyNotFound
+//
+func (client *Client) Get(ctx context.Context, key string) (Value, error) {
+
+	isReadMaster := client.config.ReadPreference == "" || client.config.ReadPreference == ReadMaster
+
+	if isReadMaster && client.isDirectReadable(key) {
+		value, nodeAddress, err := client.slots.get(ctx, client.storedKey(key))
+		if err == nil {
+			return Value{Value: value, NodeAddress: nodeAddress, NodeRole: ReadMaster}, nil
+		}
+		// 노드에 없거나 에러이면 인터페이스 서버 결과를 따른다
+	}
+
+	header := http.Header{}
+	if client.config.ReadPreference != "" {
+		header.Set(ReadPreferenceHeader, client.config.ReadPreference)
+	}
+	if client.config.MaxStaleness > 0 {
+		header.Set(MaxStalenessHeader, client.config.MaxStaleness.String())
+	}
+
+	res, err := client.doWithHeader(ctx, http.MethodGet, "/hash/data/"+url.PathEscape(key), header, nil)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var getResponse struct {
+		Result
+		HandledRole      string `json:"handled_role"`
+		ReplicationLagMs int64  `json:"replication_lag_ms"`
+	}
+	if err := json.Unmarshal(res.body, &getResponse); err != nil {
+		return Value{}, err
+	}
+
+	return Value{
+		Value:          getResponse.Result.Result,
+		NodeAddress:    getResponse.NodeAddress,
+		NodeRole:       getResponse.HandledRole,
+		ReplicationLag: time.Duration(getResponse.ReplicationLagMs) * time.Millisecond,
+	}, nil
+}
+
+// Set : 여러 (Key, Value) 저장, Key 별로 저장되므로 원자적이지 않다
+//
+func (client *Client) Set(ctx context.Context, data ...KeyValue) ([]Result, error) {
+
+	requestData := struct {
+		Data []KeyValue `json:"data"`
+	}{Data: data}
+
+	res, err := client.do(ctx, http.MethodPost, "/hash/data", requestData)
+	if err != nil {
+		return nil, err
+	}
+
+	var setResponse struct {
+		Results []Result `json:"results"`
+	}
+	if err := json.Unmarshal(res.body, &setResponse); err != nil {
+		return nil, err
+	}
+
+	return setResponse.Results, nil
+}
+
+// Put : @key 의 값을 생성 또는 변경, 새로 생성된 경우 isCreated = true
+//
+func (client *Client) Put(ctx context.Context, key string, value string) (isCreated bool, err error) {
+
+	requestData := struct {
+		Value string `json:"value"`
+	}{Value: value}
+
+	res, err := client.do(ctx, http.MethodPut, "/hash/data/"+url.PathEscape(key), requestData)
+	if err != nil {
+		return false, err
+	}
+
+	return res.statusCode == http.StatusCreated, nil
+}
+
+// Exists : @key 가 저장되어 있는지 확인
+//
+func (client *Client) Exists(ctx context.Context, key string) (bool, error) {
+
+	if client.isDirectReadable(key) {
+		if isExist, err := client.slots.exists(ctx, client.storedKey(key)); err == nil && isExist {
+			return true, nil
+		}
+	}
+
+	_, err := client.do(ctx, http.MethodHead, "/hash/data/"+url.PathEscape(key), nil)
+
+	var serverError *Error
+	if errors.As(err, &serverError) && serverError.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Keys : 네임스페이스의 모든 Key
+//
+func (client *Client) Keys(ctx context.Context) ([]string, error) {
+
+	res, err := client.do(ctx, http.MethodGet, "/hash/keys", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keysResponse struct {
+		Keys []string `json:"keys"`
+	}
+	if err := json.Unmarshal(res.body, &keysResponse); err != nil {
+		return nil, err
+	}
+
+	return keysResponse.Keys, nil
+}
+
+// Export : 네임스페이스의 모든 (Key, Value)
+//
+func (client *Client) Export(ctx context.Context) ([]KeyValue, error) {
+
+	res, err := client.do(ctx, http.MethodGet, "/hash/export", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var exportResponse struct {
+		Data []KeyValue `json:"data"`
+	}
+	if err := json.Unmarshal(res.body, &exportResponse); err != nil {
+		return nil, err
+	}
+
+	return exportResponse.Data, nil
+}
+
+// isDirectReadable : DirectRead 설정 & 기본 네임스페이스에서는 다른 네임스페이스 형식 ("<name>:") 의 Key 가 아닌 경우
+// (다른 네임스페이스 Key 접근 여부는 서버가 판단)
+//
+func (client *Client) isDirectReadable(key string) bool {
+
+	if client.slots == nil {
+		return false
+	}
+
+	return client.config.Namespace != "" || !strings.Contains(key, ":")
+}
+
+// storedKey : 레디스에 저장되는 Key (네임스페이스 Prefix)
+//
+func (client *Client) storedKey(key string) string {
+
+	if client.config.Namespace == "" {
+		return key
+	}
+
+	return client.config.Namespace + ":" + key
+}
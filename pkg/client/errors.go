@@ -0,0 +1,122 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+/* 서버 에러 응답 (response.ErrorTemplate) 의 고정 에러 코드
+ * 서버의 internal/models/response 와 같은 값 (한 번 정해진 코드 값은 변경하지 않는다)
+ */
+const (
+	CodeInvalidPath    = "INVALID_PATH"
+	CodeInvalidBody    = "INVALID_BODY"
+	CodeInvalidRequest = "INVALID_REQUEST"
+	CodeKeyNotFound    = "KEY_NOT_FOUND"
+	CodeRedisCommand   = "REDIS_COMMAND_FAILED"
+	CodeClientSetup    = "CLIENT_SETUP_FAILED"
+	CodeInternal       = "INTERNAL_ERROR"
+
+	CodeNamespaceNotFound = "NAMESPACE_NOT_FOUND"
+	CodeInvalidNamespace  = "INVALID_NAMESPACE"
+	CodeInvalidKey        = "INVALID_KEY"
+	CodeQuotaExceeded     = "QUOTA_EXCEEDED"
+
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+
+	CodeRateLimited = "RATE_LIMITED"
+	CodeOverloaded  = "SERVER_OVERLOADED"
+
+	CodeReplicaUnavailable = "REPLICA_UNAVAILABLE"
+	CodeReplicationAck     = "REPLICATION_ACK_FAILED"
+
+	CodeCircuitOpen  = "CIRCUIT_OPEN"
+	CodeRedisTimeout = "REDIS_TIMEOUT"
+	CodeNodeDown     = "NODE_DOWN"
+)
+
+// errors.Is(err, client.ErrKeyNotFound) 처럼 에러 코드로 분기
+var (
+	ErrKeyNotFound        = &Error{Code: CodeKeyNotFound}
+	ErrNamespaceNotFound  = &Error{Code: CodeNamespaceNotFound}
+	ErrQuotaExceeded      = &Error{Code: CodeQuotaExceeded}
+	ErrUnauthorized       = &Error{Code: CodeUnauthorized}
+	ErrForbidden          = &Error{Code: CodeForbidden}
+	ErrRateLimited        = &Error{Code: CodeRateLimited}
+	ErrOverloaded         = &Error{Code: CodeOverloaded}
+	ErrReplicaUnavailable = &Error{Code: CodeReplicaUnavailable}
+	ErrReplicationAck     = &Error{Code: CodeReplicationAck}
+	ErrCircuitOpen        = &Error{Code: CodeCircuitOpen}
+	ErrRedisTimeout       = &Error{Code: CodeRedisTimeout}
+	ErrNodeDown           = &Error{Code: CodeNodeDown}
+)
+
+// Error : 서버의 에러 응답
+type Error struct {
+	// StatusCode : HTTP 상태 코드
+	StatusCode int
+	// Code : 고정된 에러 코드 (ex. KEY_NOT_FOUND), Body 가 없는 응답 (HEAD 등) 은 빈 문자열
+	Code string
+	// Message : 사람이 읽기 위한 에러 설명, 내용은 바뀔 수 있다
+	Message string
+}
+
+func (err *Error) Error() string {
+
+	if err.Code == "" {
+		return fmt.Sprintf("서버 에러 응답 (HTTP %d)", err.StatusCode)
+	}
+
+	return fmt.Sprintf("서버 에러 응답 (HTTP %d) [%s] %s", err.StatusCode, err.Code, err.Message)
+}
+
+// Is : 에러 코드가 같으면 같은 에러 (errors.Is)
+//
+func (err *Error) Is(target error) bool {
+
+	targetError, isError := target.(*Error)
+	if !isError {
+		return false
+	}
+
+	return targetError.Code != "" && targetError.Code == err.Code
+}
+
+// isRetryable : 잠시 후 같은 요청이 성공할 수 있는 응답 (조회 요청 기준)
+// REPLICATION_ACK_FAILED 는 마스터에 이미 반영된 결과이므로 재시도하지 않는다
+//
+func (err *Error) isRetryable() bool {
+
+	if err.Code == CodeReplicationAck {
+		return false
+	}
+
+	switch err.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRejectedBeforeWrite : 서버가 레디스에 명령을 보내기 전에 거절한 응답 (쓰기 요청도 재시도 가능)
+// 502, 504 와 그 외 503 은 쓰기가 이미 반영되었을 수 있다
+//
+func (err *Error) isRejectedBeforeWrite() bool {
+
+	if err.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if err.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+
+	switch err.Code {
+	case CodeOverloaded, CodeCircuitOpen, CodeNodeDown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hash_interface/internal/hash"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+/* 해쉬 슬롯 맵 (DirectRead)
+ * 인터페이스 서버의 GET /cluster/slots 로 슬롯 구간 -> 마스터 주소를 받아 캐시하고,
+ * 조회 요청을 담당 마스터에 직접 보낸다. (저장은 데이터 로그 / 슬레이브 전파를 위해 항상 인터페이스 서버로)
+ * 다음 경우 맵을 다시 조회한다.
+ *   - 서버 응답의 X-Topology-Epoch 가 캐시한 맵의 Epoch 보다 큰 경우
+ *   - 노드 연결 에러 / MOVED / ASK 응답
+ *   - SlotRefreshInterval 경과
+ */
+
+// maxIdleNodeConns : 노드 별 유지하는 유휴 연결 수
+const maxIdleNodeConns = 8
+
+var (
+	// errNotDirect : 슬롯 담당 노드가 없음
+	errNotDirect = errors.New("client : 직접 조회할 노드 없음")
+	// errDirectMiss : 노드에 Key 가 없음 (슬롯 이동 중일 수 있으므로 서버에 다시 확인)
+	errDirectMiss = errors.New("client : 노드에 Key 없음")
+)
+
+// slotRange : [start, end] 구간의 마스터
+type slotRange struct {
+	start  uint16
+	end    uint16
+	master string
+}
+
+type slotMap struct {
+	client *Client
+	config Config
+
+	mutex  sync.RWMutex
+	ranges []slotRange
+	epoch  uint64
+	// hashAlgorithm : 서버의 해쉬 슬롯 계산 방식 (응답에 없으면 legacy)
+	hashAlgorithm string
+	refreshedAt   time.Time
+	isStale       bool
+
+	// refreshMutex : 동시에 한 번만 갱신, isRefreshing : 백그라운드 갱신 중 (1)
+	refreshMutex sync.Mutex
+	isRefreshing int32
+
+	poolMutex sync.Mutex
+	pools     map[string]*redis.Pool
+}
+
+func newSlotMap(client *Client) *slotMap {
+	return &slotMap{
+		client: client,
+		config: client.config,
+		pools:  make(map[string]*redis.Pool),
+	}
+}
+
+// refresh : GET /cluster/slots 로 슬롯 맵 다시 조회
+//
+func (slots *slotMap) refresh(ctx context.Context) error {
+
+	slots.refreshMutex.Lock()
+	defer slots.refreshMutex.Unlock()
+
+	res, err := slots.client.do(ctx, http.MethodGet, "/cluster/slots", nil)
+	if err != nil {
+		slots.markStale()
+		return err
+	}
+
+	var slotsResponse struct {
+		Epoch         uint64 `json:"epoch"`
+		HashAlgorithm string `json:"hash_algorithm"`
+		Slots         []struct {
+			Start  uint16 `json:"start"`
+			End    uint16 `json:"end"`
+			Master struct {
+				Address string `json:"address"`
+			} `json:"master"`
+		} `json:"slots"`
+	}
+	if err := json.Unmarshal(res.body, &slotsResponse); err != nil {
+		slots.markStale()
+		return err
+	}
+
+	ranges := make([]slotRange, 0, len(slotsResponse.Slots))
+	for _, eachSlots := range slotsResponse.Slots {
+		ranges = append(ranges, slotRange{
+			start:  eachSlots.Start,
+			end:    eachSlots.End,
+			master: eachSlots.Master.Address,
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	slots.mutex.Lock()
+	slots.ranges = ranges
+	slots.epoch = slotsResponse.Epoch
+	slots.hashAlgorithm = slotsResponse.HashAlgorithm
+	slots.refreshedAt = time.Now()
+	slots.isStale = false
+	slots.mutex.Unlock()
+
+	return nil
+}
+
+// observeEpoch : 서버 응답의 토폴로지 Epoch 가 캐시한 맵보다 크면 갱신하도록 표시
+//
+func (slots *slotMap) observeEpoch(header string) {
+
+	epoch, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return
+	}
+
+	slots.mutex.Lock()
+	if epoch > slots.epoch {
+		slots.isStale = true
+	}
+	slots.mutex.Unlock()
+}
+
+// markStale : 다음 요청에서 슬롯 맵 갱신
+func (slots *slotMap) markStale() {
+	slots.mutex.Lock()
+	slots.isStale = true
+	slots.mutex.Unlock()
+}
+
+// ownerOf : @storedKey 의 해쉬 슬롯 담당 마스터, 맵이 오래되었으면 백그라운드에서 갱신
+//
+func (slots *slotMap) ownerOf(storedKey string) (string, bool) {
+
+	slots.mutex.RLock()
+	needRefresh := slots.isStale || time.Since(slots.refreshedAt) > slots.config.SlotRefreshInterval
+	ranges := slots.ranges
+	hashAlgorithm := slots.hashAlgorithm
+	slots.mutex.RUnlock()
+
+	slot := hash.SlotOf(hashAlgorithm, storedKey)
+
+	if needRefresh && atomic.CompareAndSwapInt32(&slots.isRefreshing, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&slots.isRefreshing, 0)
+			slots.refresh(context.Background())
+		}()
+	}
+
+	index := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].end >= slot
+	})
+	if index == len(ranges) || ranges[index].start > slot {
+		return "", false
+	}
+
+	return ranges[index].master, true
+}
+
+// get : 담당 마스터에서 직접 GET
+//
+func (slots *slotMap) get(ctx context.Context, storedKey string) (string, string, error) {
+
+	reply, address, err := slots.do(ctx, storedKey, "GET")
+	if err != nil {
+		return "", address, err
+	}
+
+	value, err := redis.String(reply, nil)
+	if err == redis.ErrNil {
+		return "", address, errDirectMiss
+	}
+
+	return value, address, err
+}
+
+// exists : 담당 마스터에서 직접 EXISTS
+//
+func (slots *slotMap) exists(ctx context.Context, storedKey string) (bool, error) {
+
+	reply, _, err := slots.do(ctx, storedKey, "EXISTS")
+	if err != nil {
+		return false, err
+	}
+
+	return redis.Bool(reply, nil)
+}
+
+// do : @storedKey 하나를 인자로 하는 명령을 담당 마스터에 전송
+// 연결 에러 / MOVED / ASK 응답이면 슬롯 맵을 갱신하도록 표시
+//
+func (slots *slotMap) do(ctx context.Context, storedKey string, command string) (interface{}, string, error) {
+
+	address, isOwned := slots.ownerOf(storedKey)
+	if !isOwned {
+		return nil, "", errNotDirect
+	}
+
+	timeout := defaultDialTimeout
+	if deadline, isSet := ctx.Deadline(); isSet {
+		if timeout = time.Until(deadline); timeout <= 0 {
+			return nil, address, context.DeadlineExceeded
+		}
+	}
+
+	conn := slots.poolOf(address).Get()
+	defer conn.Close()
+
+	reply, err := redis.DoWithTimeout(conn, timeout, command, storedKey)
+	if err != nil {
+		if _, isRedisError := err.(redis.Error); !isRedisError ||
+			strings.HasPrefix(err.Error(), "MOVED ") || strings.HasPrefix(err.Error(), "ASK ") {
+			slots.markStale()
+		}
+		return nil, address, err
+	}
+
+	return reply, address, nil
+}
+
+// poolOf : 노드 별 연결 Pool
+//
+func (slots *slotMap) poolOf(address string) *redis.Pool {
+
+	slots.poolMutex.Lock()
+	defer slots.poolMutex.Unlock()
+
+	if pool, isExist := slots.pools[address]; isExist {
+		return pool
+	}
+
+	pool := &redis.Pool{
+		MaxIdle:     maxIdleNodeConns,
+		IdleTimeout: 5 * time.Minute,
+		Dial: func() (redis.Conn, error) {
+			if slots.config.DialNode != nil {
+				return slots.config.DialNode(address)
+			}
+
+			options := []redis.DialOption{redis.DialConnectTimeout(defaultDialTimeout)}
+			if slots.config.TLSConfig != nil {
+				options = append(options, redis.DialUseTLS(true), redis.DialTLSConfig(slots.config.TLSConfig))
+			}
+			return redis.Dial("tcp", address, options...)
+		},
+	}
+
+	slots.pools[address] = pool
+
+	return pool
+}
+
+func (slots *slotMap) close() {
+
+	slots.poolMutex.Lock()
+	defer slots.poolMutex.Unlock()
+
+	for address, eachPool := range slots.pools {
+		eachPool.Close()
+		delete(slots.pools, address)
+	}
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	// TLS 설정 환경변수
+	TLSCertFileEnv = "TLS_CERT_FILE"
+	TLSKeyFileEnv  = "TLS_KEY_FILE"
+	// TLSCAFileEnv : 상대방 (모니터 서버 / 인터페이스 서버) 인증서를 검증할 CA
+	TLSCAFileEnv = "TLS_CA_FILE"
+)
+
+// TLSFiles : 인증서 / 키 / CA 파일 경로
+type TLSFiles struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+// GetTLSFilesFromEnv : 환경변수에서 TLS 파일 경로 읽기
+func GetTLSFilesFromEnv() TLSFiles {
+	return TLSFiles{
+		CertFile: os.Getenv(TLSCertFileEnv),
+		KeyFile:  os.Getenv(TLSKeyFileEnv),
+		CAFile:   os.Getenv(TLSCAFileEnv),
+	}
+}
+
+// IsEnabled : 인증서와 키가 모두 설정된 경우에만 TLS 사용
+func (files TLSFiles) IsEnabled() bool {
+	return files.CertFile != "" && files.KeyFile != ""
+}
+
+// ServerConfig : HTTPS 리스너 설정
+// @requireClientCert 이면 CA 로 서명된 클라이언트 인증서 필수 (mutual TLS)
+//
+func (files TLSFiles) ServerConfig(requireClientCert bool) (*tls.Config, error) {
+
+	certificate, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if requireClientCert {
+		caPool, err := files.loadCAPool()
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.ClientCAs = caPool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// ClientConfig : 상대 서버 인증서를 CA 로 검증하고, 자신의 인증서를 제시하는 설정
+// CA 가 설정되지 않은 경우 시스템 CA 사용
+//
+func (files TLSFiles) ClientConfig() (*tls.Config, error) {
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if files.IsEnabled() {
+		certificate, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if files.CAFile != "" {
+		caPool, err := files.loadCAPool()
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = caPool
+	}
+
+	return tlsConfig, nil
+}
+
+func (files TLSFiles) loadCAPool() (*x509.CertPool, error) {
+
+	if files.CAFile == "" {
+		return nil, fmt.Errorf("%s 가 설정되지 않았습니다", TLSCAFileEnv)
+	}
+
+	caCertificate, err := ioutil.ReadFile(files.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCertificate) {
+		return nil, fmt.Errorf("CA 파일(%s)에 PEM 인증서가 없습니다", files.CAFile)
+	}
+
+	return caPool, nil
+}